@@ -0,0 +1,121 @@
+package gosheets
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// archiveCellFields are the cell fields copied by ArchiveRowsWhere.
+const archiveCellFields = "userEnteredValue,userEnteredFormat"
+
+// ArchiveRowsWhere moves the rows of the current set sheet in the GoogleSheetsClient struct whose cell in a given
+// column matches a predicate to another sheet, e.g., to move the log entries older than a cutoff to an archive tab.
+// The cells are copied as entered, along with their formats, so numbers, dates and formulas keep their type; the
+// formulas are copied as is, so their relative references are not adjusted to the new rows. The rows are first
+// appended to the destination sheet, which is created if it does not exist, and then deleted from the current sheet
+// bottom-up in one or more batch updates (see deleteRowNumbers), so a failure never loses data: at worst some rows
+// are in both sheets. The protected header rows (see ProtectHeaderRows) are never moved.
+//
+// Parameters:
+//   - column: The column letter of the cells passed to the predicate (e.g., "A").
+//   - predicate: The function that reports whether the row of a cell must be moved. Empty cells are passed as nil.
+//   - destSheet: The name of the sheet to move the rows to.
+//
+// Returns:
+//   - The number of rows moved, which includes the rows deleted before a deletion failed.
+//   - A *PartialArchiveError with the rows that were appended but not deleted if a deletion failed, or an error if
+//     there was a problem reading or appending the rows, nil otherwise.
+func (gs *GoogleSheetsClient) ArchiveRowsWhere(column string, predicate func(interface{}) bool, destSheet string) (int64, error) {
+	gs = gs.startOperation() // The calls below share the retry budget of the method
+
+	err := validateClientFields(gs)
+	if err != nil {
+		return 0, err
+	}
+
+	if destSheet == "" || destSheet == gs.sheetName {
+		return 0, fmt.Errorf("invalid destination sheet %q, it must be another sheet of the spreadsheet", destSheet)
+	}
+
+	err = gs.requireScope(writeScopes...)
+	if err != nil {
+		return 0, err
+	}
+
+	properties, err := gs.getSheetProperties()
+	if err != nil {
+		return 0, fmt.Errorf("unable to retrieve sheet ID: %w", err)
+	}
+
+	cells, err := gs.readColumn(column, 1)
+	if err != nil {
+		return 0, err
+	}
+
+	var rowNumbers []int64
+	for i := gs.headerRowCount(properties); i < int64(len(cells)); i++ {
+		var value interface{}
+		if !isEmptyCell(cells[i]) {
+			value = cells[i][0]
+		}
+		if predicate(value) {
+			rowNumbers = append(rowNumbers, i+1)
+		}
+	}
+
+	if len(rowNumbers) == 0 {
+		return 0, nil
+	}
+
+	first, last := rowNumbers[0], rowNumbers[len(rowNumbers)-1]
+	block, err := gs.getGridData(fmt.Sprintf("%d:%d", first, last), "rowData(values("+archiveCellFields+"))")
+	if err != nil {
+		return 0, fmt.Errorf("unable to read the rows to archive: %w", err)
+	}
+
+	rows := make([]*sheets.RowData, len(rowNumbers))
+	for i, rowNumber := range rowNumbers {
+		rows[i] = &sheets.RowData{}
+		if index := rowNumber - first; index < int64(len(block.RowData)) {
+			rows[i] = block.RowData[index]
+		}
+	}
+
+	err = gs.ensureSheet(destSheet)
+	if err != nil {
+		return 0, err
+	}
+
+	destSheetID, err := gs.sheetIDOf(destSheet)
+	if err != nil {
+		return 0, err
+	}
+
+	batchUpdateRequest := &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{{
+			AppendCells: &sheets.AppendCellsRequest{SheetId: destSheetID, Rows: rows, Fields: archiveCellFields},
+		}},
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	_, err = gs.service.Spreadsheets.BatchUpdate(gs.spreadsheetID, batchUpdateRequest).Context(ctx).Do()
+	if err != nil {
+		return 0, fmt.Errorf("unable to append the rows to %s, no row was archived: %w", destSheet, gs.apiError(err))
+	}
+
+	deleted, err := gs.deleteRowNumbers(properties.SheetId, rowNumbers)
+	if err != nil {
+		// The rows are deleted bottom-up, so the ones left are at the start of rowNumbers
+		return int64(deleted), &PartialArchiveError{DestSheet: destSheet, Rows: rowNumbers[:len(rowNumbers)-deleted], Err: err}
+	}
+
+	if first == 1 {
+		gs.invalidateHeaders() // The header row was moved
+	}
+	gs.audit("ArchiveRowsWhere", gs.sheetName, fmt.Sprintf("rows %v", rowNumbers), fmt.Sprintf("%d rows moved to %s", len(rowNumbers), destSheet))
+	return int64(len(rowNumbers)), nil
+}
@@ -0,0 +1,160 @@
+package gosheets
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+func TestArchiveRowsWhere(t *testing.T) {
+	before2024 := func(value interface{}) bool {
+		date, ok := value.(string)
+		return ok && date < "2024"
+	}
+
+	// Test cases
+	tests := []struct {
+		name           string
+		destSheet      string
+		seedDest       bool
+		failDelete     bool
+		deletesBefore  int
+		wantMoved      int64
+		wantSource     [][]interface{}
+		wantDest       [][]interface{}
+		wantPartialErr []int64
+		wantErr        bool
+	}{
+		{
+			name:       "Destination created",
+			destSheet:  "Archive",
+			wantMoved:  2,
+			wantSource: [][]interface{}{{"Date", "Event"}, {"2024-01-05", "b"}, {"2024-02-01", "d"}},
+			wantDest:   [][]interface{}{{"2023-12-30", "a"}, {"2023-11-02", "c"}},
+		},
+		{
+			name:       "Existing destination",
+			destSheet:  "Archive",
+			seedDest:   true,
+			wantMoved:  2,
+			wantSource: [][]interface{}{{"Date", "Event"}, {"2024-01-05", "b"}, {"2024-02-01", "d"}},
+			wantDest:   [][]interface{}{{"Date", "Event"}, {"2023-12-30", "a"}, {"2023-11-02", "c"}},
+		},
+		{
+			name:           "Deletion fails",
+			destSheet:      "Archive",
+			seedDest:       true,
+			failDelete:     true,
+			wantSource:     [][]interface{}{{"Date", "Event"}, {"2023-12-30", "a"}, {"2024-01-05", "b"}, {"2023-11-02", "c"}, {"2024-02-01", "d"}},
+			wantDest:       [][]interface{}{{"Date", "Event"}, {"2023-12-30", "a"}, {"2023-11-02", "c"}},
+			wantPartialErr: []int64{2, 4},
+			wantErr:        true,
+		},
+		{
+			name:           "Deletion fails after a batch",
+			destSheet:      "Archive",
+			seedDest:       true,
+			failDelete:     true,
+			deletesBefore:  1,
+			wantMoved:      1,
+			wantSource:     [][]interface{}{{"Date", "Event"}, {"2023-12-30", "a"}, {"2024-01-05", "b"}, {"2024-02-01", "d"}},
+			wantDest:       [][]interface{}{{"Date", "Event"}, {"2023-12-30", "a"}, {"2023-11-02", "c"}},
+			wantPartialErr: []int64{2},
+			wantErr:        true,
+		},
+		{
+			name:      "Destination is the source",
+			destSheet: "Log",
+			wantErr:   true,
+		},
+	}
+
+	// One deletion request per batch update, so the scattered rows are deleted in several batch updates
+	maxRequests := maxDeleteRequests
+	maxDeleteRequests = 1
+	t.Cleanup(func() { maxDeleteRequests = maxRequests })
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.reset()
+			t.Cleanup(fake.reset)
+			fake.seedSheet("Log", [][]interface{}{
+				{"Date", "Event"},
+				{"2023-12-30", "a"},
+				{"2024-01-05", "b"},
+				{"2023-11-02", "c"},
+				{"2024-02-01", "d"},
+			})
+			if tt.seedDest {
+				fake.seedSheet("Archive", [][]interface{}{{"Date", "Event"}})
+			}
+			resetClient()
+			client.SetSheetName("Log")
+			client.ProtectHeaderRows(1)
+			t.Cleanup(func() { client.ProtectHeaderRows(-1) })
+			if tt.failDelete {
+				fake.deleteErr = &fakeError{http.StatusInternalServerError, "Internal error encountered."}
+				fake.deleteErrAfter = tt.deletesBefore
+			}
+
+			moved, err := client.ArchiveRowsWhere("A", before2024, tt.destSheet)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ArchiveRowsWhere() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if moved != tt.wantMoved {
+				t.Errorf("ArchiveRowsWhere() = %v, want %v", moved, tt.wantMoved)
+			}
+
+			var partialErr *PartialArchiveError
+			if errors.As(err, &partialErr) != (tt.wantPartialErr != nil) {
+				t.Fatalf("ArchiveRowsWhere() error = %v, want a PartialArchiveError: %v", err, tt.wantPartialErr != nil)
+			}
+			if partialErr != nil && !reflect.DeepEqual(partialErr.Rows, tt.wantPartialErr) {
+				t.Errorf("PartialArchiveError.Rows = %v, want %v", partialErr.Rows, tt.wantPartialErr)
+			}
+
+			if tt.wantSource == nil {
+				return
+			}
+			if got := fake.sheet("Log").values; !reflect.DeepEqual(got, tt.wantSource) {
+				t.Errorf("ArchiveRowsWhere() left %v in the source sheet, want %v", got, tt.wantSource)
+			}
+			if got := fake.sheet("Archive").values; !reflect.DeepEqual(got, tt.wantDest) {
+				t.Errorf("ArchiveRowsWhere() left %v in the destination sheet, want %v", got, tt.wantDest)
+			}
+		})
+	}
+}
+
+func TestArchiveRowsWhereKeepsCellTypes(t *testing.T) {
+	fake.reset()
+	t.Cleanup(fake.reset)
+	fake.seedSheet("Sales 2023", [][]interface{}{
+		{"Region", "Amount", "Double"},
+		{"North", 1234.5, "=B2*2"},
+		{"South", 10.0, "=B3*2"},
+	})
+	currencyFormat := &sheets.CellFormat{NumberFormat: &sheets.NumberFormat{Type: "CURRENCY", Pattern: "#,##0.00 €"}}
+	fake.sheet("Sales 2023").setCellData(1, 1, func(cell *sheets.CellData) { cell.UserEnteredFormat = currencyFormat })
+	resetClient()
+	client.SetSheetName("Sales 2023")
+	client.ProtectHeaderRows(1)
+	t.Cleanup(func() { client.ProtectHeaderRows(-1) })
+
+	moved, err := client.ArchiveRowsWhere("A", func(value interface{}) bool { return value == "North" }, "Archive")
+	if err != nil || moved != 1 {
+		t.Fatalf("ArchiveRowsWhere() = %v, %v, want 1, nil", moved, err)
+	}
+
+	archive := fake.sheet("Archive")
+	want := [][]interface{}{{"North", 1234.5, "=B2*2"}}
+	if !reflect.DeepEqual(archive.values, want) {
+		t.Errorf("ArchiveRowsWhere() left %v in the destination sheet, want %v", archive.values, want)
+	}
+	if cell := archive.cells[[2]int64{0, 1}]; cell == nil || !reflect.DeepEqual(cell.UserEnteredFormat, currencyFormat) {
+		t.Errorf("ArchiveRowsWhere() did not copy the number format of the moved cells: %+v", cell)
+	}
+}
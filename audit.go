@@ -0,0 +1,34 @@
+package gosheets
+
+import (
+	"fmt"
+	"time"
+)
+
+// audit appends a row describing a successful write to the audit sheet set with WithAuditLog. Writes to the audit
+// sheet itself are not logged, so logging never logs itself. A failure to log is reported to the warning hook
+// instead of being returned, so it never fails the write.
+//
+// Parameters:
+//   - operation: The name of the method that made the write (e.g., "AppendData").
+//   - sheetName: The name of the sheet that was written.
+//   - target: The range or row that was written (e.g., "A1" or "row 7").
+//   - summary: A short description of the change (e.g., "3 rows"), without the written values.
+func (gs *GoogleSheetsClient) audit(operation, sheetName, target, summary string) {
+	if gs.auditSheet == "" || sheetName == gs.auditSheet {
+		return
+	}
+
+	row := []interface{}{time.Now().UTC().Format(time.RFC3339), gs.actor, operation, sheetName, target, summary}
+	err := gs.appendToSheet(gs.auditSheet, [][]interface{}{row}, "A1", ValueInput(InputRaw)) // The actor is never a formula
+	if err != nil {
+		gs.warn(fmt.Errorf("unable to log %s on %s to the audit sheet %s: %w", operation, sheetName, gs.auditSheet, err))
+	}
+}
+
+// warn reports a problem that does not fail an operation to the hook set with WithWarningHook, if any.
+func (gs *GoogleSheetsClient) warn(err error) {
+	if gs.warningHook != nil {
+		gs.warningHook(err)
+	}
+}
@@ -0,0 +1,91 @@
+package gosheets
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWithAuditLog(t *testing.T) {
+	fake.reset()
+	t.Cleanup(fake.reset)
+	fake.seedSheet("Audit", nil)
+
+	var warnings []error
+	gs, err := NewGoogleSheetsClient(testCredentials, WithEndpoint(testEndpoint), WithAuditLog("Audit"),
+		WithActor("billing-service"), WithWarningHook(func(err error) { warnings = append(warnings, err) }))
+	if err != nil {
+		t.Fatalf("NewGoogleSheetsClient() error = %v", err)
+	}
+	gs.SetSpreadsheetID("SPREADSHEET_ID")
+	gs.SetSheetName("Sheet1")
+
+	// Test cases, run in order against the same spreadsheet
+	tests := []struct {
+		name         string
+		operation    func() error
+		wantAdded    int
+		wantLog      []interface{}
+		wantWarnings int
+		wantErr      bool
+	}{
+		{
+			name:      "Append is logged",
+			operation: func() error { return gs.AppendData([][]interface{}{{"a"}, {"b"}}, "A1") },
+			wantAdded: 1,
+			wantLog:   []interface{}{"billing-service", "AppendData", "Sheet1", "A1", "2 rows"},
+		},
+		{
+			name:      "Clear is logged",
+			operation: func() error { return gs.ClearRow(3) },
+			wantAdded: 1,
+			wantLog:   []interface{}{"billing-service", "ClearRow", "Sheet1", "row 3", "values cleared"},
+		},
+		{
+			name:      "Writes to the audit sheet are not logged",
+			operation: func() error { return gs.AppendDataToSheet("Audit", [][]interface{}{{"manual"}}, "A1") },
+			wantAdded: 1, // Only the appended row
+		},
+		{
+			name:      "Failed writes are not logged",
+			operation: func() error { return gs.ClearRow(0) },
+			wantErr:   true,
+		},
+		{
+			name: "Audit failures are reported to the hook",
+			operation: func() error {
+				gs.auditSheet = "Missing"
+				defer func() { gs.auditSheet = "Audit" }()
+				return gs.AppendData([][]interface{}{{"c"}}, "A1")
+			},
+			wantWarnings: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings = nil
+			before := len(fake.sheet("Audit").values)
+
+			err := tt.operation()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("operation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(warnings) != tt.wantWarnings {
+				t.Errorf("warnings = %v, want %d", warnings, tt.wantWarnings)
+			}
+
+			log := fake.sheet("Audit").values
+			if len(log) != before+tt.wantAdded {
+				t.Fatalf("audit sheet has %d rows, want %d", len(log), before+tt.wantAdded)
+			}
+			if tt.wantLog == nil {
+				return
+			}
+
+			entry := log[len(log)-1]
+			if entry[0] == "" || !reflect.DeepEqual(entry[1:], tt.wantLog) {
+				t.Errorf("audit row = %v, want a timestamp followed by %v", entry, tt.wantLog)
+			}
+		})
+	}
+}
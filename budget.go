@@ -0,0 +1,108 @@
+package gosheets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// operationBudget is the retry budget of a single call of a method that makes several API calls in a row (see
+// WithRetryBudget), shared by all the attempts of the API calls the method makes, retries included, and by the ones
+// of the methods it calls.
+//
+//   - The maxAttempts field is the number of attempts the operation may make, or 0 for no limit.
+//   - The start field is the time the operation started.
+//   - The deadline field is the time the operation must end by, or the zero time for no limit.
+//   - The attempts field counts the attempts made so far.
+type operationBudget struct {
+	mu          sync.Mutex
+	maxAttempts int
+	start       time.Time
+	deadline    time.Time
+	attempts    int
+}
+
+// startOperation returns the client that the calls of an exported method making several API calls in a row must be
+// made with, so they share a retry budget (see WithRetryBudget). The returned client is a copy of gs with a new
+// budget, or gs itself if the client has no retry budget or the method was called by another one that has a budget
+// already.
+//
+// Returns:
+//   - The client to make the calls of the method with.
+func (gs *GoogleSheetsClient) startOperation() *GoogleSheetsClient {
+	if gs.operation != nil || (gs.retryAttempts <= 0 && gs.retryElapsed <= 0) {
+		return gs
+	}
+
+	budget := &operationBudget{maxAttempts: gs.retryAttempts, start: time.Now()}
+	if gs.retryElapsed > 0 {
+		budget.deadline = budget.start.Add(gs.retryElapsed)
+	}
+
+	op := *gs
+	op.operation = budget
+	return &op
+}
+
+// context returns the context to make an API call of the operation with: one that carries the budget, so the
+// retry transport draws the attempts of the call from it, and that expires when the operation runs out of time or
+// at the timeout of the call, whichever comes first.
+//
+// Parameters:
+//   - ctx: The context of the API call.
+//   - timeout: The default timeout of the client, applied when ctx has no deadline of its own.
+//
+// Returns:
+//   - The context to use for the API call and the function that releases its resources.
+func (b *operationBudget) context(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx = withOperationBudget(ctx, b)
+
+	deadline := b.deadline
+	if _, ok := ctx.Deadline(); !ok && timeout > 0 {
+		if callDeadline := time.Now().Add(timeout); deadline.IsZero() || callDeadline.Before(deadline) {
+			deadline = callDeadline
+		}
+	}
+	if deadline.IsZero() {
+		return ctx, func() {}
+	}
+	return context.WithDeadline(ctx, deadline)
+}
+
+// spend counts an attempt against the budget.
+//
+// Returns:
+//   - An error wrapping ErrRetryBudgetExhausted if the operation made all the attempts of its budget already, in
+//     which case the attempt must not be made, nil otherwise.
+func (b *operationBudget) spend() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.maxAttempts > 0 && b.attempts >= b.maxAttempts {
+		return fmt.Errorf("%w: %d attempts made in %v", ErrRetryBudgetExhausted, b.attempts, time.Since(b.start).Round(time.Millisecond))
+	}
+	b.attempts++
+	return nil
+}
+
+// budgetError wraps the error of an API call with the reason the retry budget of the operation was exhausted, if
+// the call failed because the operation ran out of time.
+//
+// Parameters:
+//   - err: The error of the API call.
+//
+// Returns:
+//   - An error wrapping both ErrRetryBudgetExhausted and err if the budget was exhausted, err otherwise.
+func (gs *GoogleSheetsClient) budgetError(err error) error {
+	if gs.operation == nil || gs.operation.deadline.IsZero() || errors.Is(err, ErrRetryBudgetExhausted) ||
+		!errors.Is(err, context.DeadlineExceeded) || time.Now().Before(gs.operation.deadline) {
+		return err
+	}
+
+	gs.operation.mu.Lock()
+	defer gs.operation.mu.Unlock()
+	elapsed := time.Since(gs.operation.start).Round(time.Millisecond)
+	return fmt.Errorf("%w: %v elapsed in %d attempts: %w", ErrRetryBudgetExhausted, elapsed, gs.operation.attempts, err)
+}
@@ -0,0 +1,83 @@
+package gosheets
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWithRetryBudget(t *testing.T) {
+	data := [][]interface{}{{"a"}, {"b"}, {"c"}, {"d"}}
+
+	// Test cases
+	tests := []struct {
+		name        string
+		maxAttempts int
+		maxElapsed  time.Duration
+		delay       time.Duration
+		wantErr     bool
+	}{
+		{
+			name:        "Enough budget",
+			maxAttempts: 100,
+			maxElapsed:  10 * time.Second,
+		},
+		{
+			name:        "Attempts exhausted",
+			maxAttempts: 2,
+			wantErr:     true,
+		},
+		{
+			name:       "Time exhausted",
+			maxElapsed: 100 * time.Millisecond,
+			delay:      40 * time.Millisecond,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.reset()
+			t.Cleanup(fake.reset)
+			fake.seedSheet("Queue", [][]interface{}{{"Item"}})
+			fake.delay = tt.delay
+			t.Cleanup(func() {
+				// Let the calls abandoned at the deadline reach the fake before the next test
+				fake.delay = 0
+				time.Sleep(tt.delay)
+			})
+
+			gs, err := NewGoogleSheetsClient(testCredentials, WithEndpoint(testEndpoint), WithRetryBudget(tt.maxAttempts, tt.maxElapsed))
+			if err != nil {
+				t.Fatalf("NewGoogleSheetsClient() error = %v", err)
+			}
+			gs.SetSpreadsheetID("SPREADSHEET_ID")
+			gs.SetSheetName("Queue")
+
+			// The budget is per call of the method, so a second call starts with a full budget
+			for range 2 {
+				err = gs.AppendDataChunked(data, "A1", 1)
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("AppendDataChunked() error = %v, wantErr %v", err, tt.wantErr)
+				}
+			}
+			if !tt.wantErr {
+				if got := len(fake.sheet("Queue").values); got != 1+2*len(data) {
+					t.Errorf("AppendDataChunked() left %d rows, want %d", got, 1+2*len(data))
+				}
+				return
+			}
+
+			var chunkErr *ChunkError
+			if !errors.As(err, &chunkErr) || !errors.Is(err, ErrRetryBudgetExhausted) {
+				t.Fatalf("AppendDataChunked() error = %v, want a *ChunkError wrapping ErrRetryBudgetExhausted", err)
+			}
+			if chunkErr.RowIndex == 0 || chunkErr.RowIndex >= len(data) {
+				t.Errorf("ChunkError.RowIndex = %d, want the budget to run out after some chunks", chunkErr.RowIndex)
+			}
+			if tt.maxAttempts > 0 && fake.callCount() > 2*tt.maxAttempts {
+				t.Errorf("AppendDataChunked() made %d API calls, want at most %d", fake.callCount(), 2*tt.maxAttempts)
+			}
+		})
+	}
+}
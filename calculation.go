@@ -0,0 +1,75 @@
+package gosheets
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// SetCalculationSettings enables or disables the iterative calculation of the spreadsheet set in the
+// GoogleSheetsClient struct. With iterative calculation enabled, formulas with circular references are evaluated
+// by repeating the calculation instead of failing with a circular dependency error, as some modeling formulas need.
+//
+// Parameters:
+//   - iterative: Whether to enable iterative calculation. When false, maxIterations and threshold are ignored.
+//   - maxIterations: The maximum number of calculation rounds (e.g., 50), greater than 0.
+//   - threshold: The convergence threshold (e.g., 0.05): the calculation stops when the results of two successive
+//     rounds differ by less than it. It must be a finite number greater than or equal to 0.
+//
+// Returns:
+//   - An error if the settings are not valid or there was a problem updating them, nil otherwise.
+func (gs *GoogleSheetsClient) SetCalculationSettings(iterative bool, maxIterations int64, threshold float64) error {
+	if gs.spreadsheetID == "" {
+		return fmt.Errorf("spreadsheet ID not set")
+	}
+
+	// Leaving the settings out of a request with them in the fields mask disables iterative calculation
+	var settings *sheets.IterativeCalculationSettings
+	if iterative {
+		if maxIterations <= 0 {
+			return fmt.Errorf("invalid maximum number of iterations %d: it must be greater than 0", maxIterations)
+		}
+		if threshold < 0 || math.IsNaN(threshold) || math.IsInf(threshold, 0) {
+			return fmt.Errorf("invalid convergence threshold %v: it must be a finite number greater than or equal to 0", threshold)
+		}
+
+		settings = &sheets.IterativeCalculationSettings{
+			MaxIterations:        maxIterations,
+			ConvergenceThreshold: threshold,
+			ForceSendFields:      []string{"ConvergenceThreshold"}, // A threshold of 0 is omitted otherwise
+		}
+	}
+
+	err := gs.requireScope(writeScopes...)
+	if err != nil {
+		return err
+	}
+
+	batchUpdateRequest := &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{
+			{
+				UpdateSpreadsheetProperties: &sheets.UpdateSpreadsheetPropertiesRequest{
+					Properties: &sheets.SpreadsheetProperties{IterativeCalculationSettings: settings},
+					Fields:     "iterativeCalculationSettings",
+				},
+			},
+		},
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	_, err = gs.service.Spreadsheets.BatchUpdate(gs.spreadsheetID, batchUpdateRequest).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("unable to set calculation settings: %w", gs.apiError(err))
+	}
+
+	summary := "iterative calculation off"
+	if iterative {
+		summary = fmt.Sprintf("iterative calculation on, %d iterations, threshold %v", maxIterations, threshold)
+	}
+	gs.audit("SetCalculationSettings", gs.sheetName, "spreadsheet", summary)
+	return nil
+}
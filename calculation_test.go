@@ -0,0 +1,95 @@
+package gosheets
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+func TestSetCalculationSettings(t *testing.T) {
+	t.Cleanup(fake.reset)
+
+	// Test cases
+	tests := []struct {
+		name          string
+		iterative     bool
+		maxIterations int64
+		threshold     float64
+		want          *sheets.IterativeCalculationSettings
+		wantErr       bool
+	}{
+		{
+			name:          "Enable iterative calculation",
+			iterative:     true,
+			maxIterations: 50,
+			threshold:     0.05,
+			want:          &sheets.IterativeCalculationSettings{MaxIterations: 50, ConvergenceThreshold: 0.05},
+		},
+		{
+			name:          "Zero threshold",
+			iterative:     true,
+			maxIterations: 10,
+			threshold:     0,
+			want:          &sheets.IterativeCalculationSettings{MaxIterations: 10},
+		},
+		{
+			name:          "Disable iterative calculation ignores the other settings",
+			iterative:     false,
+			maxIterations: -1,
+			threshold:     math.NaN(),
+			want:          nil,
+		},
+		{
+			name:          "No iterations",
+			iterative:     true,
+			maxIterations: 0,
+			threshold:     0.05,
+			wantErr:       true,
+		},
+		{
+			name:          "Negative threshold",
+			iterative:     true,
+			maxIterations: 50,
+			threshold:     -0.1,
+			wantErr:       true,
+		},
+		{
+			name:          "Infinite threshold",
+			iterative:     true,
+			maxIterations: 50,
+			threshold:     math.Inf(1),
+			wantErr:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.reset()
+			resetClient()
+
+			err := client.SetCalculationSettings(tt.iterative, tt.maxIterations, tt.threshold)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SetCalculationSettings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if len(fake.requests) != 0 {
+					t.Errorf("SetCalculationSettings() sent %d requests, want none", len(fake.requests))
+				}
+				return
+			}
+
+			if len(fake.requests) != 1 || fake.requests[0].UpdateSpreadsheetProperties == nil {
+				t.Fatalf("SetCalculationSettings() sent %v, want one UpdateSpreadsheetProperties request", fake.requests)
+			}
+			request := fake.requests[0].UpdateSpreadsheetProperties
+			if request.Fields != "iterativeCalculationSettings" {
+				t.Errorf("fields = %q, want %q", request.Fields, "iterativeCalculationSettings")
+			}
+			if got := request.Properties.IterativeCalculationSettings; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("settings = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package gosheets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// ColumnSpec declares the layout of a column for ApplyColumnSchema. The zero value of a field leaves that property
+// of the column unchanged.
+//
+//   - The Header field is the header of the column, in the first row of the sheet.
+//   - The Width field is the width of the column in pixels.
+//   - The NumberFormat field is the number format of the cells below the header (e.g., &sheets.NumberFormat{Type:
+//     "DATE", Pattern: "yyyy-mm-dd"}).
+//   - The Validation field is the data validation of the cells below the header: a dropdown or checkboxes.
+//   - The Bold field makes the text of the cells below the header bold.
+type ColumnSpec struct {
+	Header       string
+	Width        int64
+	NumberFormat *sheets.NumberFormat
+	Validation   *ColumnValidation
+	Bold         bool
+}
+
+// ColumnValidation is the data validation of a ColumnSpec. Set either a list of values or checkboxes.
+//
+//   - The Values field holds the values of a dropdown; the cells reject any other value.
+//   - The Checkbox field turns the cells into checkboxes holding TRUE and FALSE (see SetCheckboxes).
+type ColumnValidation struct {
+	Values   []string
+	Checkbox bool
+}
+
+// ApplyColumnSchema applies the layout declared for each column of the current set sheet in the GoogleSheetsClient
+// struct: the widths, number formats, validations and bold text of all the columns are set in a single batch
+// update, so the sheet never shows half of the schema. The columns are found by header (see ColumnByHeader); the
+// header row is assumed to be the first row of the sheet.
+//
+// Parameters:
+//   - schema: The layout of each column, by header. Every header must be declared at most once.
+//   - opts: Optional settings for the write (e.g., CreateMissingColumns to add the columns whose header is not in
+//     the header row).
+//
+// Returns:
+//   - An error wrapping ErrHeaderNotFound and listing every header of the schema that is not in the header row,
+//     unless CreateMissingColumns is passed, in which case nothing is changed.
+//   - An error if a spec is not valid or there was a problem applying the schema, nil otherwise.
+func (gs *GoogleSheetsClient) ApplyColumnSchema(schema []ColumnSpec, opts ...WriteOption) error {
+	options := newWriteOptions(opts)
+
+	if len(schema) == 0 {
+		return nil // Nothing to apply, avoid spending an API call
+	}
+
+	var declared []string
+	for _, spec := range schema {
+		if spec.Header == "" {
+			return fmt.Errorf("invalid column spec: the header must not be empty")
+		}
+		if slices.Contains(declared, spec.Header) {
+			return fmt.Errorf("%w: %q is declared more than once in the schema", ErrDuplicateHeader, spec.Header)
+		}
+		if spec.Width < 0 {
+			return fmt.Errorf("invalid width %d for column %q: it must not be negative", spec.Width, spec.Header)
+		}
+		if v := spec.Validation; v != nil && (v.Checkbox == (len(v.Values) > 0)) {
+			return fmt.Errorf("invalid validation for column %q: set either the dropdown values or a checkbox", spec.Header)
+		}
+		declared = append(declared, spec.Header)
+	}
+
+	err := validateClientFields(gs)
+	if err != nil {
+		return err
+	}
+
+	err = gs.requireScope(writeScopes...)
+	if err != nil {
+		return err
+	}
+
+	// The column of each spec, and the headers missing from the header row
+	columns := make([]int64, len(schema))
+	var missing []string
+	width := int64(0)
+	err = gs.withHeaders(func(headers []string) error {
+		missing = nil
+		width = int64(len(headers))
+		for i, spec := range schema {
+			columns[i] = int64(slices.Index(headers, spec.Header))
+			if columns[i] == -1 {
+				missing = append(missing, fmt.Sprintf("%q", spec.Header))
+			}
+		}
+		if len(missing) > 0 {
+			return errStaleHeaders
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errStaleHeaders) {
+		return err
+	}
+	if len(missing) > 0 && !options.createMissing {
+		return fmt.Errorf("%w: %s", ErrHeaderNotFound, strings.Join(missing, ", "))
+	}
+
+	properties, err := gs.getSheetProperties()
+	if err != nil {
+		return fmt.Errorf("unable to retrieve sheet ID: %w", err)
+	}
+	sheetID := properties.SheetId
+
+	// The missing columns are added after the last header, in the order of the schema
+	var requests []*sheets.Request
+	var newHeaders []*sheets.CellData
+	for i, spec := range schema {
+		if columns[i] == -1 {
+			columns[i] = width + int64(len(newHeaders))
+			header := spec.Header
+			newHeaders = append(newHeaders, &sheets.CellData{UserEnteredValue: &sheets.ExtendedValue{StringValue: &header}})
+		}
+	}
+	if len(newHeaders) > 0 {
+		if missingColumns := width + int64(len(newHeaders)) - properties.GridProperties.ColumnCount; missingColumns > 0 {
+			requests = append(requests, &sheets.Request{
+				AppendDimension: &sheets.AppendDimensionRequest{SheetId: sheetID, Dimension: "COLUMNS", Length: missingColumns},
+			})
+		}
+		requests = append(requests, &sheets.Request{
+			UpdateCells: &sheets.UpdateCellsRequest{
+				Range:  a1Range{StartRow: 0, EndRow: 1, StartColumn: width, EndColumn: width + int64(len(newHeaders))}.gridRange(sheetID),
+				Rows:   []*sheets.RowData{{Values: newHeaders}},
+				Fields: "userEnteredValue",
+			},
+		})
+	}
+
+	for i, spec := range schema {
+		requests = append(requests, columnSpecRequests(sheetID, columns[i], spec)...)
+	}
+
+	batchUpdateRequest := &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: requests,
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	_, err = gs.service.Spreadsheets.BatchUpdate(gs.spreadsheetID, batchUpdateRequest).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("unable to apply column schema: %w", gs.apiError(err))
+	}
+
+	if len(newHeaders) > 0 {
+		gs.invalidateHeaders() // Headers were added
+	}
+	gs.audit("ApplyColumnSchema", gs.sheetName, "header row", fmt.Sprintf("%d columns, %d added", len(schema), len(newHeaders)))
+	return nil
+}
+
+// columnSpecRequests builds the batch update requests that apply the layout of a column.
+//
+// Parameters:
+//   - sheetID: The ID of the sheet.
+//   - column: The 0-based index of the column.
+//   - spec: The layout of the column.
+//
+// Returns:
+//   - The requests, none if the spec leaves the column unchanged.
+func columnSpecRequests(sheetID, column int64, spec ColumnSpec) []*sheets.Request {
+	var requests []*sheets.Request
+
+	if spec.Width > 0 {
+		requests = append(requests, &sheets.Request{
+			UpdateDimensionProperties: &sheets.UpdateDimensionPropertiesRequest{
+				Range:      &sheets.DimensionRange{SheetId: sheetID, Dimension: "COLUMNS", StartIndex: column, EndIndex: column + 1},
+				Properties: &sheets.DimensionProperties{PixelSize: spec.Width},
+				Fields:     "pixelSize",
+			},
+		})
+	}
+
+	// The cells below the header row
+	cells := a1Range{StartRow: 1, EndRow: -1, StartColumn: column, EndColumn: column + 1}
+
+	format := &sheets.CellFormat{}
+	var fields []string
+	if spec.NumberFormat != nil {
+		format.NumberFormat = spec.NumberFormat
+		fields = append(fields, "userEnteredFormat.numberFormat")
+	}
+	if spec.Bold {
+		format.TextFormat = &sheets.TextFormat{Bold: true}
+		fields = append(fields, "userEnteredFormat.textFormat.bold")
+	}
+	if len(fields) > 0 {
+		requests = append(requests, &sheets.Request{
+			RepeatCell: &sheets.RepeatCellRequest{
+				Range:  cells.gridRange(sheetID),
+				Cell:   &sheets.CellData{UserEnteredFormat: format},
+				Fields: strings.Join(fields, ","),
+			},
+		})
+	}
+
+	if spec.Validation != nil {
+		rule := &sheets.DataValidationRule{
+			Condition: &sheets.BooleanCondition{Type: "BOOLEAN"},
+			Strict:    true,
+		}
+		if !spec.Validation.Checkbox {
+			values := make([]*sheets.ConditionValue, len(spec.Validation.Values))
+			for i, value := range spec.Validation.Values {
+				values[i] = &sheets.ConditionValue{UserEnteredValue: value}
+			}
+			rule.Condition = &sheets.BooleanCondition{Type: "ONE_OF_LIST", Values: values}
+			rule.ShowCustomUi = true // Show the values as a dropdown
+		}
+		requests = append(requests, &sheets.Request{
+			SetDataValidation: &sheets.SetDataValidationRequest{Range: cells.gridRange(sheetID), Rule: rule},
+		})
+	}
+
+	return requests
+}
@@ -0,0 +1,139 @@
+package gosheets
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+func TestApplyColumnSchema(t *testing.T) {
+	t.Cleanup(fake.reset)
+
+	data := [][]interface{}{{"Item", "Qty", "Paid"}, {"Pen", "2", "TRUE"}}
+	qty := ColumnSpec{Header: "Qty", Width: 120, NumberFormat: &sheets.NumberFormat{Type: "NUMBER", Pattern: "#,##0"}, Bold: true}
+
+	// Test cases
+	tests := []struct {
+		name        string
+		schema      []ColumnSpec
+		opts        []WriteOption
+		wantHeaders []interface{}
+		wantErr     bool
+		wantErrIs   error
+		wantInErr   []string
+	}{
+		{
+			name: "Apply the layout of every column",
+			schema: []ColumnSpec{
+				qty,
+				{Header: "Paid", Validation: &ColumnValidation{Checkbox: true}},
+				{Header: "Item", Validation: &ColumnValidation{Values: []string{"Pen", "Ink"}}},
+			},
+			wantHeaders: []interface{}{"Item", "Qty", "Paid"},
+		},
+		{
+			name:      "Unknown headers are reported together",
+			schema:    []ColumnSpec{qty, {Header: "Notes", Bold: true}, {Header: "Due", Width: 80}},
+			wantErr:   true,
+			wantErrIs: ErrHeaderNotFound,
+			wantInErr: []string{`"Notes"`, `"Due"`},
+		},
+		{
+			name:        "Create the missing columns",
+			schema:      []ColumnSpec{{Header: "Notes", Bold: true}, qty, {Header: "Due", Width: 80}},
+			opts:        []WriteOption{CreateMissingColumns()},
+			wantHeaders: []interface{}{"Item", "Qty", "Paid", "Notes", "Due"},
+		},
+		{
+			name:    "Validation with both a dropdown and a checkbox",
+			schema:  []ColumnSpec{{Header: "Paid", Validation: &ColumnValidation{Values: []string{"yes"}, Checkbox: true}}},
+			wantErr: true,
+		},
+		{
+			name:      "Header declared twice",
+			schema:    []ColumnSpec{qty, qty},
+			wantErr:   true,
+			wantErrIs: ErrDuplicateHeader,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.reset()
+			fake.seedSheet("Orders", data)
+			resetClient()
+			client.SetSheetName("Orders")
+
+			err := client.ApplyColumnSchema(tt.schema, tt.opts...)
+			if (err != nil) != tt.wantErr || (tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs)) {
+				t.Fatalf("ApplyColumnSchema() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, want := range tt.wantInErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("ApplyColumnSchema() error = %v, want it to mention %s", err, want)
+				}
+			}
+			if tt.wantErr {
+				if len(fake.requests) != 0 {
+					t.Errorf("requests = %d, want none", len(fake.requests))
+				}
+				return
+			}
+
+			sheet := fake.sheet("Orders")
+			if !reflect.DeepEqual(sheet.values[0], tt.wantHeaders) {
+				t.Errorf("header row = %v, want %v", sheet.values[0], tt.wantHeaders)
+			}
+
+			// Qty is the second column in every successful case
+			cell := sheet.cells[[2]int64{1, 1}]
+			if cell == nil || cell.UserEnteredFormat.NumberFormat == nil || cell.UserEnteredFormat.NumberFormat.Pattern != "#,##0" {
+				t.Errorf("Qty cell format = %+v, want the number format of the spec", cell)
+			} else if cell.UserEnteredFormat.TextFormat == nil || !cell.UserEnteredFormat.TextFormat.Bold {
+				t.Errorf("Qty cell is not bold")
+			}
+			if header := sheet.cells[[2]int64{0, 1}]; header != nil && header.UserEnteredFormat != nil {
+				t.Errorf("Qty header format = %+v, want the header row unchanged", header.UserEnteredFormat)
+			}
+
+			var widths []int64
+			for _, request := range fake.requests {
+				if request.UpdateDimensionProperties != nil {
+					widths = append(widths, request.UpdateDimensionProperties.Properties.PixelSize)
+				}
+			}
+			if len(widths) == 0 || widths[0] != 120 {
+				t.Errorf("column widths = %v, want 120 first", widths)
+			}
+		})
+	}
+
+	// The validations of the first case
+	fake.reset()
+	fake.seedSheet("Orders", data)
+	resetClient()
+	client.SetSheetName("Orders")
+	calls := fake.callCount()
+	err := client.ApplyColumnSchema([]ColumnSpec{
+		{Header: "Paid", Validation: &ColumnValidation{Checkbox: true}},
+		{Header: "Item", Validation: &ColumnValidation{Values: []string{"Pen", "Ink"}}},
+	})
+	if err != nil {
+		t.Fatalf("ApplyColumnSchema() error = %v", err)
+	}
+	if got := len(fake.requests); got != 2 {
+		t.Errorf("requests = %d, want 2 in a single batch", got)
+	}
+	if got := fake.callCount() - calls; got > 3 {
+		t.Errorf("API calls = %d, want at most 3 (header row, sheet properties and the batch update)", got)
+	}
+	if rule := fake.sheet("Orders").cells[[2]int64{1, 2}].DataValidation; rule == nil || rule.Condition.Type != "BOOLEAN" {
+		t.Errorf("Paid validation = %+v, want checkboxes", rule)
+	}
+	if rule := fake.sheet("Orders").cells[[2]int64{1, 0}].DataValidation; rule == nil || rule.Condition.Type != "ONE_OF_LIST" || len(rule.Condition.Values) != 2 {
+		t.Errorf("Item validation = %+v, want a dropdown of 2 values", rule)
+	}
+}
@@ -0,0 +1,379 @@
+package gosheets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// spreadsheetEpoch is the day 0 of the date serial numbers of spreadsheets.
+var spreadsheetEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// dateCellFields is the field mask of the cell data written by appendCells.
+const dateCellFields = "userEnteredValue,userEnteredFormat.numberFormat"
+
+// timeToSerial converts a time to the date serial number of spreadsheets: the number of days since 1899-12-30,
+// with the time of day as the fraction.
+//
+// Parameters:
+//   - t: The time to convert.
+//   - loc: The time zone of the spreadsheet, whose wall clock the serial number represents.
+//
+// Returns:
+//   - The date serial number of the time.
+func timeToSerial(t time.Time, loc *time.Location) float64 {
+	wall := t.In(loc)
+	asUTC := time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), wall.Nanosecond(), time.UTC)
+	return asUTC.Sub(spreadsheetEpoch).Hours() / 24
+}
+
+// serialToTime converts a date serial number of spreadsheets to a time, the inverse of timeToSerial.
+//
+// Parameters:
+//   - serial: The date serial number to convert.
+//   - loc: The time zone of the spreadsheet, whose wall clock the serial number represents.
+//
+// Returns:
+//   - The time of the serial number, rounded to the second.
+func serialToTime(serial float64, loc *time.Location) time.Time {
+	asUTC := spreadsheetEpoch.Add(time.Duration(serial * 24 * float64(time.Hour))).Round(time.Second)
+	return time.Date(asUTC.Year(), asUTC.Month(), asUTC.Day(), asUTC.Hour(), asUTC.Minute(), asUTC.Second(), 0, loc)
+}
+
+// toCellData converts a Go value to the cell data written by appendCells. time.Time values become date serial
+// numbers with a date number format; strings are written as text with InputRaw, like the values API does, and the
+// other values are converted with interfaceToExtendedValue.
+//
+// Parameters:
+//   - value: The value to convert.
+//   - loc: The time zone of the spreadsheet.
+//   - inputOption: How the values are interpreted (InputRaw or InputUserEntered).
+//
+// Returns:
+//   - The cell data of the value.
+func toCellData(value interface{}, loc *time.Location, inputOption string) *sheets.CellData {
+	if s, ok := value.(string); ok && inputOption == InputRaw {
+		return &sheets.CellData{UserEnteredValue: &sheets.ExtendedValue{StringValue: &s}} // Not a formula
+	}
+
+	t, ok := value.(time.Time)
+	if !ok {
+		return &sheets.CellData{UserEnteredValue: interfaceToExtendedValue(value)}
+	}
+
+	serial := timeToSerial(t, loc)
+	format := &sheets.NumberFormat{Type: "DATE_TIME"}
+	if wall := t.In(loc); wall.Hour() == 0 && wall.Minute() == 0 && wall.Second() == 0 && wall.Nanosecond() == 0 {
+		format.Type = "DATE"
+	}
+
+	return &sheets.CellData{
+		UserEnteredValue:  &sheets.ExtendedValue{NumberValue: &serial},
+		UserEnteredFormat: &sheets.CellFormat{NumberFormat: format},
+	}
+}
+
+// formatTimes returns a copy of data with the time.Time values replaced by their text in a given layout.
+//
+// Parameters:
+//   - data: The data to convert.
+//   - layout: The layout of the text, as accepted by time.Time.Format.
+//   - loc: The time zone the values are converted to before formatting, or nil to keep the time zone of each value.
+//   - forceText: Whether to prefix the text with an apostrophe, which makes the spreadsheet store it as text when
+//     the values are parsed (InputUserEntered) instead of converting it to a date.
+//
+// Returns:
+//   - The converted data. The rows without time.Time values are shared with data.
+func formatTimes(data [][]interface{}, layout string, loc *time.Location, forceText bool) [][]interface{} {
+	formatted := make([][]interface{}, len(data))
+	for i, row := range data {
+		formatted[i] = row
+		copied := false
+		for j, cell := range row {
+			t, ok := cell.(time.Time)
+			if !ok {
+				continue
+			}
+			if !copied {
+				formatted[i] = append([]interface{}(nil), row...) // Do not modify the data of the caller
+				copied = true
+			}
+			if loc != nil {
+				t = t.In(loc)
+			}
+			formatted[i][j] = t.Format(layout)
+			if forceText {
+				formatted[i][j] = "'" + t.Format(layout)
+			}
+		}
+	}
+	return formatted
+}
+
+// containsTime reports whether any cell of data is a time.Time value.
+func containsTime(data [][]interface{}) bool {
+	for _, row := range data {
+		for _, cell := range row {
+			if _, ok := cell.(time.Time); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// appendCells appends data after the last row with data of a given sheet with an AppendCellsRequest, writing the
+// time.Time values as dates (see WithSerialDates) in the same request as the other values.
+//
+// Parameters:
+//   - sheetName: The name of the sheet to append the data to.
+//   - data: A 2D slice representing the data to be added.
+//   - range_: The cell whose column is the first column of the appended rows (e.g., "A1").
+//   - inputOption: How the values are interpreted (InputRaw or InputUserEntered).
+//
+// Returns:
+//   - An error if there was a problem adding the data to the spreadsheet, nil otherwise.
+func (gs *GoogleSheetsClient) appendCells(sheetName string, data [][]interface{}, range_ string, inputOption string) error {
+	request, err := gs.appendCellsRequest(sheetName, data, range_, inputOption)
+	if err != nil {
+		return err
+	}
+
+	batchUpdateRequest := &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{request},
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	_, err = gs.service.Spreadsheets.BatchUpdate(gs.spreadsheetID, batchUpdateRequest).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("unable to add data to Google Sheets: %w", gs.apiError(err))
+	}
+	return nil
+}
+
+// appendCellsRequest builds the AppendCellsRequest that appends data after the last row with data of a given sheet,
+// with the time.Time values as dates, so it can be sent along with other requests in a single batch update.
+//
+// Parameters:
+//   - sheetName: The name of the sheet to append the data to.
+//   - data: A 2D slice representing the data to be added.
+//   - range_: The cell whose column is the first column of the appended rows (e.g., "A1").
+//   - inputOption: How the values are interpreted (InputRaw or InputUserEntered).
+//
+// Returns:
+//   - The request, or an error if the range is not valid or there was a problem retrieving the sheet.
+func (gs *GoogleSheetsClient) appendCellsRequest(sheetName string, data [][]interface{}, range_ string, inputOption string) (*sheets.Request, error) {
+	anchor, err := parseA1Range(range_)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, properties, err := gs.getTimeZoneAndSheet(sheetName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sheets.Request{
+		AppendCells: &sheets.AppendCellsRequest{
+			SheetId: properties.SheetId,
+			Rows:    dateRowData(data, anchor.StartColumn, loc, inputOption),
+			Fields:  dateCellFields,
+		},
+	}, nil
+}
+
+// dateRowData converts data to the rows of cell data of an AppendCellsRequest or an UpdateCellsRequest, with the
+// time.Time values as dates (see WithSerialDates).
+//
+// Parameters:
+//   - data: A 2D slice representing the data to be written.
+//   - startColumn: The 0-based index of the column of the first cell of each row; the cells before it are left
+//     empty.
+//   - loc: The time zone of the spreadsheet.
+//   - inputOption: How the values are interpreted (InputRaw or InputUserEntered).
+//
+// Returns:
+//   - The rows of cell data.
+func dateRowData(data [][]interface{}, startColumn int64, loc *time.Location, inputOption string) []*sheets.RowData {
+	rows := make([]*sheets.RowData, len(data))
+	for i, row := range data {
+		rowData := &sheets.RowData{}
+		for j := int64(0); j < startColumn; j++ {
+			rowData.Values = append(rowData.Values, &sheets.CellData{}) // The rows start at the given column
+		}
+		for _, cell := range row {
+			rowData.Values = append(rowData.Values, toCellData(cell, loc, inputOption))
+		}
+		rows[i] = rowData
+	}
+	return rows
+}
+
+// serialDateRows converts the data of a write made with WithSerialDates to rows of cell data starting at column A,
+// for the writes that cannot append it (e.g., InsertRowsAfterPosition). The other conversions of the write options
+// are applied first.
+//
+// Parameters:
+//   - data: A 2D slice representing the data to be written.
+//   - range_: The first cell the data is written to, without the sheet name (e.g., "A5").
+//   - options: The settings of the write.
+//
+// Returns:
+//   - The rows of cell data, or nil if the data holds no time.Time value, in which case it can be written as values.
+//   - An error if there was a problem converting the data or retrieving the time zone of the spreadsheet.
+func (gs *GoogleSheetsClient) serialDateRows(data [][]interface{}, range_ string, options *writeOptions) ([]*sheets.RowData, error) {
+	if options.timeLayout != "" || !containsTime(data) {
+		return nil, nil // No date to write, the values are converted when they are written
+	}
+
+	inputOption, err := gs.inputOption(options)
+	if err != nil {
+		return nil, err
+	}
+	data, err = gs.prepareValues(gs.sheetName, range_, data, options, inputOption)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, _, err := gs.getTimeZoneAndSheet(gs.sheetName)
+	if err != nil {
+		return nil, err
+	}
+	return dateRowData(data, 0, loc, inputOption), nil
+}
+
+// getTimeZoneAndSheet retrieves the time zone of the spreadsheet set in the GoogleSheetsClient struct and the
+// properties of one of its sheets, in a single request.
+//
+// Parameters:
+//   - sheetName: The name of the sheet whose properties to retrieve.
+//
+// Returns:
+//   - The time zone of the spreadsheet.
+//   - The properties of the sheet.
+//   - An error if the sheet was not found, the time zone is unknown or there was a problem retrieving them.
+func (gs *GoogleSheetsClient) getTimeZoneAndSheet(sheetName string) (*time.Location, *sheets.SheetProperties, error) {
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	spreadsheet, err := gs.service.Spreadsheets.Get(gs.spreadsheetID).Fields("properties.timeZone", "sheets.properties").Context(ctx).Do()
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to retrieve spreadsheet: %w", gs.apiError(err))
+	}
+
+	loc, err := time.LoadLocation(spreadsheet.Properties.TimeZone)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to load the time zone %q of the spreadsheet: %w", spreadsheet.Properties.TimeZone, err)
+	}
+
+	for _, sheet := range spreadsheet.Sheets {
+		if sheet.Properties.Title == sheetName {
+			return loc, sheet.Properties, nil
+		}
+	}
+
+	return nil, nil, fmt.Errorf("sheet with name %s not found", sheetName)
+}
+
+// modifiedTimeLayouts are the layouts of the text cells accepted by ReadModifiedSince, tried in order. The layouts
+// without a time zone are read in the time zone of the spreadsheet.
+var modifiedTimeLayouts = []string{time.RFC3339, time.DateTime, "2006-01-02T15:04:05", time.DateOnly}
+
+// ReadModifiedSince reads the rows of the current set sheet in the GoogleSheetsClient struct whose time in a given
+// column is after a checkpoint, for incremental syncs based on a "modified at" column.
+//
+// The time cells can hold dates (e.g., written with WithSerialDates or typed in the sheet), read in the time zone of
+// the spreadsheet, or text in RFC 3339 (as written by AppendWithTimestamp), "2006-01-02 15:04:05",
+// "2006-01-02T15:04:05" or "2006-01-02" layouts, the last three read in the time zone of the spreadsheet. The rows
+// whose time cell is empty or cannot be parsed, such as the header row, are skipped; the number of non-empty
+// cells below the first row that could not be parsed is reported to the warning hook (see WithWarningHook).
+//
+// Parameters:
+//   - timeColumn: The column letter of the times (e.g., "D").
+//   - since: The checkpoint. Only the rows with a time strictly after it are returned.
+//
+// Returns:
+//   - A 2D slice with the matching rows, as ReadData returns them, in the order of the sheet.
+//   - An error if the column is not valid or there was a problem reading the sheet, nil otherwise.
+func (gs *GoogleSheetsClient) ReadModifiedSince(timeColumn string, since time.Time) ([][]interface{}, error) {
+	err := validateClientFields(gs)
+	if err != nil {
+		return nil, err
+	}
+
+	column, row, err := parseA1Cell(timeColumn)
+	if err != nil || column == -1 || row != -1 {
+		return nil, fmt.Errorf("invalid column %q: use a column letter (e.g., \"D\")", timeColumn)
+	}
+
+	loc, _, err := gs.getTimeZoneAndSheet(gs.sheetName)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	// The times are read unformatted so dates come as serial numbers whatever the locale of the spreadsheet is
+	times, err := gs.service.Spreadsheets.Values.Get(gs.spreadsheetID, fmt.Sprintf("%s!%s:%s", quoteSheetName(gs.sheetName), timeColumn, timeColumn)).
+		ValueRenderOption("UNFORMATTED_VALUE").DateTimeRenderOption("SERIAL_NUMBER").Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve data from Google Sheets: %w", gs.apiError(err))
+	}
+
+	data, err := gs.service.Spreadsheets.Values.Get(gs.spreadsheetID, quoteSheetName(gs.sheetName)).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve data from Google Sheets: %w", gs.apiError(err))
+	}
+
+	modified := [][]interface{}{}
+	unparsed := 0
+	for i, values := range times.Values {
+		if len(values) == 0 || values[0] == "" || i >= len(data.Values) {
+			continue
+		}
+
+		t, ok := parseModifiedTime(values[0], loc)
+		if !ok {
+			if i > 0 { // The header row is expected not to hold a time
+				unparsed++
+			}
+			continue
+		}
+		if t.After(since) {
+			modified = append(modified, data.Values[i])
+		}
+	}
+
+	if unparsed > 0 {
+		gs.warn(fmt.Errorf("ReadModifiedSince skipped %d rows of sheet %s with a time that could not be parsed in column %s", unparsed, gs.sheetName, timeColumn))
+	}
+	return modified, nil
+}
+
+// parseModifiedTime converts an unformatted time cell read by ReadModifiedSince to a time.
+//
+// Parameters:
+//   - value: The value of the cell, a date serial number or a text in one of modifiedTimeLayouts.
+//   - loc: The time zone of the spreadsheet.
+//
+// Returns:
+//   - The time of the cell, and false if the cell does not hold a time.
+func parseModifiedTime(value interface{}, loc *time.Location) (time.Time, bool) {
+	switch v := value.(type) {
+	case float64:
+		return serialToTime(v, loc), true
+	case string:
+		for _, layout := range modifiedTimeLayouts {
+			if t, err := time.ParseInLocation(layout, strings.TrimSpace(v), loc); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,298 @@
+package gosheets
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTimeToSerial(t *testing.T) {
+	madrid, err := time.LoadLocation("Europe/Madrid")
+	if err != nil {
+		t.Fatalf("LoadLocation() error = %v", err)
+	}
+
+	// Test cases
+	tests := []struct {
+		name string
+		t    time.Time
+		loc  *time.Location
+		want float64
+	}{
+		{
+			name: "Epoch",
+			t:    time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC),
+			loc:  time.UTC,
+			want: 0,
+		},
+		{
+			name: "Date",
+			t:    time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+			loc:  time.UTC,
+			want: 45444,
+		},
+		{
+			name: "Date and time",
+			t:    time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC),
+			loc:  time.UTC,
+			want: 45444.5,
+		},
+		{
+			name: "Time converted to the time zone of the spreadsheet",
+			t:    time.Date(2024, 6, 1, 22, 0, 0, 0, time.UTC),
+			loc:  madrid,
+			want: 45445,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := timeToSerial(tt.t, tt.loc); got != tt.want {
+				t.Errorf("timeToSerial() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatTimes(t *testing.T) {
+	when := time.Date(2024, 6, 1, 22, 0, 0, 0, time.UTC)
+	data := [][]interface{}{{"Header1", "Header2"}, {"Value1", when}}
+
+	got := formatTimes(data, "02/01/2006 15:04", time.FixedZone("UTC+2", 2*60*60), false)
+	want := [][]interface{}{{"Header1", "Header2"}, {"Value1", "02/06/2024 00:00"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("formatTimes() = %v, want %v", got, want)
+	}
+
+	got = formatTimes(data, "02/01/2006 15:04", time.FixedZone("UTC+2", 2*60*60), true)
+	want = [][]interface{}{{"Header1", "Header2"}, {"Value1", "'02/06/2024 00:00"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("formatTimes() forcing text = %v, want %v", got, want)
+	}
+	if data[1][1] != when {
+		t.Errorf("formatTimes() modified the data, data[1][1] = %v", data[1][1])
+	}
+}
+
+func TestAppendDataTimes(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name       string
+		value      time.Time
+		opts       []WriteOption
+		wantValue  interface{}
+		wantFormat string
+	}{
+		{
+			name:       "Serial date",
+			value:      time.Date(2024, 5, 31, 22, 0, 0, 0, time.UTC), // Midnight in Europe/Madrid
+			opts:       []WriteOption{WithSerialDates()},
+			wantValue:  45444.0,
+			wantFormat: "DATE",
+		},
+		{
+			name:       "Serial date and time",
+			value:      time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC),
+			opts:       []WriteOption{WithSerialDates()},
+			wantValue:  45444.5,
+			wantFormat: "DATE_TIME",
+		},
+		{
+			name:      "Formatted text",
+			value:     time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC),
+			opts:      []WriteOption{WithTimeFormat("02/01/2006", nil)},
+			wantValue: "01/06/2024",
+		},
+		{
+			name:      "Formatted text parsed as entered",
+			value:     time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC),
+			opts:      []WriteOption{WithTimeFormat("02/01/2006", nil), UserEntered()},
+			wantValue: "01/06/2024",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.reset()
+			t.Cleanup(fake.reset)
+			resetClient()
+
+			err := client.AppendData([][]interface{}{{"Event", tt.value}}, "A1", tt.opts...)
+			if err != nil {
+				t.Fatalf("AppendData() error = %v", err)
+			}
+
+			cells, err := client.ReadRichData("A3:B3")
+			if err != nil {
+				t.Fatalf("ReadRichData() error = %v", err)
+			}
+			if len(cells) != 1 || len(cells[0]) != 2 {
+				t.Fatalf("ReadRichData() = %v, want one row with two cells", cells)
+			}
+
+			cell := cells[0][1]
+			if cell.Value != tt.wantValue {
+				t.Errorf("AppendData() wrote %v (%T), want %v (%T)", cell.Value, cell.Value, tt.wantValue, tt.wantValue)
+			}
+			format := ""
+			if cell.NumberFormat != nil {
+				format = cell.NumberFormat.Type
+			}
+			if format != tt.wantFormat {
+				t.Errorf("AppendData() wrote the number format %q, want %q", format, tt.wantFormat)
+			}
+		})
+	}
+}
+
+func TestAppendDataSerialDatesInputOption(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name        string
+		opts        []WriteOption
+		wantFormula bool
+	}{
+		{
+			name: "Raw strings stay text",
+			opts: []WriteOption{WithSerialDates()},
+		},
+		{
+			name:        "Entered strings parsed as formulas",
+			opts:        []WriteOption{WithSerialDates(), UserEntered()},
+			wantFormula: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.reset()
+			t.Cleanup(fake.reset)
+			resetClient()
+
+			err := client.AppendData([][]interface{}{{"=1+1", time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)}}, "A1", tt.opts...)
+			if err != nil {
+				t.Fatalf("AppendData() error = %v", err)
+			}
+
+			if len(fake.requests) != 1 || fake.requests[0].AppendCells == nil {
+				t.Fatalf("AppendData() sent %v, want a single AppendCells request", fake.requests)
+			}
+			value := fake.requests[0].AppendCells.Rows[0].Values[0].UserEnteredValue
+			if got := value.FormulaValue != nil; got != tt.wantFormula {
+				t.Errorf("AppendData() sent a formula = %v, want %v", got, tt.wantFormula)
+			}
+			if !tt.wantFormula && (value.StringValue == nil || *value.StringValue != "=1+1") {
+				t.Errorf("AppendData() sent %+v, want the string \"=1+1\"", value)
+			}
+		})
+	}
+}
+
+func TestReadModifiedSince(t *testing.T) {
+	fake.reset()
+	t.Cleanup(fake.reset)
+	// A sheet name that needs quotes in A1 notation
+	fake.seedSheet("Changes 2024", [][]interface{}{
+		{"ID", "Modified at"},
+		{"1", "2024-06-01T10:00:00Z"},
+		{"2", 45444.5},               // 2024-06-01 12:00 in Europe/Madrid, 10:00 UTC
+		{"3", "2024-06-02 09:00:00"}, // Europe/Madrid, 07:00 UTC
+		{"4", "yesterday"},
+		{"5"},
+		{"6", "2024-05-01"},
+	})
+
+	var warnings []error
+	gs, err := NewGoogleSheetsClient(testCredentials, WithEndpoint(testEndpoint), WithWarningHook(func(err error) {
+		warnings = append(warnings, err)
+	}))
+	if err != nil {
+		t.Fatalf("NewGoogleSheetsClient() error = %v", err)
+	}
+	gs.SetSpreadsheetID("SPREADSHEET_ID")
+	gs.SetSheetName("Changes 2024")
+
+	// Test cases
+	tests := []struct {
+		name       string
+		timeColumn string
+		since      time.Time
+		want       [][]interface{}
+		wantErr    bool
+	}{
+		{
+			name:       "Rows after the checkpoint",
+			timeColumn: "B",
+			since:      time.Date(2024, 6, 1, 9, 59, 59, 0, time.UTC),
+			want:       [][]interface{}{{"1", "2024-06-01T10:00:00Z"}, {"2", "45444.5"}, {"3", "2024-06-02 09:00:00"}},
+		},
+		{
+			name:       "Rows at the checkpoint are excluded",
+			timeColumn: "B",
+			since:      time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC),
+			want:       [][]interface{}{{"3", "2024-06-02 09:00:00"}},
+		},
+		{
+			name:       "No rows after the checkpoint",
+			timeColumn: "B",
+			since:      time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			want:       [][]interface{}{},
+		},
+		{
+			name:       "Invalid column",
+			timeColumn: "B2",
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings = nil
+
+			got, err := gs.ReadModifiedSince(tt.timeColumn, tt.since)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadModifiedSince() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadModifiedSince() = %v, want %v", got, tt.want)
+			}
+			// Only the "yesterday" cell is reported, not the header or the empty cell
+			if len(warnings) != 1 {
+				t.Errorf("ReadModifiedSince() reported %d warnings (%v), want 1", len(warnings), warnings)
+			}
+		})
+	}
+}
+
+func TestInsertRowsAfterPositionSerialDates(t *testing.T) {
+	fake.reset()
+	t.Cleanup(fake.reset)
+	fake.seedSheet("Log", [][]interface{}{{"Event", "When"}, {"a", 1.0}, {"b", 2.0}})
+
+	resetClient()
+	client.SetSheetName("Log")
+
+	midnight := time.Date(2024, 5, 31, 22, 0, 0, 0, time.UTC) // Midnight in Europe/Madrid
+	err := client.InsertRowsAfterPosition([][]interface{}{{"new", midnight}}, 1, WithSerialDates())
+	if err != nil {
+		t.Fatalf("InsertRowsAfterPosition() error = %v", err)
+	}
+
+	// The row lands right after the position, not after the last row of the sheet
+	want := [][]interface{}{{"Event", "When"}, {"new", 45444.0}, {"a", 1.0}, {"b", 2.0}}
+	if got := fake.sheet("Log").values; !reflect.DeepEqual(got, want) {
+		t.Errorf("values = %v, want %v", got, want)
+	}
+
+	cells, err := client.ReadRichData("B2")
+	if err != nil {
+		t.Fatalf("ReadRichData() error = %v", err)
+	}
+	if format := cells[0][0].NumberFormat; format == nil || format.Type != "DATE" {
+		t.Errorf("number format = %+v, want a DATE format", format)
+	}
+}
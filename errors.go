@@ -0,0 +1,434 @@
+package gosheets
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"golang.org/x/oauth2"
+	"google.golang.org/api/googleapi"
+)
+
+var (
+	// ErrInvalidCredentials is returned when the service account key cannot be parsed or used to sign token requests.
+	ErrInvalidCredentials = errors.New("invalid service account credentials")
+
+	// ErrCredentialsRevoked is returned when Google rejects the service account key, usually because it expired,
+	// was deleted or was revoked.
+	ErrCredentialsRevoked = errors.New("service account credentials expired or revoked")
+
+	// ErrAPINotEnabled is returned when the Google Sheets API is not enabled for the project that owns the credentials.
+	ErrAPINotEnabled = errors.New("google sheets API not enabled for the project")
+
+	// ErrQuotaProjectDenied is returned when the credentials are not allowed to bill the API usage to the quota project
+	// set with WithQuotaProject. Grant them the Service Usage Consumer role on that project.
+	ErrQuotaProjectDenied = errors.New("credentials not allowed to use the quota project")
+
+	// ErrNotShared is returned when the spreadsheet has not been shared with the service account.
+	ErrNotShared = errors.New("spreadsheet not shared with the service account")
+
+	// ErrDelegationNotAuthorized is returned when the service account is not allowed to impersonate the subject
+	// set with WithImpersonationSubject.
+	ErrDelegationNotAuthorized = errors.New("service account not authorized to impersonate the subject")
+
+	// ErrSpreadsheetNotFound is returned when the spreadsheet ID does not match any spreadsheet.
+	ErrSpreadsheetNotFound = errors.New("spreadsheet not found")
+
+	// ErrEmptyData is returned when a method that searches the data read from the sheet receives no data, which
+	// usually means the ReadData call that produced it returned nothing (e.g., an empty sheet or a wrong range).
+	ErrEmptyData = errors.New("empty data: ReadData likely returned no rows")
+
+	// ErrHeaderProtected is returned when a row insertion or deletion targets the protected header rows of the
+	// sheet (see ProtectHeaderRows) and the Force option was not passed.
+	ErrHeaderProtected = errors.New("header rows are protected")
+
+	// ErrHeaderNotFound is returned when no cell of the header row of the sheet matches the header a method
+	// searches for.
+	ErrHeaderNotFound = errors.New("header not found")
+
+	// ErrDuplicateHeader is returned when a change of the header row would leave two columns with the same header.
+	ErrDuplicateHeader = errors.New("duplicate header")
+
+	// ErrInvalidRange is returned when the API rejects a range, either because it is not valid A1 notation or because
+	// it names a sheet that does not exist.
+	ErrInvalidRange = errors.New("invalid range")
+
+	// ErrRowNotFound is returned when no row of the sheet matches the value a method searches for.
+	ErrRowNotFound = errors.New("row not found")
+
+	// ErrNamedRangeNotFound is returned when the spreadsheet has no named range with the name a method was given.
+	ErrNamedRangeNotFound = errors.New("named range not found")
+
+	// ErrDuplicateKey is returned when the rows passed to a keyed write (e.g., BulkUpsert) hold the same key more than once.
+	ErrDuplicateKey = errors.New("duplicate key")
+
+	// ErrMissingScope is returned when a method needs an OAuth2 scope the client was not created with.
+	// Use errors.As with a *MissingScopeError to get the name of the required scope.
+	ErrMissingScope = errors.New("missing OAuth2 scope")
+
+	// ErrReferenced is returned when a deletion is refused because formulas reference the cells it would delete (see
+	// WithReferenceCheck). Use errors.As with a *ReferenceError to get the referencing cells.
+	ErrReferenced = errors.New("cells are referenced by formulas")
+
+	// ErrProtected is returned when a write is refused because it would change protected cells the credentials
+	// cannot edit (see WithProtectionCheck). Use errors.As with a *ProtectedRangeError to get the protected ranges.
+	ErrProtected = errors.New("cells are protected")
+
+	// ErrRetryBudgetExhausted is returned when a method stops because it made all the attempts of its API calls or
+	// spent all the time of its retry budget (see WithRetryBudget). The error of the method reports the work done
+	// before it.
+	ErrRetryBudgetExhausted = errors.New("retry budget exhausted")
+)
+
+// MissingScopeError is returned when a method needs an OAuth2 scope the client was not created with.
+//
+//   - The Scope field is the scope the client must be created with (see WithScopes).
+type MissingScopeError struct {
+	Scope string
+}
+
+func (e *MissingScopeError) Error() string {
+	return fmt.Sprintf("missing OAuth2 scope %s, create the client with WithScopes(%q)", e.Scope, e.Scope)
+}
+
+// Is makes errors.Is(err, ErrMissingScope) report true for a *MissingScopeError.
+func (e *MissingScopeError) Is(target error) bool {
+	return target == ErrMissingScope
+}
+
+// ReferenceError is returned when a deletion is refused because formulas reference the cells it would delete (see
+// WithReferenceCheck).
+//
+//   - The Target field is the range that was not deleted, in A1 notation.
+//   - The Cells field is the locations of the formula cells that reference it.
+type ReferenceError struct {
+	Target string
+	Cells  []CellLocation
+}
+
+func (e *ReferenceError) Error() string {
+	cells := make([]string, len(e.Cells))
+	for i, cell := range e.Cells {
+		cells[i] = cell.A1()
+	}
+	return fmt.Sprintf("%s is referenced by the formulas of %s", e.Target, strings.Join(cells, ", "))
+}
+
+// Is makes errors.Is(err, ErrReferenced) report true for a *ReferenceError.
+func (e *ReferenceError) Is(target error) bool {
+	return target == ErrReferenced
+}
+
+// ProtectedRangeError is returned when a write is refused because it would change protected cells the credentials
+// cannot edit (see WithProtectionCheck).
+//
+//   - The Target field is the target of the write that was not made (e.g., "Sheet1!A2:C10" or "Sheet1!7:7").
+//   - The Ranges field is the protected ranges the write overlaps.
+type ProtectedRangeError struct {
+	Target string
+	Ranges []ProtectedRangeInfo
+}
+
+func (e *ProtectedRangeError) Error() string {
+	ranges := make([]string, len(e.Ranges))
+	for i, r := range e.Ranges {
+		ranges[i] = r.A1()
+		if r.Description != "" {
+			ranges[i] += fmt.Sprintf(" (%q)", r.Description)
+		}
+	}
+	return fmt.Sprintf("%s overlaps the protected ranges %s", e.Target, strings.Join(ranges, ", "))
+}
+
+// Is makes errors.Is(err, ErrProtected) report true for a *ProtectedRangeError.
+func (e *ProtectedRangeError) Is(target error) bool {
+	return target == ErrProtected
+}
+
+// classifyTokenError maps an error returned while fetching an OAuth2 token to one of the credential errors.
+//
+// Parameters:
+//   - err: The error returned by the token source.
+//
+// Returns:
+//   - An error wrapping both the matching sentinel error and the original error.
+func classifyTokenError(err error) error {
+	var retrieveErr *oauth2.RetrieveError
+	if errors.As(err, &retrieveErr) {
+		switch retrieveErr.ErrorCode {
+		case "invalid_grant", "invalid_client":
+			return fmt.Errorf("%w: %w", ErrCredentialsRevoked, err)
+		case "unauthorized_client":
+			return fmt.Errorf("%w: check that domain-wide delegation is enabled for the service account "+
+				"and its scopes are authorized in the Google Workspace admin console: %w", ErrDelegationNotAuthorized, err)
+		}
+		return fmt.Errorf("unable to fetch token: %w", err)
+	}
+
+	// Errors that are not token endpoint responses come from signing the token request, which
+	// means the private key in the credentials is malformed.
+	return fmt.Errorf("%w: %w", ErrInvalidCredentials, err)
+}
+
+// classifyAPIError maps an error returned by the Google Sheets API to one of the sentinel errors of this package.
+//
+// Parameters:
+//   - err: The error returned by the API call.
+//   - email: The service account email, included in the error message when the spreadsheet is not shared with it.
+//
+// Returns:
+//   - An error wrapping both the matching sentinel error and the original error, or err unchanged if it is not recognized.
+func classifyAPIError(err error, email string) error {
+	// Token errors reach the API calls wrapped by the HTTP transport.
+	var retrieveErr *oauth2.RetrieveError
+	if errors.As(err, &retrieveErr) {
+		return classifyTokenError(err)
+	}
+
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+
+	switch apiErr.Code {
+	case http.StatusBadRequest:
+		if isUnparsableRange(err) {
+			return fmt.Errorf("%w: %w", ErrInvalidRange, err)
+		}
+	case http.StatusUnauthorized:
+		return fmt.Errorf("%w: %w", ErrCredentialsRevoked, err)
+	case http.StatusNotFound:
+		return fmt.Errorf("%w: %w", ErrSpreadsheetNotFound, err)
+	case http.StatusForbidden:
+		if isAPINotEnabled(apiErr) {
+			return fmt.Errorf("%w: %w", ErrAPINotEnabled, err)
+		}
+		if isQuotaProjectDenied(apiErr) {
+			return fmt.Errorf("%w: %w", ErrQuotaProjectDenied, err)
+		}
+		if email == "" {
+			return fmt.Errorf("%w: %w", ErrNotShared, err)
+		}
+		return fmt.Errorf("%w: share the spreadsheet with %s: %w", ErrNotShared, email, err)
+	}
+
+	return err
+}
+
+// apiError classifies an error returned by the Google Sheets API, adding the service account email of the client
+// to permission-denied errors so callers can tell users which account to share the spreadsheet with.
+// For clients with a quota project (see WithQuotaProject), the errors caused by that project name it, since the API
+// does not tell it apart from the project that owns the credentials.
+//
+// A range that cannot be parsed usually names a sheet that does not exist anymore, so for clients that use the
+// first sheet (see UseFirstSheet) it also discards the resolved title, in case the first sheet was renamed.
+func (gs *GoogleSheetsClient) apiError(err error) error {
+	err = gs.budgetError(err)
+	if isUnparsableRange(err) {
+		gs.forgetFirstSheet()
+	}
+	err = classifyAPIError(err, gs.serviceAccountEmail)
+	if gs.quotaProject != "" && (errors.Is(err, ErrAPINotEnabled) || errors.Is(err, ErrQuotaProjectDenied)) {
+		return fmt.Errorf("quota project %s: %w", gs.quotaProject, err)
+	}
+	return err
+}
+
+// isUnparsableRange reports whether err is a Google API error rejecting the range of the request, which the API
+// returns for ranges that name a missing sheet.
+func isUnparsableRange(err error) bool {
+	var apiErr *googleapi.Error
+	return errors.As(err, &apiErr) && apiErr.Code == http.StatusBadRequest &&
+		strings.Contains(apiErr.Message, "Unable to parse range")
+}
+
+// isSheetNameConflict reports whether err is a Google API error rejecting a new sheet because the spreadsheet
+// already has a sheet with its name.
+func isSheetNameConflict(err error) bool {
+	var apiErr *googleapi.Error
+	return errors.As(err, &apiErr) && apiErr.Code == http.StatusBadRequest &&
+		strings.Contains(apiErr.Message, "A sheet with the name") && strings.Contains(apiErr.Message, "already exists")
+}
+
+// isAPINotEnabled reports whether a 403 error was caused by the Google Sheets API being disabled for the project.
+func isAPINotEnabled(apiErr *googleapi.Error) bool {
+	for _, item := range apiErr.Errors {
+		if item.Reason == "accessNotConfigured" {
+			return true
+		}
+	}
+
+	return strings.Contains(apiErr.Message, "SERVICE_DISABLED") ||
+		strings.Contains(apiErr.Message, "has not been used in project") ||
+		strings.Contains(apiErr.Body, "SERVICE_DISABLED")
+}
+
+// isQuotaProjectDenied reports whether a 403 error was caused by the credentials not being allowed to use the quota
+// project of the requests.
+func isQuotaProjectDenied(apiErr *googleapi.Error) bool {
+	for _, item := range apiErr.Errors {
+		if item.Reason == "USER_PROJECT_DENIED" {
+			return true
+		}
+	}
+
+	return strings.Contains(apiErr.Body, "USER_PROJECT_DENIED") ||
+		strings.Contains(apiErr.Message, "does not have required permission to use project")
+}
+
+// StatusCode returns the HTTP status of the Google Sheets API response that caused an error, for quick checks such
+// as StatusCode(err) == http.StatusTooManyRequests. The error may be wrapped any number of times.
+//
+// Parameters:
+//   - err: The error returned by a method of the client.
+//
+// Returns:
+//   - The HTTP status code (e.g., 403 or 429), or 0 if err is nil or was not caused by an API response.
+func StatusCode(err error) int {
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Code
+	}
+	return 0
+}
+
+// ChunkError is returned by chunked operations when one of the chunks fails. The rows before RowIndex were written.
+//
+//   - The RowIndex field is the 0-based index in the input data of the first row of the chunk that failed.
+//   - The Err field is the error returned for the chunk.
+type ChunkError struct {
+	RowIndex int
+	Err      error
+}
+
+func (e *ChunkError) Error() string {
+	return fmt.Sprintf("unable to write the chunk starting at row index %d (%d rows written): %v", e.RowIndex, e.RowIndex, e.Err)
+}
+
+func (e *ChunkError) Unwrap() error {
+	return e.Err
+}
+
+// ItemError is the failure of a single item of a batch operation.
+//
+//   - The Item field identifies the item that failed: a range, a sheet name, a spreadsheet ID or a row number.
+//   - The Err field is the error returned for the item.
+type ItemError struct {
+	Item string
+	Err  error
+}
+
+func (e *ItemError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Item, e.Err)
+}
+
+func (e *ItemError) Unwrap() error {
+	return e.Err
+}
+
+// MultiError is returned by batch operations when some of their items fail. It records the failure of every item,
+// so callers can retry only the failed ones, and supports errors.Is and errors.As on the errors of the items.
+//
+//   - The Errors field holds the failures in the order they happened.
+type MultiError struct {
+	Errors []*ItemError
+}
+
+// Add records the failure of an item. Nil errors are ignored.
+//
+// Parameters:
+//   - item: The identifier of the item (e.g., "Sheet1!A1:B2", "Sheet2" or "row 7").
+//   - err: The error returned for the item.
+func (e *MultiError) Add(item string, err error) {
+	if err != nil {
+		e.Errors = append(e.Errors, &ItemError{Item: item, Err: err})
+	}
+}
+
+// Items returns the identifiers of the failed items, in the order they failed.
+func (e *MultiError) Items() []string {
+	items := make([]string, len(e.Errors))
+	for i, itemErr := range e.Errors {
+		items[i] = itemErr.Item
+	}
+	return items
+}
+
+// ErrorOrNil returns e if it recorded at least one failure, nil otherwise. Use it to return a MultiError from a
+// function without returning a non-nil error interface holding an empty MultiError.
+func (e *MultiError) ErrorOrNil() error {
+	if e == nil || len(e.Errors) == 0 {
+		return nil
+	}
+	return e
+}
+
+func (e *MultiError) Error() string {
+	if len(e.Errors) == 1 {
+		return "1 item failed: " + e.Errors[0].Error()
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("%d items failed:", len(e.Errors)))
+	for _, itemErr := range e.Errors {
+		result.WriteString("\n  - " + itemErr.Error())
+	}
+	return result.String()
+}
+
+// Unwrap returns the errors of the items, so errors.Is and errors.As match any of them.
+func (e *MultiError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, itemErr := range e.Errors {
+		errs[i] = itemErr
+	}
+	return errs
+}
+
+// SchemaError is returned by ValidateSchema when the header row of the sheet does not match the headers declared
+// by a struct.
+//
+//   - The Missing field holds the headers declared by the struct that are not in the header row.
+//   - The Extra field holds the headers of the header row that the struct does not declare.
+//   - The OutOfOrder field holds the headers in both whose column is not in the order of the fields of the struct.
+type SchemaError struct {
+	Missing    []string
+	Extra      []string
+	OutOfOrder []string
+}
+
+func (e *SchemaError) Error() string {
+	var problems []string
+	if len(e.Missing) > 0 {
+		problems = append(problems, fmt.Sprintf("missing columns %q", e.Missing))
+	}
+	if len(e.Extra) > 0 {
+		problems = append(problems, fmt.Sprintf("extra columns %q", e.Extra))
+	}
+	if len(e.OutOfOrder) > 0 {
+		problems = append(problems, fmt.Sprintf("out-of-order columns %q", e.OutOfOrder))
+	}
+	return "header row does not match the schema: " + strings.Join(problems, ", ")
+}
+
+// PartialArchiveError is returned by ArchiveRowsWhere when the rows were appended to the destination sheet but some
+// of them could not be deleted from the source sheet, so they are in both sheets. The rows deleted before the failure
+// are counted by the number of rows returned along with the error.
+//
+//   - The DestSheet field is the name of the sheet the rows were appended to.
+//   - The Rows field holds the 1-based numbers of the rows in the source sheet that were appended but not deleted.
+//   - The Err field is the error returned by the deletion.
+type PartialArchiveError struct {
+	DestSheet string
+	Rows      []int64
+	Err       error
+}
+
+func (e *PartialArchiveError) Error() string {
+	return fmt.Sprintf("rows %v were appended to %s but not deleted: %v", e.Rows, e.DestSheet, e.Err)
+}
+
+func (e *PartialArchiveError) Unwrap() error {
+	return e.Err
+}
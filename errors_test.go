@@ -0,0 +1,232 @@
+package gosheets
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/oauth2"
+	"google.golang.org/api/googleapi"
+)
+
+func TestClassifyAPIError(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name         string
+		err          error
+		email        string
+		want         error
+		wantContains string
+	}{
+		{
+			name:  "Unauthorized",
+			err:   &googleapi.Error{Code: http.StatusUnauthorized},
+			email: "bot@project.iam.gserviceaccount.com",
+			want:  ErrCredentialsRevoked,
+		},
+		{
+			name:  "Spreadsheet not found",
+			err:   &googleapi.Error{Code: http.StatusNotFound},
+			email: "bot@project.iam.gserviceaccount.com",
+			want:  ErrSpreadsheetNotFound,
+		},
+		{
+			name: "API not enabled",
+			err: &googleapi.Error{
+				Code:    http.StatusForbidden,
+				Message: "Google Sheets API has not been used in project 123 before or it is disabled.",
+			},
+			email: "bot@project.iam.gserviceaccount.com",
+			want:  ErrAPINotEnabled,
+		},
+		{
+			name:  "Invalid range",
+			err:   &googleapi.Error{Code: http.StatusBadRequest, Message: "Unable to parse range: Missing!A1"},
+			email: "bot@project.iam.gserviceaccount.com",
+			want:  ErrInvalidRange,
+		},
+		{
+			name: "Quota project denied",
+			err: &googleapi.Error{
+				Code:    http.StatusForbidden,
+				Message: "Caller does not have required permission to use project billing-123.",
+			},
+			email: "bot@project.iam.gserviceaccount.com",
+			want:  ErrQuotaProjectDenied,
+		},
+		{
+			name:         "Spreadsheet not shared",
+			err:          &googleapi.Error{Code: http.StatusForbidden, Message: "The caller does not have permission"},
+			email:        "bot@project.iam.gserviceaccount.com",
+			want:         ErrNotShared,
+			wantContains: "bot@project.iam.gserviceaccount.com",
+		},
+		{
+			name: "Token error wrapped by the transport",
+			err:  &url.Error{Op: "Get", URL: "https://sheets.googleapis.com", Err: &oauth2.RetrieveError{ErrorCode: "unauthorized_client"}},
+			want: ErrDelegationNotAuthorized,
+		},
+		{
+			name: "Unrecognized error",
+			err:  &googleapi.Error{Code: http.StatusBadRequest},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := classifyAPIError(tt.err, tt.email)
+			if tt.want != nil && !errors.Is(got, tt.want) {
+				t.Errorf("classifyAPIError() = %v, want %v", got, tt.want)
+			}
+			if tt.want == nil && got != tt.err {
+				t.Errorf("classifyAPIError() = %v, want the original error", got)
+			}
+			if !strings.Contains(got.Error(), tt.wantContains) {
+				t.Errorf("classifyAPIError() = %v, want it to contain %q", got, tt.wantContains)
+			}
+		})
+	}
+}
+
+func TestClassifyTokenError(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{
+			name: "Revoked key",
+			err:  &oauth2.RetrieveError{ErrorCode: "invalid_grant"},
+			want: ErrCredentialsRevoked,
+		},
+		{
+			name: "Domain-wide delegation not authorized",
+			err:  &oauth2.RetrieveError{ErrorCode: "unauthorized_client"},
+			want: ErrDelegationNotAuthorized,
+		},
+		{
+			name: "Malformed private key",
+			err:  errors.New("private key should be a PEM or plain PKCS1 or PKCS8"),
+			want: ErrInvalidCredentials,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifyTokenError(tt.err); !errors.Is(got, tt.want) {
+				t.Errorf("classifyTokenError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatusCode(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{
+			name: "API error",
+			err:  &googleapi.Error{Code: http.StatusTooManyRequests},
+			want: http.StatusTooManyRequests,
+		},
+		{
+			name: "Wrapped and classified API error",
+			err:  fmt.Errorf("unable to retrieve data: %w", classifyAPIError(&googleapi.Error{Code: http.StatusForbidden}, "")),
+			want: http.StatusForbidden,
+		},
+		{
+			name: "Error without response",
+			err:  errors.New("spreadsheet ID not set"),
+			want: 0,
+		},
+		{
+			name: "Nil error",
+			err:  nil,
+			want: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StatusCode(tt.err); got != tt.want {
+				t.Errorf("StatusCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMultiError(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name      string
+		items     []string
+		errs      []error
+		wantItems []string
+		wantIs    error
+		wantNil   bool
+		wantError string
+	}{
+		{
+			name:      "Single failure",
+			items:     []string{"Sheet1!A1:B2"},
+			errs:      []error{ErrNotShared},
+			wantItems: []string{"Sheet1!A1:B2"},
+			wantIs:    ErrNotShared,
+			wantError: "1 item failed: Sheet1!A1:B2: " + ErrNotShared.Error(),
+		},
+		{
+			name:      "Multiple failures with nil errors ignored",
+			items:     []string{"Sheet1", "Sheet2", "Sheet3"},
+			errs:      []error{ErrSpreadsheetNotFound, nil, ErrAPINotEnabled},
+			wantItems: []string{"Sheet1", "Sheet3"},
+			wantIs:    ErrAPINotEnabled,
+			wantError: "2 items failed:\n  - Sheet1: " + ErrSpreadsheetNotFound.Error() + "\n  - Sheet3: " + ErrAPINotEnabled.Error(),
+		},
+		{
+			name:    "No failures",
+			items:   []string{"Sheet1"},
+			errs:    []error{nil},
+			wantNil: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			multiErr := &MultiError{}
+			for i, item := range tt.items {
+				multiErr.Add(item, tt.errs[i])
+			}
+
+			err := multiErr.ErrorOrNil()
+			if (err == nil) != tt.wantNil {
+				t.Fatalf("ErrorOrNil() = %v, wantNil %v", err, tt.wantNil)
+			}
+			if err == nil {
+				return
+			}
+
+			if !reflect.DeepEqual(multiErr.Items(), tt.wantItems) {
+				t.Errorf("Items() = %v, want %v", multiErr.Items(), tt.wantItems)
+			}
+			if !errors.Is(err, tt.wantIs) {
+				t.Errorf("errors.Is(%v, %v) = false, want true", err, tt.wantIs)
+			}
+			var itemErr *ItemError
+			if !errors.As(err, &itemErr) || itemErr.Item != tt.wantItems[0] {
+				t.Errorf("errors.As() item = %v, want %v", itemErr, tt.wantItems[0])
+			}
+			if err.Error() != tt.wantError {
+				t.Errorf("Error() = %q, want %q", err.Error(), tt.wantError)
+			}
+		})
+	}
+}
@@ -0,0 +1,289 @@
+package gosheets
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// DataToCSV converts a 2D slice of interface{} values to CSV text, quoting the cells that contain
+// commas, quotes or line breaks. Nil cells are written as empty fields.
+//
+// Parameters:
+//   - data: The 2D slice of interface{} values to convert.
+//
+// Returns:
+//   - A string with the CSV representation of the data.
+func DataToCSV(data [][]interface{}) string {
+	var result strings.Builder
+
+	// Writing to a strings.Builder never fails.
+	_ = writeCSV(&result, data)
+
+	return result.String()
+}
+
+// ExportRangeToCSVFile reads a range from the current set sheet in the GoogleSheetsClient struct and writes it
+// as CSV to a file, creating the file or truncating it if it already exists.
+//
+// Parameters:
+//   - readRange: The range of cells to export (e.g., "A1:B2").
+//   - path: The path of the CSV file to write.
+//
+// Returns:
+//   - An error if there was a problem reading the range or writing the file, nil otherwise.
+func (gs *GoogleSheetsClient) ExportRangeToCSVFile(readRange string, path string) error {
+	data, err := gs.ReadData(readRange)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("unable to create CSV file: %w", err)
+	}
+	defer file.Close()
+
+	err = writeCSV(file, data)
+	if err != nil {
+		return fmt.Errorf("unable to write CSV file: %w", err)
+	}
+
+	return file.Close()
+}
+
+// ExportSheetToCSVFile reads the used block of the current set sheet in the GoogleSheetsClient struct (see
+// ReadUsedRange) and writes it as CSV to a file. The CSV is written to a temporary file in the same directory, which
+// is renamed to the path once complete, so readers of the file never see it half written and a failure leaves any
+// previous file unchanged.
+//
+// Parameters:
+//   - path: The path of the CSV file to write.
+//
+// Returns:
+//   - An error if there was a problem reading the sheet or writing the file, nil otherwise.
+func (gs *GoogleSheetsClient) ExportSheetToCSVFile(path string) error {
+	data, err := gs.ReadUsedRange()
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("unable to create CSV file: %w", err)
+	}
+	defer os.Remove(file.Name()) // Fails harmlessly once the file is renamed
+	defer file.Close()
+
+	err = writeCSV(file, data)
+	if err != nil {
+		return fmt.Errorf("unable to write CSV file: %w", err)
+	}
+
+	err = file.Close()
+	if err != nil {
+		return fmt.Errorf("unable to write CSV file: %w", err)
+	}
+
+	err = os.Rename(file.Name(), path)
+	if err != nil {
+		return fmt.Errorf("unable to replace CSV file: %w", err)
+	}
+	return nil
+}
+
+// LoadCSVFileToSheet loads a CSV file into the current set sheet in the GoogleSheetsClient struct, appending its
+// records as rows in chunks of DefaultChunkSize rows (see AppendDataChunked). The whole file is read and parsed
+// before the sheet is changed, so a missing or malformed file never leaves the sheet cleared with nothing loaded.
+//
+// Parameters:
+//   - path: The path of the CSV file to load. The records may have different numbers of fields.
+//   - replace: Whether to clear the values of the sheet before loading the file, so the file replaces its data
+//     (including the header row, which should then be the first record of the file). The formatting is kept.
+//   - opts: Optional settings for the write of the rows (e.g., InferTypes, since the CSV fields are all strings).
+//
+// Returns:
+//   - An error if there was a problem reading the file, clearing the sheet or appending the rows, nil otherwise.
+//     If a chunk fails, the error is a *ChunkError with the index of the first record that was not loaded.
+func (gs *GoogleSheetsClient) LoadCSVFileToSheet(path string, replace bool, opts ...WriteOption) error {
+	data, err := readCSVFile(path)
+	if err != nil {
+		return err
+	}
+
+	err = validateClientFields(gs)
+	if err != nil {
+		return err
+	}
+
+	err = gs.requireScope(writeScopes...)
+	if err != nil {
+		return err
+	}
+
+	if replace {
+		ctx, cancel := gs.withTimeout(context.Background())
+		defer cancel()
+
+		_, err = gs.service.Spreadsheets.Values.Clear(gs.spreadsheetID, quoteSheetName(gs.sheetName), &sheets.ClearValuesRequest{}).Context(ctx).Do()
+		if err != nil {
+			return fmt.Errorf("unable to clear sheet before loading CSV file: %w", gs.apiError(err))
+		}
+
+		gs.invalidateHeaders() // The header row was cleared
+		gs.audit("LoadCSVFileToSheet", gs.sheetName, "all rows", "values cleared")
+	}
+
+	return gs.AppendDataChunked(data, "A1", DefaultChunkSize, opts...)
+}
+
+// readCSVFile reads and parses a whole CSV file.
+//
+// Parameters:
+//   - path: The path of the CSV file to read.
+//
+// Returns:
+//   - The records of the file as a 2D slice of strings, or an error if the file cannot be read or is not valid CSV.
+func readCSVFile(path string) ([][]interface{}, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open CSV file: %w", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1 // Rows without trailing empty cells have fewer fields
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse CSV file: %w", err)
+	}
+
+	data := make([][]interface{}, len(records))
+	for i, record := range records {
+		data[i] = make([]interface{}, len(record))
+		for j, field := range record {
+			data[i][j] = field
+		}
+	}
+	return data, nil
+}
+
+// ReadAsJSON reads a range from the current set sheet in the GoogleSheetsClient struct and encodes it as JSON, e.g.,
+// to serve the data of a sheet from an HTTP handler. The cells are encoded as ReadData returns them (strings).
+//
+// Parameters:
+//   - readRange: The range of cells to read (e.g., "A1:F100" or "A:F").
+//   - useHeader: Whether the first row of the range is a header row. If true, each of the other rows is encoded as
+//     an object with a key per header, in the order of the columns, and null for the cells missing at the end of
+//     the row; cells in columns with an empty header are left out. If false, the rows are encoded as an array of
+//     arrays.
+//
+// Returns:
+//   - The JSON array of the rows, "[]" if the range has no data.
+//   - ErrDuplicateHeader if useHeader is true and two columns have the same header, or an error if there was a
+//     problem reading the range, nil otherwise.
+func (gs *GoogleSheetsClient) ReadAsJSON(readRange string, useHeader bool) ([]byte, error) {
+	if !useHeader {
+		data, err := gs.ReadData(readRange)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(data)
+	}
+
+	headers, rows, err := gs.ReadWithHeader(readRange)
+	if err != nil {
+		return nil, err
+	}
+
+	// The keys are encoded one by one to keep the order of the columns, which a map would lose
+	keys := make([][]byte, len(headers))
+	seen := make(map[string]bool, len(headers))
+	for i, header := range headers {
+		if header == "" {
+			continue
+		}
+		if seen[header] {
+			return nil, fmt.Errorf("%w: %q", ErrDuplicateHeader, header)
+		}
+		seen[header] = true
+
+		keys[i], err = json.Marshal(header)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i, row := range rows {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteByte('{')
+		first := true
+		for j, key := range keys {
+			if key == nil {
+				continue
+			}
+			var cell interface{}
+			if j < len(row) {
+				cell = row[j]
+			}
+			value, err := json.Marshal(cell)
+			if err != nil {
+				return nil, fmt.Errorf("unable to encode cell: %w", err)
+			}
+
+			if !first {
+				buf.WriteByte(',')
+			}
+			first = false
+			buf.Write(key)
+			buf.WriteByte(':')
+			buf.Write(value)
+		}
+		buf.WriteByte('}')
+	}
+	buf.WriteByte(']')
+
+	return buf.Bytes(), nil
+}
+
+// writeCSV writes a 2D slice of interface{} values as CSV records to w.
+//
+// Parameters:
+//   - w: The writer to write the CSV records to.
+//   - data: The 2D slice of interface{} values to write.
+//
+// Returns:
+//   - An error if there was a problem writing the records, nil otherwise.
+func writeCSV(w io.Writer, data [][]interface{}) error {
+	writer := csv.NewWriter(w)
+
+	for _, row := range data {
+		record := make([]string, len(row))
+		for i, cell := range row {
+			if cell != nil {
+				record[i] = fmt.Sprintf("%v", cell)
+			}
+		}
+
+		err := writer.Write(record)
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,276 @@
+package gosheets
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDataToCSV(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name string
+		data [][]interface{}
+		want string
+	}{
+		{
+			name: "Valid data (same number of columns)",
+			data: [][]interface{}{{"Value1", "Value2"}, {"Value3", 4}},
+			want: "Value1,Value2\nValue3,4\n",
+		},
+		{
+			name: "Cells that need quoting",
+			data: [][]interface{}{{"a,b", `say "hi"`, "line\nbreak"}},
+			want: "\"a,b\",\"say \"\"hi\"\"\",\"line\nbreak\"\n",
+		},
+		{
+			name: "Nil cells",
+			data: [][]interface{}{{"Value1", nil, "Value3"}},
+			want: "Value1,,Value3\n",
+		},
+		{
+			name: "Valid data (empty)",
+			data: [][]interface{}{},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DataToCSV(tt.data); got != tt.want {
+				t.Errorf("DataToCSV() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExportRangeToCSVFile(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name      string
+		sheetName string
+		readRange string
+		path      string
+		wantErr   bool
+	}{
+		{
+			name:      "Valid read range",
+			sheetName: "Sheet1",
+			readRange: "A1:B4",
+			path:      filepath.Join(t.TempDir(), "export.csv"),
+			wantErr:   false,
+		},
+		{
+			name:      "Non-existent sheet",
+			sheetName: "Sheet2",
+			readRange: "A1:B4",
+			path:      filepath.Join(t.TempDir(), "export.csv"),
+			wantErr:   true,
+		},
+		{
+			name:      "Non-existent directory",
+			sheetName: "Sheet1",
+			readRange: "A1:B4",
+			path:      filepath.Join(t.TempDir(), "missing", "export.csv"),
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetClient()
+			client.SetSheetName(tt.sheetName)
+
+			err := client.ExportRangeToCSVFile(tt.readRange, tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExportRangeToCSVFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				if _, err := os.Stat(tt.path); err != nil {
+					t.Errorf("ExportRangeToCSVFile() did not create the file: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestReadAsJSON(t *testing.T) {
+	fake.reset()
+	t.Cleanup(fake.reset)
+	fake.seedSheet("People", [][]interface{}{{"Name", "Email", "", "Age"}, {"Alice", "alice@example.com", "x", 30.0}, {"Bob"}})
+	fake.seedSheet("Duplicated", [][]interface{}{{"Name", "Name"}, {"Alice", "Bob"}})
+	fake.seedSheet("Empty", nil)
+
+	// Test cases
+	tests := []struct {
+		name      string
+		sheetName string
+		useHeader bool
+		want      string
+		wantErr   error
+	}{
+		{
+			name:      "Array of arrays",
+			sheetName: "People",
+			useHeader: false,
+			want:      `[["Name","Email","","Age"],["Alice","alice@example.com","x","30"],["Bob"]]`,
+		},
+		{
+			name:      "Array of objects in column order",
+			sheetName: "People",
+			useHeader: true,
+			want:      `[{"Name":"Alice","Email":"alice@example.com","Age":"30"},{"Name":"Bob","Email":null,"Age":null}]`,
+		},
+		{
+			name:      "Empty sheet without header",
+			sheetName: "Empty",
+			useHeader: false,
+			want:      `[]`,
+		},
+		{
+			name:      "Empty sheet with header",
+			sheetName: "Empty",
+			useHeader: true,
+			want:      `[]`,
+		},
+		{
+			name:      "Duplicate headers",
+			sheetName: "Duplicated",
+			useHeader: true,
+			wantErr:   ErrDuplicateHeader,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetClient()
+			client.SetSheetName(tt.sheetName)
+
+			got, err := client.ReadAsJSON("A:D", tt.useHeader)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ReadAsJSON() error = %v, want %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("ReadAsJSON() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExportSheetToCSVFile(t *testing.T) {
+	t.Cleanup(fake.reset)
+
+	fake.reset()
+	fake.seedSheet("Export", [][]interface{}{{"Name", "Note"}, {"Pen"}, {"Ink", "a, b"}})
+	resetClient()
+	client.SetSheetName("Export")
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "export.csv")
+	if err := os.WriteFile(path, []byte("old contents\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	if err := client.ExportSheetToCSVFile(path); err != nil {
+		t.Fatalf("ExportSheetToCSVFile() error = %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if want := "Name,Note\nPen,\nInk,\"a, b\"\n"; string(got) != want {
+		t.Errorf("file contents = %q, want %q", got, want)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("directory has %d files, want only the CSV file without temporary files", len(entries))
+	}
+
+	client.SetSheetName("Missing")
+	if err := client.ExportSheetToCSVFile(path); err == nil {
+		t.Errorf("ExportSheetToCSVFile() of a missing sheet error = nil, want an error")
+	}
+	if got, _ := os.ReadFile(path); string(got) != "Name,Note\nPen,\nInk,\"a, b\"\n" {
+		t.Errorf("file contents after a failed export = %q, want the previous export", got)
+	}
+}
+
+func TestLoadCSVFileToSheet(t *testing.T) {
+	t.Cleanup(fake.reset)
+
+	existing := [][]interface{}{{"Name", "Qty"}, {"Pen", "1"}}
+	dir := t.TempDir()
+	validPath := filepath.Join(dir, "valid.csv")
+	if err := os.WriteFile(validPath, []byte("Name,Qty\nInk,2\n\"Cap, red\"\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	malformedPath := filepath.Join(dir, "malformed.csv")
+	if err := os.WriteFile(malformedPath, []byte("Name,Qty\n\"Ink,2\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	// Test cases
+	tests := []struct {
+		name       string
+		path       string
+		replace    bool
+		wantValues [][]interface{}
+		wantErr    bool
+	}{
+		{
+			name:       "Append the records",
+			path:       validPath,
+			replace:    false,
+			wantValues: [][]interface{}{{"Name", "Qty"}, {"Pen", "1"}, {"Name", "Qty"}, {"Ink", "2"}, {"Cap, red"}},
+		},
+		{
+			name:       "Replace the data",
+			path:       validPath,
+			replace:    true,
+			wantValues: [][]interface{}{{"Name", "Qty"}, {"Ink", "2"}, {"Cap, red"}},
+		},
+		{
+			name:       "Missing file keeps the sheet",
+			path:       filepath.Join(dir, "missing.csv"),
+			replace:    true,
+			wantValues: existing,
+			wantErr:    true,
+		},
+		{
+			name:       "Malformed file keeps the sheet",
+			path:       malformedPath,
+			replace:    true,
+			wantValues: existing,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.reset()
+			fake.seedSheet("Import", [][]interface{}{{"Name", "Qty"}, {"Pen", "1"}})
+			resetClient()
+			client.SetSheetName("Import")
+
+			err := client.LoadCSVFileToSheet(tt.path, tt.replace)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadCSVFileToSheet() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			got, err := client.ReadData("A:B")
+			if err != nil {
+				t.Fatalf("ReadData() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.wantValues) {
+				t.Errorf("values = %v, want %v", got, tt.wantValues)
+			}
+		})
+	}
+}
@@ -0,0 +1,1638 @@
+package gosheets
+
+import (
+	"cmp"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// fakeSheetsServer is an in-memory implementation of the subset of the Google Sheets API used by this package.
+// It also serves the OAuth2 token endpoint, so the client authenticates with the regular service account flow.
+//
+//   - The spreadsheets field stores the spreadsheets by ID.
+//   - The requests field records every batch update request received, so tests can inspect them.
+//   - The delay field makes every API response wait, to test timeouts.
+//   - The calls field counts the API calls received since the last reset, token requests excluded.
+//   - The batchUpdateErr field makes every batch update fail with the given error, to test partial failures.
+//   - The deleteErr field makes the batch updates that delete rows or columns fail with the given error, after the
+//     first deleteErrAfter of them.
+//   - The transientErrs field makes the next API calls fail with the given errors, one per call, to test retries.
+//   - The inputOptions field records the value input option of every values write, in order.
+type fakeSheetsServer struct {
+	mu             sync.Mutex
+	spreadsheets   map[string]*fakeSpreadsheet
+	requests       []*sheets.Request
+	nextSheetID    int64
+	delay          time.Duration
+	calls          int
+	batchUpdateErr *fakeError
+	deleteErr      *fakeError
+	deleteErrAfter int
+	transientErrs  []*fakeError
+	inputOptions   []string
+}
+
+// fakeSpreadsheet is a spreadsheet stored by the fake server.
+type fakeSpreadsheet struct {
+	id          string
+	title       string
+	timeZone    string
+	locale      string
+	sheets      []*fakeSheet
+	metadata    []*sheets.DeveloperMetadata
+	namedRanges []*sheets.NamedRange
+}
+
+// fakeSheet is a sheet stored by the fake server. The values are indexed by 0-based row and column.
+//
+//   - The cells field stores the cell attributes other than the value (format, note, validation, etc.) by position.
+//   - The rows field stores the properties of the rows that differ from the default (e.g., hidden rows) by row index.
+//   - The bandings field stores the banded ranges of the sheet.
+//   - The protectedRanges field stores the protected ranges of the sheet.
+type fakeSheet struct {
+	properties      *sheets.SheetProperties
+	values          [][]interface{}
+	cells           map[[2]int64]*sheets.CellData
+	rows            map[int64]*sheets.DimensionProperties
+	bandings        []*sheets.BandedRange
+	protectedRanges []*sheets.ProtectedRange
+}
+
+// fakeError is an error answered by the fake server with the given HTTP status.
+type fakeError struct {
+	code    int
+	message string
+}
+
+func (e *fakeError) Error() string {
+	return e.message
+}
+
+// newFakeSheetsServer creates a fake server with the seed spreadsheet used by the tests.
+func newFakeSheetsServer() *fakeSheetsServer {
+	f := &fakeSheetsServer{}
+	f.reset()
+	return f
+}
+
+// reset restores the seed data: the spreadsheet "SPREADSHEET_ID" with a single sheet "Sheet1" holding a header row and one data row.
+func (f *fakeSheetsServer) reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.spreadsheets = map[string]*fakeSpreadsheet{}
+	f.requests = nil
+	f.nextSheetID = 0
+	f.calls = 0
+	f.batchUpdateErr = nil
+	f.deleteErr = nil
+	f.deleteErrAfter = 0
+	f.transientErrs = nil
+	f.inputOptions = nil
+
+	spreadsheet := &fakeSpreadsheet{id: "SPREADSHEET_ID", title: "Test spreadsheet", timeZone: "Europe/Madrid", locale: "en_US"}
+	sheet := f.addSheet(spreadsheet, "Sheet1")
+	sheet.values = [][]interface{}{{"Header1", "Header2"}, {"Value1", "Value2"}}
+	f.spreadsheets[spreadsheet.id] = spreadsheet
+}
+
+// seedSheet adds a sheet with the given values to the seed spreadsheet. Tests that call it should restore the seed
+// data with reset when they finish.
+func (f *fakeSheetsServer) seedSheet(title string, values [][]interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	sheet := f.addSheet(f.spreadsheets["SPREADSHEET_ID"], title)
+	sheet.values = values
+}
+
+// hideRows hides rows of a sheet of the seed spreadsheet, either by the user or by a filter.
+//
+//   - The rows are 0-based indexes.
+func (f *fakeSheetsServer) hideRows(title string, byFilter bool, rows ...int64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	sheet := f.spreadsheets["SPREADSHEET_ID"].sheetByTitle(title)
+	if sheet.rows == nil {
+		sheet.rows = map[int64]*sheets.DimensionProperties{}
+	}
+	for _, row := range rows {
+		sheet.rows[row] = &sheets.DimensionProperties{PixelSize: 21, HiddenByUser: !byFilter, HiddenByFilter: byFilter}
+	}
+}
+
+// addSpreadsheet adds a spreadsheet with a single empty sheet "Sheet1", like a freshly created one.
+func (f *fakeSheetsServer) addSpreadsheet(id string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	spreadsheet := &fakeSpreadsheet{id: id, title: "Untitled spreadsheet", timeZone: "Europe/Madrid", locale: "en_US"}
+	f.addSheet(spreadsheet, "Sheet1")
+	f.spreadsheets[id] = spreadsheet
+}
+
+// seedNamedRange adds a named range over the given range of a sheet of the seed spreadsheet.
+func (f *fakeSheetsServer) seedNamedRange(name, title, a1 string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	spreadsheet := f.spreadsheets["SPREADSHEET_ID"]
+	grid, err := parseA1Range(a1)
+	if err != nil {
+		panic(err)
+	}
+	spreadsheet.namedRanges = append(spreadsheet.namedRanges, &sheets.NamedRange{
+		NamedRangeId: fmt.Sprintf("named-range-%d", len(spreadsheet.namedRanges)),
+		Name:         name,
+		Range:        grid.gridRange(spreadsheet.sheetByTitle(title).properties.SheetId),
+	})
+}
+
+// seedProtectedRange adds a protected range over the given range of a sheet of the seed spreadsheet, or over the
+// whole sheet when the range is empty.
+func (f *fakeSheetsServer) seedProtectedRange(title, a1 string, protectedRange sheets.ProtectedRange) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	sheet := f.spreadsheets["SPREADSHEET_ID"].sheetByTitle(title)
+	protectedRange.Range = &sheets.GridRange{SheetId: sheet.properties.SheetId}
+	if a1 != "" {
+		grid, err := parseA1Range(a1)
+		if err != nil {
+			panic(err)
+		}
+		protectedRange.Range = grid.gridRange(sheet.properties.SheetId)
+	}
+	protectedRange.ProtectedRangeId = int64(len(sheet.protectedRanges) + 1)
+	sheet.protectedRanges = append(sheet.protectedRanges, &protectedRange)
+}
+
+// sheet returns the sheet of the seed spreadsheet with the given title, or nil if there is none.
+func (f *fakeSheetsServer) sheet(title string) *fakeSheet {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.spreadsheets["SPREADSHEET_ID"].sheetByTitle(title)
+}
+
+// callCount returns the number of API calls received since the last reset.
+func (f *fakeSheetsServer) callCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.calls
+}
+
+// credentials returns a service account key whose token_uri points to the token endpoint of the fake server.
+func (f *fakeSheetsServer) credentials(serverURL string) ([]byte, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(map[string]string{
+		"type":           "service_account",
+		"project_id":     "gosheets-test",
+		"private_key_id": "1",
+		"private_key":    string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		"client_email":   "gosheets-test@gosheets-test.iam.gserviceaccount.com",
+		"client_id":      "1",
+		"token_uri":      serverURL + "/token",
+	})
+}
+
+// addSheet adds an empty sheet with the default grid size to the spreadsheet.
+func (f *fakeSheetsServer) addSheet(spreadsheet *fakeSpreadsheet, title string) *fakeSheet {
+	f.nextSheetID++
+	sheet := &fakeSheet{
+		properties: &sheets.SheetProperties{
+			SheetId:        f.nextSheetID - 1,
+			Title:          title,
+			Index:          int64(len(spreadsheet.sheets)),
+			SheetType:      "GRID",
+			GridProperties: &sheets.GridProperties{RowCount: 1000, ColumnCount: 26},
+		},
+	}
+	spreadsheet.sheets = append(spreadsheet.sheets, sheet)
+	return sheet
+}
+
+func (f *fakeSheetsServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/token" {
+		writeJSON(w, map[string]interface{}{"access_token": "fake-token", "token_type": "Bearer", "expires_in": 3600})
+		return
+	}
+
+	time.Sleep(f.delay)
+
+	if r.Header.Get("Authorization") != "Bearer fake-token" {
+		writeError(w, &fakeError{http.StatusUnauthorized, "Request is missing required authentication credential."})
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls++
+	if len(f.transientErrs) > 0 {
+		err := f.transientErrs[0]
+		f.transientErrs = f.transientErrs[1:]
+		writeError(w, err)
+		return
+	}
+
+	resp, err := f.route(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, resp)
+}
+
+// route dispatches a request to the handler of the matching API method.
+func (f *fakeSheetsServer) route(r *http.Request) (interface{}, error) {
+	path, ok := strings.CutPrefix(r.URL.Path, "/v4/spreadsheets/")
+	if !ok {
+		return nil, &fakeError{http.StatusNotFound, "unknown method " + r.URL.Path}
+	}
+
+	id, rest, _ := strings.Cut(path, "/")
+	id, method, _ := strings.Cut(id, ":")
+
+	spreadsheet, ok := f.spreadsheets[id]
+	if !ok {
+		return nil, &fakeError{http.StatusNotFound, "Requested entity was not found."}
+	}
+
+	switch {
+	case rest == "" && method == "" && r.Method == http.MethodGet:
+		return f.getSpreadsheet(spreadsheet, r)
+	case rest == "" && method == "batchUpdate":
+		return f.batchUpdate(spreadsheet, r)
+	case rest == "values:batchUpdate":
+		return f.batchUpdateValues(spreadsheet, r)
+	case rest == "values:batchGet":
+		return f.batchGetValues(spreadsheet, r)
+	case rest == "developerMetadata:search":
+		return f.searchDeveloperMetadata(spreadsheet, r)
+	case strings.HasPrefix(rest, "values/"):
+		a1 := strings.TrimPrefix(rest, "values/")
+		switch {
+		case strings.HasSuffix(a1, ":append"):
+			return f.appendValues(spreadsheet, strings.TrimSuffix(a1, ":append"), r)
+		case strings.HasSuffix(a1, ":clear"):
+			return f.clearValues(spreadsheet, strings.TrimSuffix(a1, ":clear"))
+		case r.Method == http.MethodPut:
+			return f.updateValues(spreadsheet, a1, r)
+		default:
+			return f.getValues(spreadsheet, a1, r)
+		}
+	}
+
+	return nil, &fakeError{http.StatusNotFound, "unknown method " + r.URL.Path}
+}
+
+// getSpreadsheet answers Spreadsheets.Get with the spreadsheet metadata and, when includeGridData is set, the grid
+// data of the requested ranges, or of every sheet without ranges.
+func (f *fakeSheetsServer) getSpreadsheet(spreadsheet *fakeSpreadsheet, r *http.Request) (interface{}, error) {
+	resp := &sheets.Spreadsheet{
+		SpreadsheetId: spreadsheet.id,
+		Properties:    &sheets.SpreadsheetProperties{Title: spreadsheet.title, TimeZone: spreadsheet.timeZone, Locale: spreadsheet.locale},
+	}
+
+	if r.URL.Query().Get("includeGridData") != "true" {
+		for _, sheet := range spreadsheet.sheets {
+			resp.Sheets = append(resp.Sheets, &sheets.Sheet{Properties: sheet.properties, ProtectedRanges: sheet.protectedRanges, BandedRanges: sheet.bandings})
+		}
+		resp.NamedRanges = spreadsheet.namedRanges
+		return resp, nil
+	}
+
+	if len(r.URL.Query()["ranges"]) == 0 {
+		for _, sheet := range spreadsheet.sheets {
+			resp.Sheets = append(resp.Sheets, &sheets.Sheet{
+				Properties:      sheet.properties,
+				ProtectedRanges: sheet.protectedRanges,
+				Data:            []*sheets.GridData{sheet.gridData(a1Range{EndRow: -1, EndColumn: -1})},
+			})
+		}
+		resp.NamedRanges = spreadsheet.namedRanges
+		return resp, nil
+	}
+
+	for _, a1 := range r.URL.Query()["ranges"] {
+		sheet, grid, err := spreadsheet.resolve(a1)
+		if err != nil {
+			return nil, err
+		}
+		resp.Sheets = append(resp.Sheets, &sheets.Sheet{
+			Properties: sheet.properties,
+			Data:       []*sheets.GridData{sheet.gridData(grid)},
+		})
+	}
+	return resp, nil
+}
+
+// batchUpdate answers Spreadsheets.BatchUpdate. The requests are applied atomically: if one of them fails, none of
+// them is applied.
+func (f *fakeSheetsServer) batchUpdate(spreadsheet *fakeSpreadsheet, r *http.Request) (interface{}, error) {
+	if f.batchUpdateErr != nil {
+		return nil, f.batchUpdateErr
+	}
+
+	var req sheets.BatchUpdateSpreadsheetRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, &fakeError{http.StatusBadRequest, err.Error()}
+	}
+	for _, request := range req.Requests {
+		if request.DeleteDimension != nil && f.deleteErr != nil {
+			if f.deleteErrAfter == 0 {
+				return nil, f.deleteErr
+			}
+			f.deleteErrAfter--
+			break
+		}
+	}
+
+	if len(req.Requests) == 0 {
+		return nil, &fakeError{http.StatusBadRequest, "Invalid requests: Must specify at least one request."}
+	}
+
+	snapshot := spreadsheet.clone()
+	resp := &sheets.BatchUpdateSpreadsheetResponse{SpreadsheetId: spreadsheet.id}
+	for _, request := range req.Requests {
+		reply, err := f.apply(spreadsheet, request)
+		if err != nil {
+			*spreadsheet = *snapshot
+			return nil, err
+		}
+		resp.Replies = append(resp.Replies, reply)
+	}
+
+	f.requests = append(f.requests, req.Requests...)
+	return resp, nil
+}
+
+// apply applies a single batch update request. Requests that only change formatting are accepted without effect.
+func (f *fakeSheetsServer) apply(spreadsheet *fakeSpreadsheet, request *sheets.Request) (*sheets.Response, error) {
+	switch {
+	case request.InsertDimension != nil:
+		return &sheets.Response{}, spreadsheet.insertDimension(request.InsertDimension.Range)
+	case request.DeleteDimension != nil:
+		return &sheets.Response{}, spreadsheet.deleteDimension(request.DeleteDimension.Range)
+	case request.MoveDimension != nil:
+		return &sheets.Response{}, spreadsheet.moveDimension(request.MoveDimension)
+	case request.AddSheet != nil:
+		properties := request.AddSheet.Properties
+		if spreadsheet.sheetByTitle(properties.Title) != nil {
+			return nil, &fakeError{http.StatusBadRequest, fmt.Sprintf("A sheet with the name %q already exists.", properties.Title)}
+		}
+		if properties.SheetId != 0 && spreadsheet.sheetByID(properties.SheetId) != nil {
+			return nil, &fakeError{http.StatusBadRequest, fmt.Sprintf("A sheet with the ID %d already exists.", properties.SheetId)}
+		}
+		sheet := f.addSheet(spreadsheet, properties.Title)
+		if properties.SheetId != 0 {
+			sheet.properties.SheetId = properties.SheetId
+		}
+		if properties.GridProperties != nil {
+			gridProperties := *properties.GridProperties
+			sheet.properties.GridProperties = &gridProperties
+		}
+		return &sheets.Response{AddSheet: &sheets.AddSheetResponse{Properties: sheet.properties}}, nil
+	case request.DeleteSheet != nil:
+		return &sheets.Response{}, spreadsheet.deleteSheet(request.DeleteSheet.SheetId)
+	case request.UpdateSheetProperties != nil:
+		return &sheets.Response{}, spreadsheet.updateSheetProperties(request.UpdateSheetProperties)
+	case request.AppendDimension != nil:
+		return &sheets.Response{}, spreadsheet.appendDimension(request.AppendDimension)
+	case request.UpdateCells != nil:
+		return &sheets.Response{}, spreadsheet.updateCells(request.UpdateCells)
+	case request.AppendCells != nil:
+		return &sheets.Response{}, spreadsheet.appendCells(request.AppendCells)
+	case request.RepeatCell != nil:
+		return &sheets.Response{}, spreadsheet.repeatCell(request.RepeatCell)
+	case request.FindReplace != nil:
+		reply, err := spreadsheet.findReplace(request.FindReplace)
+		return &sheets.Response{FindReplace: reply}, err
+	case request.SortRange != nil:
+		return &sheets.Response{}, spreadsheet.sortRange(request.SortRange)
+	case request.UpdateBorders != nil:
+		return &sheets.Response{}, spreadsheet.updateBorders(request.UpdateBorders)
+	case request.AddBanding != nil:
+		banding, err := spreadsheet.addBanding(request.AddBanding.BandedRange)
+		return &sheets.Response{AddBanding: &sheets.AddBandingResponse{BandedRange: banding}}, err
+	case request.UpdateBanding != nil:
+		return &sheets.Response{}, spreadsheet.updateBanding(request.UpdateBanding)
+	case request.SetDataValidation != nil:
+		return &sheets.Response{}, spreadsheet.setDataValidation(request.SetDataValidation)
+	case request.CopyPaste != nil:
+		return &sheets.Response{}, spreadsheet.copyPaste(request.CopyPaste)
+	case request.AddNamedRange != nil:
+		namedRange := *request.AddNamedRange.NamedRange
+		for _, other := range spreadsheet.namedRanges {
+			if other.Name == namedRange.Name {
+				return nil, &fakeError{http.StatusBadRequest, fmt.Sprintf("A named range with the name %q already exists.", namedRange.Name)}
+			}
+		}
+		if spreadsheet.sheetByID(namedRange.Range.SheetId) == nil {
+			return nil, &fakeError{http.StatusBadRequest, fmt.Sprintf("No grid with id: %d", namedRange.Range.SheetId)}
+		}
+		namedRange.NamedRangeId = fmt.Sprintf("named-range-%d", len(spreadsheet.namedRanges))
+		spreadsheet.namedRanges = append(spreadsheet.namedRanges, &namedRange)
+		return &sheets.Response{AddNamedRange: &sheets.AddNamedRangeResponse{NamedRange: &namedRange}}, nil
+	case request.AddProtectedRange != nil:
+		protectedRange := *request.AddProtectedRange.ProtectedRange
+		sheet := spreadsheet.sheetByID(protectedRange.Range.SheetId)
+		if sheet == nil {
+			return nil, &fakeError{http.StatusBadRequest, fmt.Sprintf("No grid with id: %d", protectedRange.Range.SheetId)}
+		}
+		protectedRange.ProtectedRangeId = int64(len(sheet.protectedRanges) + 1)
+		sheet.protectedRanges = append(sheet.protectedRanges, &protectedRange)
+		return &sheets.Response{AddProtectedRange: &sheets.AddProtectedRangeResponse{ProtectedRange: &protectedRange}}, nil
+	case request.CreateDeveloperMetadata != nil:
+		metadata := *request.CreateDeveloperMetadata.DeveloperMetadata
+		metadata.MetadataId = int64(len(spreadsheet.metadata) + 1)
+		spreadsheet.metadata = append(spreadsheet.metadata, &metadata)
+		return &sheets.Response{CreateDeveloperMetadata: &sheets.CreateDeveloperMetadataResponse{DeveloperMetadata: &metadata}}, nil
+	case request.UpdateDeveloperMetadata != nil:
+		return &sheets.Response{}, spreadsheet.updateDeveloperMetadata(request.UpdateDeveloperMetadata)
+	case request.DeleteDeveloperMetadata != nil:
+		// Like the API, deleting the metadata of a filter that matches none is not an error
+		deleted := spreadsheet.matchMetadata([]*sheets.DataFilter{request.DeleteDeveloperMetadata.DataFilter})
+		spreadsheet.metadata = slices.DeleteFunc(spreadsheet.metadata, func(metadata *sheets.DeveloperMetadata) bool {
+			return slices.Contains(deleted, metadata)
+		})
+		return &sheets.Response{DeleteDeveloperMetadata: &sheets.DeleteDeveloperMetadataResponse{DeletedDeveloperMetadata: deleted}}, nil
+	}
+
+	return &sheets.Response{}, nil
+}
+
+// getValues answers Spreadsheets.Values.Get.
+func (f *fakeSheetsServer) getValues(spreadsheet *fakeSpreadsheet, a1 string, r *http.Request) (interface{}, error) {
+	sheet, grid, err := spreadsheet.resolve(a1)
+	if err != nil {
+		return nil, err
+	}
+
+	render := r.URL.Query().Get("valueRenderOption")
+	var values [][]interface{}
+	for i := grid.StartRow; i < int64(len(sheet.values)) && (grid.EndRow == -1 || i < grid.EndRow); i++ {
+		var row []interface{}
+		for j := grid.StartColumn; j < int64(len(sheet.values[i])) && (grid.EndColumn == -1 || j < grid.EndColumn); j++ {
+			row = append(row, renderValue(sheet.values[i][j], render))
+		}
+		values = append(values, trimRow(row))
+	}
+
+	// The API omits the trailing empty rows and the values field when the range is empty.
+	for len(values) > 0 && len(values[len(values)-1]) == 0 {
+		values = values[:len(values)-1]
+	}
+	return &sheets.ValueRange{Range: a1, MajorDimension: "ROWS", Values: values}, nil
+}
+
+// appendValues answers Spreadsheets.Values.Append, writing the values after the last non-empty row of the sheet.
+func (f *fakeSheetsServer) appendValues(spreadsheet *fakeSpreadsheet, a1 string, r *http.Request) (interface{}, error) {
+	sheet, grid, err := spreadsheet.resolve(a1)
+	if err != nil {
+		return nil, err
+	}
+
+	var valueRange sheets.ValueRange
+	err = json.NewDecoder(r.Body).Decode(&valueRange)
+	if err != nil {
+		return nil, &fakeError{http.StatusBadRequest, err.Error()}
+	}
+
+	f.inputOptions = append(f.inputOptions, r.URL.Query().Get("valueInputOption"))
+	startRow := max(sheet.lastRow(), grid.StartRow)
+	updated := sheet.write(startRow, grid.StartColumn, fakeEnteredValues(valueRange.Values, r.URL.Query().Get("valueInputOption")))
+	return &sheets.AppendValuesResponse{
+		SpreadsheetId: spreadsheet.id,
+		TableRange:    a1,
+		Updates:       updated,
+	}, nil
+}
+
+// updateValues answers Spreadsheets.Values.Update.
+func (f *fakeSheetsServer) updateValues(spreadsheet *fakeSpreadsheet, a1 string, r *http.Request) (interface{}, error) {
+	sheet, grid, err := spreadsheet.resolve(a1)
+	if err != nil {
+		return nil, err
+	}
+
+	var valueRange sheets.ValueRange
+	err = json.NewDecoder(r.Body).Decode(&valueRange)
+	if err != nil {
+		return nil, &fakeError{http.StatusBadRequest, err.Error()}
+	}
+
+	f.inputOptions = append(f.inputOptions, r.URL.Query().Get("valueInputOption"))
+	values := valueRange.Values
+	if valueRange.MajorDimension == "COLUMNS" {
+		values = nil
+		for j, column := range valueRange.Values {
+			for i, value := range column {
+				for len(values) <= i {
+					values = append(values, nil)
+				}
+				for len(values[i]) < j {
+					values[i] = append(values[i], nil)
+				}
+				values[i] = append(values[i], value)
+			}
+		}
+	}
+	return sheet.write(grid.StartRow, grid.StartColumn, fakeEnteredValues(values, r.URL.Query().Get("valueInputOption"))), nil
+}
+
+// fakeEnteredValues returns the values stored for the values of a write. Like the API, with USER_ENTERED a leading
+// apostrophe makes the rest of the text be stored as text, without the apostrophe. The other text is not parsed.
+func fakeEnteredValues(values [][]interface{}, inputOption string) [][]interface{} {
+	if inputOption != "USER_ENTERED" {
+		return values
+	}
+
+	entered := make([][]interface{}, len(values))
+	for i, row := range values {
+		entered[i] = append([]interface{}(nil), row...)
+		for j, value := range row {
+			if text, ok := value.(string); ok {
+				entered[i][j] = strings.TrimPrefix(text, "'")
+			}
+		}
+	}
+	return entered
+}
+
+// batchUpdateValues answers Spreadsheets.Values.BatchUpdate. The ranges are written atomically: if one of them
+// fails, none of them is written.
+func (f *fakeSheetsServer) batchUpdateValues(spreadsheet *fakeSpreadsheet, r *http.Request) (interface{}, error) {
+	var req sheets.BatchUpdateValuesRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, &fakeError{http.StatusBadRequest, err.Error()}
+	}
+
+	f.inputOptions = append(f.inputOptions, req.ValueInputOption)
+	snapshot := spreadsheet.clone()
+	resp := &sheets.BatchUpdateValuesResponse{SpreadsheetId: spreadsheet.id}
+	for _, valueRange := range req.Data {
+		sheet, grid, err := spreadsheet.resolve(valueRange.Range)
+		if err != nil {
+			*spreadsheet = *snapshot
+			return nil, err
+		}
+		updated := sheet.write(grid.StartRow, grid.StartColumn, fakeEnteredValues(valueRange.Values, req.ValueInputOption))
+		resp.Responses = append(resp.Responses, updated)
+		resp.TotalUpdatedRows += updated.UpdatedRows
+		resp.TotalUpdatedCells += updated.UpdatedCells
+	}
+	return resp, nil
+}
+
+// batchGetValues answers Spreadsheets.Values.BatchGet, reading each range like getValues.
+func (f *fakeSheetsServer) batchGetValues(spreadsheet *fakeSpreadsheet, r *http.Request) (interface{}, error) {
+	resp := &sheets.BatchGetValuesResponse{SpreadsheetId: spreadsheet.id}
+	for _, a1 := range r.URL.Query()["ranges"] {
+		valueRange, err := f.getValues(spreadsheet, a1, r)
+		if err != nil {
+			return nil, err
+		}
+		resp.ValueRanges = append(resp.ValueRanges, valueRange.(*sheets.ValueRange))
+	}
+	return resp, nil
+}
+
+// searchDeveloperMetadata answers Spreadsheets.DeveloperMetadata.Search.
+func (f *fakeSheetsServer) searchDeveloperMetadata(spreadsheet *fakeSpreadsheet, r *http.Request) (interface{}, error) {
+	var req sheets.SearchDeveloperMetadataRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return nil, &fakeError{http.StatusBadRequest, err.Error()}
+	}
+
+	resp := &sheets.SearchDeveloperMetadataResponse{}
+	for _, metadata := range spreadsheet.matchMetadata(req.DataFilters) {
+		resp.MatchedDeveloperMetadata = append(resp.MatchedDeveloperMetadata, &sheets.MatchedDeveloperMetadata{
+			DeveloperMetadata: metadata,
+			DataFilters:       req.DataFilters,
+		})
+	}
+	return resp, nil
+}
+
+// clearValues answers Spreadsheets.Values.Clear.
+func (f *fakeSheetsServer) clearValues(spreadsheet *fakeSpreadsheet, a1 string) (interface{}, error) {
+	sheet, grid, err := spreadsheet.resolve(a1)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := grid.StartRow; i < int64(len(sheet.values)) && (grid.EndRow == -1 || i < grid.EndRow); i++ {
+		for j := grid.StartColumn; j < int64(len(sheet.values[i])) && (grid.EndColumn == -1 || j < grid.EndColumn); j++ {
+			sheet.values[i][j] = nil
+		}
+	}
+	return &sheets.ClearValuesResponse{SpreadsheetId: spreadsheet.id, ClearedRange: a1}, nil
+}
+
+// resolve splits a range like "Sheet1!A1:B2" into the sheet it refers to and its grid indexes. Ranges without
+// a sheet name refer to the first sheet, and ranges with only a sheet name refer to the whole sheet.
+func (s *fakeSpreadsheet) resolve(a1 string) (*fakeSheet, a1Range, error) {
+	title, cells, hasSheet := strings.Cut(a1, "!")
+	if !hasSheet {
+		if sheet := s.sheetByTitle(strings.Trim(a1, "'")); sheet != nil {
+			return sheet, a1Range{EndRow: -1, EndColumn: -1}, nil
+		}
+		title, cells = s.sheets[0].properties.Title, a1
+	}
+
+	// Like the API, names that need quotes (e.g., "My Data" or "2024-06-01") are not recognized without them
+	sheet := s.sheetByTitle(unquoteSheetName(title))
+	if sheet == nil || (!strings.HasPrefix(title, "'") && quoteSheetName(title) != title) {
+		return nil, a1Range{}, &fakeError{http.StatusBadRequest, "Unable to parse range: " + a1}
+	}
+
+	grid, err := parseA1Range(cells)
+	if err != nil {
+		return nil, a1Range{}, &fakeError{http.StatusBadRequest, "Unable to parse range: " + a1}
+	}
+	return sheet, grid, nil
+}
+
+// sheetByTitle returns the sheet with the given title, or nil if there is none.
+func (s *fakeSpreadsheet) sheetByTitle(title string) *fakeSheet {
+	for _, sheet := range s.sheets {
+		if sheet.properties.Title == title {
+			return sheet
+		}
+	}
+	return nil
+}
+
+// sheetByID returns the sheet with the given ID, or nil if there is none.
+func (s *fakeSpreadsheet) sheetByID(sheetID int64) *fakeSheet {
+	for _, sheet := range s.sheets {
+		if sheet.properties.SheetId == sheetID {
+			return sheet
+		}
+	}
+	return nil
+}
+
+// insertDimension inserts empty rows or columns in a sheet.
+func (s *fakeSpreadsheet) insertDimension(r *sheets.DimensionRange) error {
+	sheet := s.sheetByID(r.SheetId)
+	if sheet == nil || r.EndIndex <= r.StartIndex {
+		return &fakeError{http.StatusBadRequest, "Invalid requests[0].insertDimension"}
+	}
+
+	count := r.EndIndex - r.StartIndex
+	if r.Dimension == "COLUMNS" {
+		sheet.properties.GridProperties.ColumnCount += count
+		for i, row := range sheet.values {
+			if int64(len(row)) > r.StartIndex {
+				row = append(row[:r.StartIndex], append(make([]interface{}, count), row[r.StartIndex:]...)...)
+				sheet.values[i] = row
+			}
+		}
+		return nil
+	}
+
+	sheet.properties.GridProperties.RowCount += count
+	if int64(len(sheet.values)) > r.StartIndex {
+		sheet.values = append(sheet.values[:r.StartIndex], append(make([][]interface{}, count), sheet.values[r.StartIndex:]...)...)
+	}
+	s.moveRowMetadata(r.SheetId, r.StartIndex, count)
+	return nil
+}
+
+// moveRowMetadata moves the developer metadata attached to the rows of a sheet after rows are inserted (a positive
+// count) or deleted (a negative count) at a given index. The metadata of deleted rows is deleted with them.
+func (s *fakeSpreadsheet) moveRowMetadata(sheetID, index, count int64) {
+	s.metadata = slices.DeleteFunc(s.metadata, func(metadata *sheets.DeveloperMetadata) bool {
+		dimension := metadata.Location.DimensionRange
+		if dimension == nil || dimension.Dimension != "ROWS" || dimension.SheetId != sheetID || dimension.StartIndex < index {
+			return false
+		}
+		if count < 0 && dimension.StartIndex < index-count {
+			return true
+		}
+		moved, location := *dimension, *metadata.Location // Copied, since the snapshots of clone share them
+		moved.StartIndex += count
+		moved.EndIndex += count
+		location.DimensionRange = &moved
+		metadata.Location = &location
+		return false
+	})
+}
+
+// appendDimension adds empty rows or columns at the end of a sheet.
+func (s *fakeSpreadsheet) appendDimension(request *sheets.AppendDimensionRequest) error {
+	sheet := s.sheetByID(request.SheetId)
+	if sheet == nil {
+		return &fakeError{http.StatusBadRequest, fmt.Sprintf("No grid with id: %d", request.SheetId)}
+	}
+
+	if request.Dimension == "COLUMNS" {
+		sheet.properties.GridProperties.ColumnCount += request.Length
+	} else {
+		sheet.properties.GridProperties.RowCount += request.Length
+	}
+	return nil
+}
+
+// deleteDimension deletes rows or columns from a sheet.
+func (s *fakeSpreadsheet) deleteDimension(r *sheets.DimensionRange) error {
+	sheet := s.sheetByID(r.SheetId)
+	if sheet == nil || r.EndIndex <= r.StartIndex {
+		return &fakeError{http.StatusBadRequest, "Invalid requests[0].deleteDimension"}
+	}
+
+	if r.Dimension == "COLUMNS" {
+		if r.EndIndex > sheet.properties.GridProperties.ColumnCount {
+			return &fakeError{http.StatusBadRequest, "Invalid requests[0].deleteDimension: range out of bounds"}
+		}
+		sheet.properties.GridProperties.ColumnCount -= r.EndIndex - r.StartIndex
+		for i, row := range sheet.values {
+			if int64(len(row)) > r.StartIndex {
+				sheet.values[i] = append(row[:r.StartIndex], row[min(r.EndIndex, int64(len(row))):]...)
+			}
+		}
+		return nil
+	}
+
+	if r.EndIndex > sheet.properties.GridProperties.RowCount {
+		return &fakeError{http.StatusBadRequest, "Invalid requests[0].deleteDimension: range out of bounds"}
+	}
+	sheet.properties.GridProperties.RowCount -= r.EndIndex - r.StartIndex
+	if int64(len(sheet.values)) > r.StartIndex {
+		sheet.values = append(sheet.values[:r.StartIndex], sheet.values[min(r.EndIndex, int64(len(sheet.values))):]...)
+	}
+	s.moveRowMetadata(r.SheetId, r.StartIndex, r.StartIndex-r.EndIndex)
+	return nil
+}
+
+// moveDimension moves rows or columns of a sheet, with the destination index relative to the grid before the move
+// like the API does.
+func (s *fakeSpreadsheet) moveDimension(request *sheets.MoveDimensionRequest) error {
+	r := request.Source
+	sheet := s.sheetByID(r.SheetId)
+	dest := request.DestinationIndex
+	if sheet == nil || r.EndIndex <= r.StartIndex || (dest > r.StartIndex && dest < r.EndIndex) {
+		return &fakeError{http.StatusBadRequest, "Invalid requests[0].moveDimension"}
+	}
+
+	if r.Dimension == "COLUMNS" {
+		for i, row := range sheet.values {
+			row = moveItems(row, r.StartIndex, r.EndIndex, dest)
+			for len(row) > 0 && row[len(row)-1] == nil {
+				row = row[:len(row)-1]
+			}
+			sheet.values[i] = row
+		}
+		return nil
+	}
+
+	values := moveItems(sheet.values, r.StartIndex, r.EndIndex, dest)
+	for len(values) > 0 && len(values[len(values)-1]) == 0 {
+		values = values[:len(values)-1]
+	}
+	sheet.values = values
+	return nil
+}
+
+// moveItems moves the items in [start, end) of a slice before the item at dest, padding the slice with zero values
+// as needed.
+func moveItems[T any](items []T, start, end, dest int64) []T {
+	items = append(items, make([]T, max(0, max(end, dest)-int64(len(items))))...)
+	moved := slices.Clone(items[start:end])
+	rest := slices.Delete(slices.Clone(items), int(start), int(end))
+	if dest > start {
+		dest -= end - start
+	}
+	return slices.Insert(rest, int(dest), moved...)
+}
+
+// deleteSheet deletes a sheet, refusing to delete the last one like the API does.
+func (s *fakeSpreadsheet) deleteSheet(sheetID int64) error {
+	if len(s.sheets) == 1 {
+		return &fakeError{http.StatusBadRequest, "You can't remove all the sheets in a document."}
+	}
+
+	for i, sheet := range s.sheets {
+		if sheet.properties.SheetId == sheetID {
+			s.sheets = append(s.sheets[:i], s.sheets[i+1:]...)
+			for j, other := range s.sheets {
+				other.properties.Index = int64(j)
+			}
+			return nil
+		}
+	}
+	return &fakeError{http.StatusBadRequest, fmt.Sprintf("No grid with id: %d", sheetID)}
+}
+
+// updateSheetProperties updates the sheet properties listed in the fields mask of the request.
+func (s *fakeSpreadsheet) updateSheetProperties(request *sheets.UpdateSheetPropertiesRequest) error {
+	sheet := s.sheetByID(request.Properties.SheetId)
+	if sheet == nil {
+		return &fakeError{http.StatusBadRequest, fmt.Sprintf("No grid with id: %d", request.Properties.SheetId)}
+	}
+
+	for _, field := range strings.Split(request.Fields, ",") {
+		switch field {
+		case "index":
+			index := request.Properties.Index
+			if index < 0 || index > int64(len(s.sheets)) {
+				return &fakeError{http.StatusBadRequest, fmt.Sprintf("Invalid index: %d", index)}
+			}
+			// Indexes are relative to the tab order before the move.
+			if index > sheet.properties.Index {
+				index--
+			}
+			s.sheets = append(s.sheets[:sheet.properties.Index], s.sheets[sheet.properties.Index+1:]...)
+			s.sheets = append(s.sheets[:index], append([]*fakeSheet{sheet}, s.sheets[index:]...)...)
+			for i, other := range s.sheets {
+				other.properties.Index = int64(i)
+			}
+		case "title":
+			sheet.properties.Title = request.Properties.Title
+		case "hidden":
+			sheet.properties.Hidden = request.Properties.Hidden
+		case "tabColor", "tabColorStyle":
+			sheet.properties.TabColor = request.Properties.TabColor
+			sheet.properties.TabColorStyle = request.Properties.TabColorStyle
+		case "gridProperties.frozenRowCount":
+			sheet.properties.GridProperties.FrozenRowCount = request.Properties.GridProperties.FrozenRowCount
+		case "gridProperties.frozenColumnCount":
+			sheet.properties.GridProperties.FrozenColumnCount = request.Properties.GridProperties.FrozenColumnCount
+		case "gridProperties.rowCount":
+			sheet.properties.GridProperties.RowCount = request.Properties.GridProperties.RowCount
+		case "gridProperties.columnCount":
+			sheet.properties.GridProperties.ColumnCount = request.Properties.GridProperties.ColumnCount
+		case "gridProperties.hideGridlines":
+			sheet.properties.GridProperties.HideGridlines = request.Properties.GridProperties.HideGridlines
+		}
+	}
+	return nil
+}
+
+// updateCells writes the cell data of the request, limited to the fields of its mask.
+func (s *fakeSpreadsheet) updateCells(request *sheets.UpdateCellsRequest) error {
+	var sheetID, startRow, startColumn int64
+	switch {
+	case request.Start != nil:
+		sheetID, startRow, startColumn = request.Start.SheetId, request.Start.RowIndex, request.Start.ColumnIndex
+	case request.Range != nil:
+		sheetID, startRow, startColumn = request.Range.SheetId, request.Range.StartRowIndex, request.Range.StartColumnIndex
+	}
+
+	sheet := s.sheetByID(sheetID)
+	if sheet == nil {
+		return &fakeError{http.StatusBadRequest, fmt.Sprintf("No grid with id: %d", sheetID)}
+	}
+	if request.Fields == "" {
+		return &fakeError{http.StatusBadRequest, "Invalid requests[0].updateCells: fields is required"}
+	}
+
+	has := func(field string) bool {
+		return request.Fields == "*" || strings.Contains(request.Fields, field)
+	}
+
+	// With a range, the values of the cells of the range not covered by the rows are cleared.
+	if request.Range != nil && has("userEnteredValue") {
+		_, _, endRow, _, endColumn, err := s.gridRange(request.Range)
+		if err != nil {
+			return err
+		}
+		for i := startRow; i < endRow && i < int64(len(sheet.values)); i++ {
+			for j := startColumn; j < endColumn && j < int64(len(sheet.values[i])); j++ {
+				sheet.values[i][j] = nil
+			}
+		}
+	}
+
+	for i, rowData := range request.Rows {
+		for j, cell := range rowData.Values {
+			row, column := startRow+int64(i), startColumn+int64(j)
+			grid := sheet.properties.GridProperties
+			if row >= grid.RowCount || column >= grid.ColumnCount {
+				return &fakeError{http.StatusBadRequest, fmt.Sprintf("Range (%s!%s%d) exceeds grid limits.",
+					sheet.properties.Title, fakeColumnLetter(column), row+1)}
+			}
+			if has("userEnteredValue") {
+				var value interface{}
+				if extended := cell.UserEnteredValue; extended != nil {
+					value = extendedValueToInterface(extended)
+				}
+				sheet.write(row, column, [][]interface{}{{value}})
+			}
+			sheet.setCellData(row, column, func(stored *sheets.CellData) {
+				if has("userEnteredFormat") {
+					stored.UserEnteredFormat = cell.UserEnteredFormat
+				}
+				if has("note") {
+					stored.Note = cell.Note
+				}
+				if has("dataValidation") {
+					stored.DataValidation = cell.DataValidation
+				}
+			})
+		}
+	}
+	return nil
+}
+
+// appendCells writes the cell data of the request after the last non-empty row of the sheet.
+func (s *fakeSpreadsheet) appendCells(request *sheets.AppendCellsRequest) error {
+	sheet := s.sheetByID(request.SheetId)
+	if sheet == nil {
+		return &fakeError{http.StatusBadRequest, fmt.Sprintf("No grid with id: %d", request.SheetId)}
+	}
+
+	return s.updateCells(&sheets.UpdateCellsRequest{
+		Start:  &sheets.GridCoordinate{SheetId: request.SheetId, RowIndex: sheet.lastRow()},
+		Rows:   request.Rows,
+		Fields: request.Fields,
+	})
+}
+
+// repeatCell applies the format of the request cell to every cell of the request range, limited to the format
+// fields of its mask (e.g., "userEnteredFormat.wrapStrategy").
+func (s *fakeSpreadsheet) repeatCell(request *sheets.RepeatCellRequest) error {
+	sheet, startRow, endRow, startColumn, endColumn, err := s.gridRange(request.Range)
+	if err != nil {
+		return err
+	}
+	if request.Fields == "" {
+		return &fakeError{http.StatusBadRequest, "Invalid requests[0].repeatCell: fields is required"}
+	}
+
+	for i := startRow; i < endRow; i++ {
+		for j := startColumn; j < endColumn; j++ {
+			sheet.setCellData(i, j, func(stored *sheets.CellData) {
+				stored.UserEnteredFormat = mergeFormat(stored.UserEnteredFormat, request.Cell.UserEnteredFormat, request.Fields)
+			})
+		}
+	}
+	return nil
+}
+
+// findReplace applies a FindReplaceRequest scoped to a range, replacing the text of the stored values like the API.
+func (s *fakeSpreadsheet) findReplace(request *sheets.FindReplaceRequest) (*sheets.FindReplaceResponse, error) {
+	if request.Range == nil {
+		return nil, &fakeError{http.StatusBadRequest, "Invalid requests[0].findReplace: only ranged searches are supported by the fake"}
+	}
+	sheet, startRow, endRow, startColumn, endColumn, err := s.gridRange(request.Range)
+	if err != nil {
+		return nil, err
+	}
+
+	pattern := request.Find
+	if !request.SearchByRegex {
+		pattern = regexp.QuoteMeta(pattern)
+	}
+	if request.MatchEntireCell {
+		pattern = "^(?:" + pattern + ")$"
+	}
+	if !request.MatchCase {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, &fakeError{http.StatusBadRequest, fmt.Sprintf("Invalid regular expression: %s", request.Find)}
+	}
+
+	reply := &sheets.FindReplaceResponse{}
+	for i := startRow; i < endRow && i < int64(len(sheet.values)); i++ {
+		for j := startColumn; j < endColumn && j < int64(len(sheet.values[i])); j++ {
+			if sheet.values[i][j] == nil {
+				continue
+			}
+			text := fmt.Sprint(sheet.values[i][j])
+			if strings.HasPrefix(text, "=") && !request.IncludeFormulas {
+				continue
+			}
+			occurrences := len(re.FindAllStringIndex(text, -1))
+			replaced := re.ReplaceAllLiteralString(text, request.Replacement)
+			if request.SearchByRegex {
+				replaced = re.ReplaceAllString(text, request.Replacement)
+			}
+			if occurrences > 0 && replaced != text {
+				sheet.values[i][j] = replaced
+				reply.ValuesChanged++
+				reply.OccurrencesChanged += int64(occurrences)
+			}
+		}
+	}
+	return reply, nil
+}
+
+// sortRange applies a SortRangeRequest over whole rows like the API: numbers sort before text, text is compared
+// case-insensitively and the empty cells always go last.
+func (s *fakeSpreadsheet) sortRange(request *sheets.SortRangeRequest) error {
+	sheet, startRow, endRow, startColumn, _, err := s.gridRange(request.Range)
+	if err != nil {
+		return err
+	}
+	if startColumn != 0 || request.Range.EndColumnIndex != 0 {
+		return &fakeError{http.StatusBadRequest, "Invalid requests[0].sortRange: only whole rows are supported by the fake"}
+	}
+
+	endRow = min(endRow, int64(len(sheet.values)))
+	if startRow >= endRow {
+		return nil
+	}
+
+	compare := func(a, b []interface{}) int {
+		for _, spec := range request.SortSpecs {
+			var x, y interface{}
+			if spec.DimensionIndex < int64(len(a)) {
+				x = a[spec.DimensionIndex]
+			}
+			if spec.DimensionIndex < int64(len(b)) {
+				y = b[spec.DimensionIndex]
+			}
+
+			xEmpty, yEmpty := x == nil || x == "", y == nil || y == ""
+			if xEmpty || yEmpty {
+				if xEmpty != yEmpty {
+					return map[bool]int{true: 1, false: -1}[xEmpty] // Empty cells last, whatever the order
+				}
+				continue
+			}
+
+			xNumber, xIsNumber := x.(float64)
+			yNumber, yIsNumber := y.(float64)
+			var c int
+			switch {
+			case xIsNumber && yIsNumber:
+				c = cmp.Compare(xNumber, yNumber)
+			case xIsNumber != yIsNumber:
+				c = map[bool]int{true: -1, false: 1}[xIsNumber]
+			default:
+				c = strings.Compare(strings.ToLower(fmt.Sprint(x)), strings.ToLower(fmt.Sprint(y)))
+			}
+			if spec.SortOrder == "DESCENDING" {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		return 0
+	}
+	slices.SortStableFunc(sheet.values[startRow:endRow], compare)
+	return nil
+}
+
+// updateBorders applies an UpdateBordersRequest, storing the borders in the format of each cell of the range like
+// the API: the outer borders on the cells at the edges and the inner borders on the sides between the cells.
+func (s *fakeSpreadsheet) updateBorders(request *sheets.UpdateBordersRequest) error {
+	sheet, startRow, endRow, startColumn, endColumn, err := s.gridRange(request.Range)
+	if err != nil {
+		return err
+	}
+
+	// pick returns the outer border if the side of the cell is at the edge of the range, the inner border otherwise
+	pick := func(edge bool, outer, inner *sheets.Border) *sheets.Border {
+		if edge {
+			return outer
+		}
+		return inner
+	}
+
+	for i := startRow; i < endRow; i++ {
+		for j := startColumn; j < endColumn; j++ {
+			sheet.setCellData(i, j, func(stored *sheets.CellData) {
+				format := mergeFormat(stored.UserEnteredFormat, nil, "")
+				borders := &sheets.Borders{}
+				if format.Borders != nil {
+					*borders = *format.Borders
+				}
+				sides := []struct {
+					border *sheets.Border
+					target **sheets.Border
+				}{
+					{pick(i == startRow, request.Top, request.InnerHorizontal), &borders.Top},
+					{pick(i == endRow-1, request.Bottom, request.InnerHorizontal), &borders.Bottom},
+					{pick(j == startColumn, request.Left, request.InnerVertical), &borders.Left},
+					{pick(j == endColumn-1, request.Right, request.InnerVertical), &borders.Right},
+				}
+				for _, side := range sides {
+					if side.border != nil {
+						*side.target = side.border
+					}
+				}
+				format.Borders = borders
+				stored.UserEnteredFormat = format
+			})
+		}
+	}
+	return nil
+}
+
+// mergeFormat returns a copy of a stored format with the fields of a mask taken from another format. A mask with the
+// whole "userEnteredFormat" replaces the stored format.
+func mergeFormat(stored, format *sheets.CellFormat, fields string) *sheets.CellFormat {
+	if format == nil {
+		format = &sheets.CellFormat{}
+	}
+	merged := &sheets.CellFormat{}
+	if stored != nil {
+		*merged = *stored
+	}
+
+	for _, field := range strings.Split(fields, ",") {
+		switch strings.TrimPrefix(strings.TrimSpace(field), "userEnteredFormat.") {
+		case "userEnteredFormat", "*":
+			copied := *format
+			merged = &copied
+		case "wrapStrategy":
+			merged.WrapStrategy = format.WrapStrategy
+		case "horizontalAlignment":
+			merged.HorizontalAlignment = format.HorizontalAlignment
+		case "verticalAlignment":
+			merged.VerticalAlignment = format.VerticalAlignment
+		case "numberFormat":
+			merged.NumberFormat = format.NumberFormat
+		case "backgroundColor":
+			merged.BackgroundColor = format.BackgroundColor
+		case "textFormat":
+			merged.TextFormat = format.TextFormat
+		case "textFormat.bold":
+			textFormat := &sheets.TextFormat{}
+			if merged.TextFormat != nil {
+				*textFormat = *merged.TextFormat
+			}
+			if format.TextFormat != nil {
+				textFormat.Bold = format.TextFormat.Bold
+			}
+			merged.TextFormat = textFormat
+		case "borders":
+			merged.Borders = format.Borders
+		}
+	}
+	return merged
+}
+
+// setDataValidation sets the data validation rule of the cells of the request range, or removes it if the rule is nil.
+func (s *fakeSpreadsheet) setDataValidation(request *sheets.SetDataValidationRequest) error {
+	sheet, startRow, endRow, startColumn, endColumn, err := s.gridRange(request.Range)
+	if err != nil {
+		return err
+	}
+
+	for i := startRow; i < endRow; i++ {
+		for j := startColumn; j < endColumn; j++ {
+			sheet.setCellData(i, j, func(stored *sheets.CellData) {
+				stored.DataValidation = request.Rule
+			})
+		}
+	}
+	return nil
+}
+
+// copyPaste applies a CopyPasteRequest pasting the formats or the data validation rules of the source range, repeated
+// over the destination range like the API. Other paste types are not supported by the fake.
+func (s *fakeSpreadsheet) copyPaste(request *sheets.CopyPasteRequest) error {
+	if request.PasteType != "PASTE_FORMAT" && request.PasteType != "PASTE_DATA_VALIDATION" {
+		return &fakeError{http.StatusBadRequest, fmt.Sprintf("Invalid requests[0].copyPaste: paste type %s is not supported by the fake", request.PasteType)}
+	}
+	source, sourceStartRow, sourceEndRow, sourceStartColumn, sourceEndColumn, err := s.gridRange(request.Source)
+	if err != nil {
+		return err
+	}
+	sheet, startRow, endRow, startColumn, endColumn, err := s.gridRange(request.Destination)
+	if err != nil {
+		return err
+	}
+
+	rows, columns := sourceEndRow-sourceStartRow, sourceEndColumn-sourceStartColumn
+	for i := startRow; i < endRow; i++ {
+		for j := startColumn; j < endColumn; j++ {
+			copied := source.cells[[2]int64{sourceStartRow + (i-startRow)%rows, sourceStartColumn + (j-startColumn)%columns}]
+			sheet.setCellData(i, j, func(stored *sheets.CellData) {
+				if request.PasteType == "PASTE_FORMAT" {
+					stored.UserEnteredFormat = nil
+					if copied != nil {
+						stored.UserEnteredFormat = copied.UserEnteredFormat
+					}
+					return
+				}
+				stored.DataValidation = nil
+				if copied != nil {
+					stored.DataValidation = copied.DataValidation
+				}
+			})
+		}
+	}
+	return nil
+}
+
+// gridRange resolves the sheet and the bounds of a grid range, using the size of the sheet for the unbounded sides.
+func (s *fakeSpreadsheet) gridRange(grid *sheets.GridRange) (*fakeSheet, int64, int64, int64, int64, error) {
+	sheet := s.sheetByID(grid.SheetId)
+	if sheet == nil {
+		return nil, 0, 0, 0, 0, &fakeError{http.StatusBadRequest, fmt.Sprintf("No grid with id: %d", grid.SheetId)}
+	}
+
+	endRow, endColumn := grid.EndRowIndex, grid.EndColumnIndex
+	if endRow == 0 {
+		endRow = sheet.properties.GridProperties.RowCount
+	}
+	if endColumn == 0 {
+		endColumn = sheet.properties.GridProperties.ColumnCount
+	}
+	return sheet, grid.StartRowIndex, endRow, grid.StartColumnIndex, endColumn, nil
+}
+
+// matchMetadata returns the developer metadata matching the lookups of the data filters. Only the key and the
+// location type of the lookups are supported.
+func (s *fakeSpreadsheet) matchMetadata(filters []*sheets.DataFilter) []*sheets.DeveloperMetadata {
+	var matches []*sheets.DeveloperMetadata
+	for _, metadata := range s.metadata {
+		for _, filter := range filters {
+			lookup := filter.DeveloperMetadataLookup
+			if lookup == nil || (lookup.MetadataKey != "" && lookup.MetadataKey != metadata.MetadataKey) {
+				continue
+			}
+			if lookup.LocationType == "SPREADSHEET" && !metadata.Location.Spreadsheet {
+				continue
+			}
+			if lookup.LocationType == "ROW" && (metadata.Location.DimensionRange == nil || metadata.Location.DimensionRange.Dimension != "ROWS") {
+				continue
+			}
+			matches = append(matches, metadata)
+			break
+		}
+	}
+	return matches
+}
+
+// updateDeveloperMetadata applies an UpdateDeveloperMetadataRequest. Only the value of the metadata can be updated.
+func (s *fakeSpreadsheet) updateDeveloperMetadata(request *sheets.UpdateDeveloperMetadataRequest) error {
+	if request.Fields != "metadataValue" {
+		return &fakeError{http.StatusBadRequest, "unsupported fields " + request.Fields}
+	}
+	matches := s.matchMetadata(request.DataFilters)
+	if len(matches) == 0 {
+		return &fakeError{http.StatusBadRequest, "No developer metadata matches the data filters."}
+	}
+	for _, metadata := range matches {
+		metadata.MetadataValue = request.DeveloperMetadata.MetadataValue
+	}
+	return nil
+}
+
+// addBanding adds a banded range to a sheet, refusing ranges that overlap an existing banded range like the API does.
+func (s *fakeSpreadsheet) addBanding(banding *sheets.BandedRange) (*sheets.BandedRange, error) {
+	sheet, startRow, endRow, startColumn, endColumn, err := s.gridRange(banding.Range)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, existing := range sheet.bandings {
+		_, otherStartRow, otherEndRow, otherStartColumn, otherEndColumn, _ := s.gridRange(existing.Range)
+		if startRow < otherEndRow && otherStartRow < endRow && startColumn < otherEndColumn && otherStartColumn < endColumn {
+			return nil, &fakeError{http.StatusBadRequest, "Invalid requests[0].addBanding: Alternating background colors cannot overlap."}
+		}
+	}
+
+	added := *banding
+	added.BandedRangeId = int64(len(sheet.bandings) + 1)
+	sheet.bandings = append(sheet.bandings, &added)
+	return &added, nil
+}
+
+// updateBanding applies an UpdateBandingRequest. Only the range of the banded range can be updated, and like
+// addBanding the new range must not overlap another banded range.
+func (s *fakeSpreadsheet) updateBanding(request *sheets.UpdateBandingRequest) error {
+	if request.Fields != "range" {
+		return &fakeError{http.StatusBadRequest, "Invalid requests[0].updateBanding: only the range is supported by the fake"}
+	}
+
+	for _, sheet := range s.sheets {
+		for i, existing := range sheet.bandings {
+			if existing.BandedRangeId != request.BandedRange.BandedRangeId {
+				continue
+			}
+
+			others := slices.Delete(slices.Clone(sheet.bandings), i, i+1)
+			updated := *existing
+			updated.Range = request.BandedRange.Range
+			check := &fakeSpreadsheet{sheets: []*fakeSheet{{properties: sheet.properties, bandings: others}}}
+			if _, err := check.addBanding(&updated); err != nil {
+				return err
+			}
+			sheet.bandings[i] = &updated // Replaced, so the clones taken for rollbacks keep the old range
+			return nil
+		}
+	}
+	return &fakeError{http.StatusBadRequest, fmt.Sprintf("No banded range with id: %d", request.BandedRange.BandedRangeId)}
+}
+
+// clone returns a deep copy of the spreadsheet, used to roll back failed batch updates.
+func (s *fakeSpreadsheet) clone() *fakeSpreadsheet {
+	c := &fakeSpreadsheet{id: s.id, title: s.title, timeZone: s.timeZone, locale: s.locale}
+	c.namedRanges = slices.Clone(s.namedRanges) // Named ranges are never modified, only added
+	for _, metadata := range s.metadata {
+		copied := *metadata
+		c.metadata = append(c.metadata, &copied)
+	}
+	for _, sheet := range s.sheets {
+		properties := *sheet.properties
+		gridProperties := *sheet.properties.GridProperties
+		properties.GridProperties = &gridProperties
+
+		values := make([][]interface{}, len(sheet.values))
+		for i, row := range sheet.values {
+			values[i] = append([]interface{}(nil), row...)
+		}
+		cells := make(map[[2]int64]*sheets.CellData, len(sheet.cells))
+		for key, cell := range sheet.cells {
+			copied := *cell
+			cells[key] = &copied
+		}
+		rows := make(map[int64]*sheets.DimensionProperties, len(sheet.rows))
+		for key, row := range sheet.rows {
+			copied := *row
+			rows[key] = &copied
+		}
+		bandings := slices.Clone(sheet.bandings)               // Banded ranges are replaced, never modified in place
+		protectedRanges := slices.Clone(sheet.protectedRanges) // Protected ranges are never modified, only added
+		c.sheets = append(c.sheets, &fakeSheet{properties: &properties, values: values, cells: cells, rows: rows,
+			bandings: bandings, protectedRanges: protectedRanges})
+	}
+	return c
+}
+
+// gridData returns the cell data of a range of the sheet, omitting the trailing empty rows and cells like the API.
+func (s *fakeSheet) gridData(grid a1Range) *sheets.GridData {
+	data := &sheets.GridData{StartRow: grid.StartRow, StartColumn: grid.StartColumn}
+
+	endRow, endColumn := grid.EndRow, grid.EndColumn
+	if endRow == -1 {
+		endRow = s.properties.GridProperties.RowCount
+	}
+	if endColumn == -1 {
+		endColumn = s.properties.GridProperties.ColumnCount
+	}
+
+	for i := grid.StartRow; i < endRow; i++ {
+		metadata := &sheets.DimensionProperties{PixelSize: 21}
+		if stored, ok := s.rows[i]; ok {
+			metadata = stored
+		}
+		data.RowMetadata = append(data.RowMetadata, metadata)
+
+		rowData := &sheets.RowData{}
+		for j := grid.StartColumn; j < endColumn; j++ {
+			rowData.Values = append(rowData.Values, s.cellData(i, j))
+		}
+		for len(rowData.Values) > 0 && isEmptyCellData(rowData.Values[len(rowData.Values)-1]) {
+			rowData.Values = rowData.Values[:len(rowData.Values)-1]
+		}
+		data.RowData = append(data.RowData, rowData)
+	}
+	for len(data.RowData) > 0 && len(data.RowData[len(data.RowData)-1].Values) == 0 {
+		data.RowData = data.RowData[:len(data.RowData)-1]
+	}
+	return data
+}
+
+// cellData returns the value and the attributes of a cell.
+func (s *fakeSheet) cellData(row, column int64) *sheets.CellData {
+	cell := &sheets.CellData{}
+	if stored, ok := s.cells[[2]int64{row, column}]; ok {
+		*cell = *stored
+	}
+	if format := cell.UserEnteredFormat; format != nil && format.TextFormat != nil && format.TextFormat.Link != nil {
+		cell.Hyperlink = format.TextFormat.Link.Uri // The link of the whole text is the link of the cell
+	}
+
+	var value interface{}
+	if row < int64(len(s.values)) && column < int64(len(s.values[row])) {
+		value = s.values[row][column]
+	}
+	if cell.EffectiveFormat == nil && (cell.UserEnteredFormat != nil || (value != nil && value != "")) {
+		cell.EffectiveFormat = fakeEffectiveFormat(cell.UserEnteredFormat)
+	}
+	if value == nil || value == "" {
+		return cell
+	}
+
+	cell.UserEnteredValue = fakeExtendedValue(value)
+	cell.EffectiveValue = fakeExtendedValue(value)
+	if formula, ok := value.(string); ok && strings.HasPrefix(formula, "=") {
+		// Formulas are not evaluated, their effective value is the formula text, or an error for the formulas
+		// recognized by fakeFormulaError.
+		cell.EffectiveValue = &sheets.ExtendedValue{StringValue: &formula}
+		if errorValue := fakeFormulaError(formula); errorValue != nil {
+			cell.EffectiveValue = &sheets.ExtendedValue{ErrorValue: errorValue}
+		}
+	}
+	cell.FormattedValue = renderValue(value, "").(string)
+	return cell
+}
+
+// fakeEffectiveFormat returns the effective format of a cell with the given user-entered format: the default format
+// of the spreadsheet with the fields set in the user-entered format replaced. Tests simulate conditional formatting
+// by storing the effective format of a cell directly.
+func fakeEffectiveFormat(userEntered *sheets.CellFormat) *sheets.CellFormat {
+	format := &sheets.CellFormat{
+		BackgroundColor:   &sheets.Color{Red: 1, Green: 1, Blue: 1},
+		TextFormat:        &sheets.TextFormat{FontFamily: "Arial", FontSize: 10, ForegroundColor: &sheets.Color{}},
+		VerticalAlignment: "BOTTOM",
+		WrapStrategy:      "OVERFLOW_CELL",
+	}
+	if userEntered == nil {
+		return format
+	}
+
+	if userEntered.NumberFormat != nil {
+		format.NumberFormat = userEntered.NumberFormat
+	}
+	if userEntered.BackgroundColor != nil {
+		format.BackgroundColor = userEntered.BackgroundColor
+	}
+	if userEntered.TextFormat != nil {
+		format.TextFormat = userEntered.TextFormat
+	}
+	if userEntered.HorizontalAlignment != "" {
+		format.HorizontalAlignment = userEntered.HorizontalAlignment
+	}
+	if userEntered.VerticalAlignment != "" {
+		format.VerticalAlignment = userEntered.VerticalAlignment
+	}
+	if userEntered.WrapStrategy != "" {
+		format.WrapStrategy = userEntered.WrapStrategy
+	}
+	format.Borders = userEntered.Borders
+	return format
+}
+
+// fakeFormulaError returns the error a formula evaluates to, for a few formulas that always fail: references to
+// deleted cells (#REF!), divisions by a literal zero and NA().
+func fakeFormulaError(formula string) *sheets.ErrorValue {
+	switch {
+	case strings.Contains(formula, "#REF!"):
+		return &sheets.ErrorValue{Type: "REF", Message: "Reference does not exist."}
+	case strings.HasSuffix(formula, "/0"):
+		return &sheets.ErrorValue{Type: "DIVIDE_BY_ZERO", Message: "Function DIVIDE parameter 2 cannot be zero."}
+	case strings.Contains(formula, "NA()"):
+		return &sheets.ErrorValue{Type: "N_A", Message: "Value not available."}
+	}
+	return nil
+}
+
+// setCellData merges the attributes of a cell, other than the value, into the stored ones.
+func (s *fakeSheet) setCellData(row, column int64, update func(cell *sheets.CellData)) {
+	if s.cells == nil {
+		s.cells = map[[2]int64]*sheets.CellData{}
+	}
+	key := [2]int64{row, column}
+	if s.cells[key] == nil {
+		s.cells[key] = &sheets.CellData{}
+	}
+	update(s.cells[key])
+}
+
+// isEmptyCellData reports whether a cell has neither a value nor any attribute.
+func isEmptyCellData(cell *sheets.CellData) bool {
+	return cell.UserEnteredValue == nil && cell.UserEnteredFormat == nil && cell.EffectiveFormat == nil &&
+		cell.Note == "" && cell.DataValidation == nil && cell.Hyperlink == ""
+}
+
+// fakeExtendedValue converts a stored value to a cell value of the API.
+func fakeExtendedValue(value interface{}) *sheets.ExtendedValue {
+	switch v := value.(type) {
+	case float64:
+		return &sheets.ExtendedValue{NumberValue: &v}
+	case bool:
+		return &sheets.ExtendedValue{BoolValue: &v}
+	case string:
+		if strings.HasPrefix(v, "=") {
+			return &sheets.ExtendedValue{FormulaValue: &v}
+		}
+		return &sheets.ExtendedValue{StringValue: &v}
+	}
+	text := fmt.Sprint(value)
+	return &sheets.ExtendedValue{StringValue: &text}
+}
+
+// lastRow returns the 0-based index of the row after the last non-empty row of the sheet.
+func (s *fakeSheet) lastRow() int64 {
+	for i := len(s.values) - 1; i >= 0; i-- {
+		if len(trimRow(s.values[i])) > 0 {
+			return int64(i + 1)
+		}
+	}
+	return 0
+}
+
+// write stores the values with their top-left cell at the given 0-based indexes, growing the grid if needed.
+func (s *fakeSheet) write(startRow, startColumn int64, values [][]interface{}) *sheets.UpdateValuesResponse {
+	columns := 0
+	for i, row := range values {
+		rowIndex := startRow + int64(i)
+		for int64(len(s.values)) <= rowIndex {
+			s.values = append(s.values, nil)
+		}
+		for int64(len(s.values[rowIndex])) < startColumn+int64(len(row)) {
+			s.values[rowIndex] = append(s.values[rowIndex], nil)
+		}
+		copy(s.values[rowIndex][startColumn:], row)
+		columns = max(columns, len(row))
+	}
+
+	grid := s.properties.GridProperties
+	grid.RowCount = max(grid.RowCount, int64(len(s.values)))
+	grid.ColumnCount = max(grid.ColumnCount, startColumn+int64(columns))
+
+	resp := &sheets.UpdateValuesResponse{UpdatedRows: int64(len(values)), UpdatedColumns: int64(columns)}
+	if len(values) > 0 && columns > 0 {
+		resp.UpdatedRange = fmt.Sprintf("%s!%s%d:%s%d", s.properties.Title,
+			fakeColumnLetter(startColumn), startRow+1,
+			fakeColumnLetter(startColumn+int64(columns)-1), startRow+int64(len(values)))
+		resp.UpdatedCells = int64(len(values) * columns)
+	}
+	return resp
+}
+
+// trimRow removes the trailing empty cells of a row, as the API does.
+func trimRow(row []interface{}) []interface{} {
+	for len(row) > 0 && (row[len(row)-1] == nil || row[len(row)-1] == "") {
+		row = row[:len(row)-1]
+	}
+	return row
+}
+
+// renderValue converts a stored value according to the valueRenderOption of the request.
+func renderValue(value interface{}, render string) interface{} {
+	if value == nil {
+		return ""
+	}
+	if render == "UNFORMATTED_VALUE" || render == "FORMULA" {
+		return value
+	}
+
+	switch v := value.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strings.ToUpper(strconv.FormatBool(v))
+	}
+	return fmt.Sprint(value)
+}
+
+// fakeColumnLetter converts a 0-based column index to its letter (e.g., 0 to "A", 27 to "AB").
+func fakeColumnLetter(index int64) string {
+	letter := ""
+	for index++; index > 0; index = (index - 1) / 26 {
+		letter = string(rune('A'+(index-1)%26)) + letter
+	}
+	return letter
+}
+
+// writeJSON writes a successful JSON response.
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error response in the format of the Google APIs.
+func writeError(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+	if fe, ok := err.(*fakeError); ok {
+		code = fe.code
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": map[string]interface{}{"code": code, "message": err.Error()},
+	})
+}
@@ -0,0 +1,941 @@
+package gosheets
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// RichCell represents the value of a cell together with its formatting.
+//
+//   - The Value field is the value entered in the cell: a float64, string or bool, a string starting with "=" for
+//     formulas, or nil for empty cells.
+//   - The NumberFormat field is the number format of the cell (e.g., a date or currency pattern), nil if not set.
+//   - The BackgroundColor field is the background color of the cell, nil if not set.
+//   - The TextFormat field is the text format of the cell (font, size, bold, italic, color, etc.), nil if not set.
+type RichCell struct {
+	Value           interface{}
+	NumberFormat    *sheets.NumberFormat
+	BackgroundColor *sheets.Color
+	TextFormat      *sheets.TextFormat
+}
+
+// richCellFields is the field mask of the cell data read into a RichCell.
+const richCellFields = "userEnteredValue,userEnteredFormat(numberFormat,backgroundColor,textFormat)"
+
+// ReadRichData reads the values and the formatting of a range of the current set sheet in the GoogleSheetsClient struct.
+// Unlike ReadData, which only returns values, the result keeps enough information to copy a formatted region.
+//
+// Parameters:
+//   - rangeA1: The range of cells to read data from (e.g., "A1:B2").
+//
+// Returns:
+//   - A 2D slice of RichCell values, or an error if there was a problem.
+func (gs *GoogleSheetsClient) ReadRichData(rangeA1 string) ([][]RichCell, error) {
+	gridData, err := gs.getGridData(rangeA1, "rowData(values("+richCellFields+"))")
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([][]RichCell, len(gridData.RowData))
+	for i, rowData := range gridData.RowData {
+		row := make([]RichCell, len(rowData.Values))
+		for j, cellData := range rowData.Values {
+			row[j].Value = extendedValueToInterface(cellData.UserEnteredValue)
+			if format := cellData.UserEnteredFormat; format != nil {
+				row[j].NumberFormat = format.NumberFormat
+				row[j].BackgroundColor = format.BackgroundColor
+				row[j].TextFormat = format.TextFormat
+			}
+		}
+		data[i] = row
+	}
+
+	return data, nil
+}
+
+// CellFormatInfo is the effective format of a cell: the format it is displayed with, resolved from the format
+// entered for the cell, the defaults of the spreadsheet and the conditional formatting rules that apply to it.
+//
+//   - The NumberFormat field is the number format of the cell (e.g., a date or currency pattern), nil if not set.
+//   - The BackgroundColor field is the background color of the cell.
+//   - The TextFormat field is the text format of the cell (font, size, bold, italic, color, etc.).
+//   - The HorizontalAlignment and VerticalAlignment fields are the alignment of the cell (e.g., "LEFT", "BOTTOM").
+//   - The WrapStrategy field is how the text that does not fit in the cell is shown (e.g., "OVERFLOW_CELL").
+//   - The Borders field is the borders of the cell, nil if it has none.
+type CellFormatInfo struct {
+	NumberFormat        *sheets.NumberFormat
+	BackgroundColor     *sheets.Color
+	TextFormat          *sheets.TextFormat
+	HorizontalAlignment string
+	VerticalAlignment   string
+	WrapStrategy        string
+	Borders             *sheets.Borders
+}
+
+// GetEffectiveFormat reads the effective format of a cell of the current set sheet in the GoogleSheetsClient
+// struct. Unlike ReadRichData, which returns the format entered for the cells, it returns the format the cell is
+// displayed with, including the formatting inherited from the defaults or applied by conditional formatting rules.
+//
+// Parameters:
+//   - cell: The cell to read (e.g., "B2").
+//
+// Returns:
+//   - The effective format of the cell, with empty fields if the API returns no format for it.
+//   - An error if the cell reference is not a single cell or there was a problem reading it, nil otherwise.
+func (gs *GoogleSheetsClient) GetEffectiveFormat(cell string) (*CellFormatInfo, error) {
+	column, row, err := parseA1Cell(cell)
+	if err != nil || column == -1 || row == -1 {
+		return nil, fmt.Errorf("invalid cell %q: use a single cell (e.g., \"B2\")", cell)
+	}
+
+	gridData, err := gs.getGridData(cell, "rowData(values(effectiveFormat))")
+	if err != nil {
+		return nil, err
+	}
+
+	info := &CellFormatInfo{}
+	if len(gridData.RowData) == 0 || len(gridData.RowData[0].Values) == 0 || gridData.RowData[0].Values[0].EffectiveFormat == nil {
+		return info, nil
+	}
+
+	format := gridData.RowData[0].Values[0].EffectiveFormat
+	info.NumberFormat = format.NumberFormat
+	info.BackgroundColor = format.BackgroundColor
+	info.TextFormat = format.TextFormat
+	info.HorizontalAlignment = format.HorizontalAlignment
+	info.VerticalAlignment = format.VerticalAlignment
+	info.WrapStrategy = format.WrapStrategy
+	info.Borders = format.Borders
+	return info, nil
+}
+
+// WriteRichData writes the values and the formatting of a block of cells to the current set sheet in the
+// GoogleSheetsClient struct, starting at the given cell. It is the counterpart of ReadRichData, so a formatted
+// region can be copied by reading it with ReadRichData and writing it elsewhere with this method. Cells with a
+// nil NumberFormat, BackgroundColor or TextFormat have that formatting cleared.
+//
+// Parameters:
+//   - startCell: The top-left cell of the block to write (e.g., "B2").
+//   - cells: A 2D slice of RichCell values, each inner slice representing a row.
+//
+// Returns:
+//   - An error if there was a problem writing the cells, nil otherwise.
+func (gs *GoogleSheetsClient) WriteRichData(startCell string, cells [][]RichCell) error {
+	start, err := parseA1Range(startCell)
+	if err != nil {
+		return err
+	}
+
+	err = gs.requireScope(writeScopes...)
+	if err != nil {
+		return err
+	}
+
+	sheetID, err := gs.getSheetID()
+	if err != nil {
+		return fmt.Errorf("unable to retrieve sheet ID: %w", err)
+	}
+
+	rows := make([]*sheets.RowData, len(cells))
+	for i, row := range cells {
+		rowData := &sheets.RowData{Values: make([]*sheets.CellData, len(row))}
+		for j, cell := range row {
+			rowData.Values[j] = &sheets.CellData{
+				UserEnteredValue: interfaceToExtendedValue(cell.Value),
+				UserEnteredFormat: &sheets.CellFormat{
+					NumberFormat:    cell.NumberFormat,
+					BackgroundColor: cell.BackgroundColor,
+					TextFormat:      cell.TextFormat,
+				},
+			}
+		}
+		rows[i] = rowData
+	}
+
+	request := &sheets.Request{
+		UpdateCells: &sheets.UpdateCellsRequest{
+			Start: &sheets.GridCoordinate{
+				SheetId:     sheetID,
+				RowIndex:    start.StartRow,
+				ColumnIndex: start.StartColumn,
+			},
+			Rows:   rows,
+			Fields: richCellFields,
+		},
+	}
+
+	batchUpdateRequest := &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{request},
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	_, err = gs.service.Spreadsheets.BatchUpdate(gs.spreadsheetID, batchUpdateRequest).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("unable to write cells to Google Sheets: %w", gs.apiError(err))
+	}
+
+	if start.StartRow == 0 {
+		gs.invalidateHeaders() // The header row was overwritten
+	}
+	gs.audit("WriteRichData", gs.sheetName, startCell, fmt.Sprintf("%d rows", len(cells)))
+	return nil
+}
+
+// Values accepted by SetTextWrap and SetAlignment.
+var (
+	wrapStrategies       = []string{"OVERFLOW_CELL", "CLIP", "WRAP"}
+	horizontalAlignments = []string{"LEFT", "CENTER", "RIGHT"}
+	verticalAlignments   = []string{"TOP", "MIDDLE", "BOTTOM"}
+)
+
+// borderStyles are the border styles accepted by SetBorders.
+var borderStyles = []string{"DOTTED", "DASHED", "SOLID", "SOLID_MEDIUM", "SOLID_THICK", "DOUBLE", "NONE"}
+
+// Border is the style of one side of the borders set by SetBorders.
+//
+//   - The Style field is one of DOTTED, DASHED, SOLID, SOLID_MEDIUM, SOLID_THICK, DOUBLE or NONE to remove the border.
+//   - The Color field is the color of the border, nil for black.
+type Border struct {
+	Style string
+	Color *sheets.Color
+}
+
+// BorderSpec describes the borders set by SetBorders. A nil side leaves the borders of the range on that side unchanged.
+//
+//   - The Top, Bottom, Left and Right fields are the borders around the range.
+//   - The InnerHorizontal and InnerVertical fields are the borders between the rows and between the columns of the range.
+type BorderSpec struct {
+	Top             *Border
+	Bottom          *Border
+	Left            *Border
+	Right           *Border
+	InnerHorizontal *Border
+	InnerVertical   *Border
+}
+
+// SetTextWrap sets how the text that does not fit in the cells of a range of the current set sheet in the
+// GoogleSheetsClient struct is shown.
+//
+// Parameters:
+//   - rangeA1: The range of cells to format (e.g., "A1:F100").
+//   - strategy: OVERFLOW_CELL to let the text overflow into the next empty cells, CLIP to cut it at the cell
+//     border or WRAP to break it into several lines.
+//
+// Returns:
+//   - An error if the strategy or the range is not valid or there was a problem formatting the cells, nil otherwise.
+func (gs *GoogleSheetsClient) SetTextWrap(rangeA1 string, strategy string) error {
+	if !slices.Contains(wrapStrategies, strategy) {
+		return fmt.Errorf("invalid wrap strategy %q, use one of %v", strategy, wrapStrategies)
+	}
+
+	format := &sheets.CellFormat{WrapStrategy: strategy}
+	err := gs.repeatCellFormat(rangeA1, format, "userEnteredFormat.wrapStrategy")
+	if err != nil {
+		return err
+	}
+
+	gs.audit("SetTextWrap", gs.sheetName, rangeA1, "wrap strategy "+strategy)
+	return nil
+}
+
+// SetAlignment sets the horizontal and vertical alignment of the cells of a range of the current set sheet in the
+// GoogleSheetsClient struct. An empty alignment leaves that direction unchanged.
+//
+// Parameters:
+//   - rangeA1: The range of cells to format (e.g., "A1:F1").
+//   - horizontal: LEFT, CENTER or RIGHT, or an empty string to keep the horizontal alignment of the cells.
+//   - vertical: TOP, MIDDLE or BOTTOM, or an empty string to keep the vertical alignment of the cells.
+//
+// Returns:
+//   - An error if both alignments are empty, an alignment or the range is not valid or there was a problem
+//     formatting the cells, nil otherwise.
+func (gs *GoogleSheetsClient) SetAlignment(rangeA1 string, horizontal, vertical string) error {
+	if horizontal == "" && vertical == "" {
+		return fmt.Errorf("no alignment given, set the horizontal alignment, the vertical alignment or both")
+	}
+	if horizontal != "" && !slices.Contains(horizontalAlignments, horizontal) {
+		return fmt.Errorf("invalid horizontal alignment %q, use one of %v", horizontal, horizontalAlignments)
+	}
+	if vertical != "" && !slices.Contains(verticalAlignments, vertical) {
+		return fmt.Errorf("invalid vertical alignment %q, use one of %v", vertical, verticalAlignments)
+	}
+
+	format := &sheets.CellFormat{HorizontalAlignment: horizontal, VerticalAlignment: vertical}
+
+	var fields []string
+	if horizontal != "" {
+		fields = append(fields, "userEnteredFormat.horizontalAlignment")
+	}
+	if vertical != "" {
+		fields = append(fields, "userEnteredFormat.verticalAlignment")
+	}
+	err := gs.repeatCellFormat(rangeA1, format, strings.Join(fields, ","))
+	if err != nil {
+		return err
+	}
+
+	gs.audit("SetAlignment", gs.sheetName, rangeA1, fmt.Sprintf("horizontal %q, vertical %q", horizontal, vertical))
+	return nil
+}
+
+// SetBorders sets the borders of a range of the current set sheet in the GoogleSheetsClient struct.
+//
+// Parameters:
+//   - rangeA1: The range of cells to add the borders to (e.g., "A1:F20").
+//   - border: The borders to set. The sides left nil are not changed.
+//
+// Returns:
+//   - An error if no side is set, a style or the range is not valid or there was a problem setting the borders,
+//     nil otherwise.
+func (gs *GoogleSheetsClient) SetBorders(rangeA1 string, border BorderSpec) error {
+	parsedRange, err := parseA1Range(rangeA1)
+	if err != nil {
+		return err
+	}
+
+	request := &sheets.UpdateBordersRequest{}
+	sides := []struct {
+		name   string
+		border *Border
+		target **sheets.Border
+	}{
+		{"top", border.Top, &request.Top},
+		{"bottom", border.Bottom, &request.Bottom},
+		{"left", border.Left, &request.Left},
+		{"right", border.Right, &request.Right},
+		{"inner horizontal", border.InnerHorizontal, &request.InnerHorizontal},
+		{"inner vertical", border.InnerVertical, &request.InnerVertical},
+	}
+
+	set := false
+	for _, side := range sides {
+		if side.border == nil {
+			continue
+		}
+		if !slices.Contains(borderStyles, side.border.Style) {
+			return fmt.Errorf("invalid %s border style %q, use one of %v", side.name, side.border.Style, borderStyles)
+		}
+		*side.target = &sheets.Border{Style: side.border.Style, Color: side.border.Color}
+		set = true
+	}
+	if !set {
+		return fmt.Errorf("no border given, set at least one side of the border spec")
+	}
+
+	err = gs.requireScope(writeScopes...)
+	if err != nil {
+		return err
+	}
+
+	sheetID, err := gs.getSheetID()
+	if err != nil {
+		return fmt.Errorf("unable to retrieve sheet ID: %w", err)
+	}
+	request.Range = parsedRange.gridRange(sheetID)
+
+	batchUpdateRequest := &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{{UpdateBorders: request}},
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	_, err = gs.service.Spreadsheets.BatchUpdate(gs.spreadsheetID, batchUpdateRequest).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("unable to set borders: %w", gs.apiError(err))
+	}
+
+	gs.audit("SetBorders", gs.sheetName, rangeA1, "borders set")
+	return nil
+}
+
+// AddBanding applies alternating row colors to a range of the current set sheet in the GoogleSheetsClient struct,
+// with a different color for its first row. A range can only have one banding, and bandings cannot overlap, so the
+// API rejects a range that intersects an existing banding.
+//
+// Parameters:
+//   - rangeA1: The range to band, including its header row (e.g., "A1:F100" or "A:F").
+//   - headerColor: The red, green and blue components, from 0 to 1, of the color of the first row.
+//   - band1Color: The color of the odd rows after the first row, in the same format.
+//   - band2Color: The color of the even rows after the first row, in the same format.
+//
+// Returns:
+//   - An error if the range or a color is not valid, or there was a problem adding the banding, nil otherwise.
+func (gs *GoogleSheetsClient) AddBanding(rangeA1 string, headerColor, band1Color, band2Color [3]float64) error {
+	parsedRange, err := parseA1Range(rangeA1)
+	if err != nil {
+		return err
+	}
+
+	colors := []struct {
+		name string
+		rgb  [3]float64
+	}{
+		{"header", headerColor},
+		{"first band", band1Color},
+		{"second band", band2Color},
+	}
+	for _, color := range colors {
+		for _, component := range color.rgb {
+			if component < 0 || component > 1 || math.IsNaN(component) {
+				return fmt.Errorf("invalid %s color %v, the components must be between 0 and 1", color.name, color.rgb)
+			}
+		}
+	}
+
+	err = gs.requireScope(writeScopes...)
+	if err != nil {
+		return err
+	}
+
+	sheetID, err := gs.getSheetID()
+	if err != nil {
+		return fmt.Errorf("unable to retrieve sheet ID: %w", err)
+	}
+
+	request := &sheets.Request{
+		AddBanding: &sheets.AddBandingRequest{
+			BandedRange: &sheets.BandedRange{
+				Range: parsedRange.gridRange(sheetID),
+				RowProperties: &sheets.BandingProperties{
+					HeaderColor:     rgbColor(headerColor),
+					FirstBandColor:  rgbColor(band1Color),
+					SecondBandColor: rgbColor(band2Color),
+				},
+			},
+		},
+	}
+
+	batchUpdateRequest := &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{request},
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	_, err = gs.service.Spreadsheets.BatchUpdate(gs.spreadsheetID, batchUpdateRequest).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("unable to add banding: %w", gs.apiError(err))
+	}
+
+	gs.audit("AddBanding", gs.sheetName, rangeA1, "banding added")
+	return nil
+}
+
+// AppendBanded appends data to the end of the current set sheet in the GoogleSheetsClient struct like AppendData,
+// then extends the banding of the table to the new rows, since a banded range does not grow on its own when rows are
+// appended below it. The banding extended is the one that ends the closest above the appended rows and shares
+// columns with them (see AddBanding); a banding that already covers the new rows, e.g., over whole columns such as
+// "A:F", is left as it is.
+//
+// Parameters:
+//   - data: A 2D slice representing the data to be added. Each inner slice
+//     represents a row of data, with each element representing a cell value.
+//   - range_: The cell used to search for existing data and find a "table"
+//     within that range where the data will be appended (e.g., "A1"), or an empty string to use the table
+//     range of the sheet (see SetTableRange).
+//
+// Returns:
+//   - An error if there was a problem adding the data or extending the banding, nil otherwise. The rows are
+//     appended without a banding when no banding is above them. Empty or nil data is a no-op that returns nil
+//     without calling the API.
+func (gs *GoogleSheetsClient) AppendBanded(data [][]interface{}, range_ string) error {
+	if len(data) == 0 {
+		return nil // Nothing to append, avoid spending an API call
+	}
+
+	err := gs.resolveSheetName()
+	if err != nil {
+		return err
+	}
+
+	updatedRange, err := gs.appendRows(gs.sheetName, data, range_)
+	if err != nil {
+		return err
+	}
+
+	target, _ := gs.rangeOrTable(gs.sheetName, range_)
+	gs.audit("AppendBanded", gs.sheetName, target, fmt.Sprintf("%d rows", len(data)))
+
+	if updatedRange == "" {
+		return nil // Only empty rows, nothing was written
+	}
+	appended, err := parseA1Range(updatedRange[strings.LastIndex(updatedRange, "!")+1:])
+	if err != nil {
+		return fmt.Errorf("unable to parse the range %q: %w", updatedRange, err)
+	}
+
+	banding, err := gs.bandingAbove(appended)
+	if err != nil || banding == nil {
+		return err
+	}
+
+	bandedRange := a1RangeFromGrid(banding.Range)
+	if bandedRange.EndRow == -1 || bandedRange.EndRow >= appended.EndRow {
+		return nil // The banding already covers the new rows
+	}
+	bandedRange.EndRow = appended.EndRow
+
+	batchUpdateRequest := &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{{
+			UpdateBanding: &sheets.UpdateBandingRequest{
+				BandedRange: &sheets.BandedRange{
+					BandedRangeId:   banding.BandedRangeId,
+					Range:           bandedRange.gridRange(banding.Range.SheetId),
+					ForceSendFields: []string{"BandedRangeId"},
+				},
+				Fields: "range",
+			},
+		}},
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	_, err = gs.service.Spreadsheets.BatchUpdate(gs.spreadsheetID, batchUpdateRequest).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("unable to extend the banding to the appended rows: %w", gs.apiError(err))
+	}
+	return nil
+}
+
+// bandingAbove finds the banded range of the current set sheet that a block of appended rows continues: the one
+// that starts above the rows, shares columns with them and ends the closest to them.
+//
+// Parameters:
+//   - appended: The range of the appended rows.
+//
+// Returns:
+//   - The banded range, or nil if no banded range is above the rows.
+//   - An error if there was a problem retrieving the banded ranges.
+func (gs *GoogleSheetsClient) bandingAbove(appended a1Range) (*sheets.BandedRange, error) {
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	spreadsheet, err := gs.service.Spreadsheets.Get(gs.spreadsheetID).Fields("sheets(properties.title,bandedRanges)").Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve banded ranges: %w", gs.apiError(err))
+	}
+
+	columns := a1Range{StartRow: 0, EndRow: -1, StartColumn: appended.StartColumn, EndColumn: appended.EndColumn}
+
+	var closest *sheets.BandedRange
+	for _, sheet := range spreadsheet.Sheets {
+		if sheet.Properties.Title != gs.sheetName {
+			continue
+		}
+		for _, banding := range sheet.BandedRanges {
+			r := a1RangeFromGrid(banding.Range)
+			if r.StartRow >= appended.StartRow || !r.intersects(columns) {
+				continue
+			}
+			if closest == nil || r.EndRow == -1 || (closest.Range.EndRowIndex != 0 && r.EndRow > closest.Range.EndRowIndex) {
+				closest = banding
+			}
+		}
+	}
+	return closest, nil
+}
+
+// inheritRowFormat copies the formats and the data validation rules (e.g., dropdowns, checkboxes and number
+// formats) of the last row above a block of appended rows onto them, for the appends made with
+// WithInheritRowFormat. The formats and the rules are pasted in a single batch update.
+//
+// Parameters:
+//   - sheetName: The name of the sheet the rows were appended to.
+//   - tableRange: The range the rows were appended to, without the sheet name. Its first row is the header row of
+//     the table, which is never copied.
+//   - updatedRange: The A1 range the rows were written to, as reported by the API (e.g., "Sheet1!A5:C7").
+//
+// Returns:
+//   - An error if there was a problem copying the format, nil otherwise. Nothing is copied when the table had no
+//     data row before the append.
+func (gs *GoogleSheetsClient) inheritRowFormat(sheetName, tableRange, updatedRange string) error {
+	appended, err := parseA1Range(updatedRange[strings.LastIndex(updatedRange, "!")+1:])
+	if err != nil {
+		return fmt.Errorf("unable to parse the range %q: %w", updatedRange, err)
+	}
+
+	headerRow := int64(0)
+	if table, err := parseA1Range(tableRange); err == nil && table.StartRow != -1 {
+		headerRow = table.StartRow
+	}
+	if appended.StartRow-1 <= headerRow {
+		return nil // No data row above the new rows to copy from
+	}
+
+	sheetID, err := gs.sheetIDOf(sheetName)
+	if err != nil {
+		return err
+	}
+
+	source := appended
+	source.StartRow, source.EndRow = appended.StartRow-1, appended.StartRow
+
+	var requests []*sheets.Request
+	for _, pasteType := range []string{"PASTE_FORMAT", "PASTE_DATA_VALIDATION"} {
+		requests = append(requests, &sheets.Request{
+			CopyPaste: &sheets.CopyPasteRequest{
+				Source:      source.gridRange(sheetID),
+				Destination: appended.gridRange(sheetID),
+				PasteType:   pasteType,
+			},
+		})
+	}
+
+	batchUpdateRequest := &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: requests,
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	_, err = gs.service.Spreadsheets.BatchUpdate(gs.spreadsheetID, batchUpdateRequest).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("unable to copy the format of the row above to the appended rows: %w", gs.apiError(err))
+	}
+	return nil
+}
+
+// sheetIDOf returns the ID of a sheet of the spreadsheet set in the GoogleSheetsClient struct, from the cache for
+// the current set sheet.
+//
+// Parameters:
+//   - sheetName: The name of the sheet.
+//
+// Returns:
+//   - The ID of the sheet, or an error if no sheet has the name or there was a problem retrieving the sheets.
+func (gs *GoogleSheetsClient) sheetIDOf(sheetName string) (int64, error) {
+	if sheetName == gs.sheetName {
+		sheetID, err := gs.getSheetID()
+		if err != nil {
+			return 0, fmt.Errorf("unable to retrieve sheet ID: %w", err)
+		}
+		return sheetID, nil
+	}
+
+	sheetProperties, err := gs.listSheetProperties()
+	if err != nil {
+		return 0, err
+	}
+	for _, properties := range sheetProperties {
+		if properties.Title == sheetName {
+			return properties.SheetId, nil
+		}
+	}
+	return 0, fmt.Errorf("sheet with name %s not found", sheetName)
+}
+
+// FitColumnsToContent sets the width of the columns of a range of the current set sheet in the GoogleSheetsClient
+// struct from the length of their values, measured on the client side, for widths that do not depend on the fonts
+// the API measures with. The values are read as they are shown in the sheet, so formula cells are measured by their
+// displayed value, and their length is counted in characters (runes), not bytes. A cell with several lines is
+// measured by its longest line. The widths are set in a single batch update.
+//
+// Parameters:
+//   - range_: The range to measure (e.g., "A1:F100" or "A:F"). The columns of the range without values get the
+//     minimum width; for ranges without an end column (e.g., "1:10") the columns up to the widest row are sized.
+//   - pixelsPerChar: The width in pixels of a character (e.g., 7 for a 10pt font).
+//   - minWidth: The minimum width of a column in pixels.
+//   - maxWidth: The maximum width of a column in pixels.
+//
+// Returns:
+//   - An error if the range or the widths are not valid, or there was a problem reading the range or setting the
+//     widths, nil otherwise.
+func (gs *GoogleSheetsClient) FitColumnsToContent(range_ string, pixelsPerChar float64, minWidth, maxWidth int64) error {
+	parsedRange, err := parseA1Range(range_)
+	if err != nil {
+		return err
+	}
+	if pixelsPerChar <= 0 || math.IsNaN(pixelsPerChar) || math.IsInf(pixelsPerChar, 0) {
+		return fmt.Errorf("invalid width per character %v: it must be positive", pixelsPerChar)
+	}
+	if minWidth < 0 || maxWidth <= 0 || minWidth > maxWidth {
+		return fmt.Errorf("invalid widths %d to %d: the minimum must not be negative nor above the maximum", minWidth, maxWidth)
+	}
+
+	err = validateClientFields(gs)
+	if err != nil {
+		return err
+	}
+
+	err = gs.requireScope(writeScopes...)
+	if err != nil {
+		return err
+	}
+
+	data, err := gs.ReadData(range_)
+	if err != nil {
+		return err
+	}
+
+	// The longest value of each column, in characters
+	var lengths []int
+	if parsedRange.EndColumn != -1 {
+		lengths = make([]int, parsedRange.EndColumn-parsedRange.StartColumn)
+	}
+	for _, row := range data {
+		for j, cell := range row {
+			for j >= len(lengths) {
+				lengths = append(lengths, 0)
+			}
+			for _, line := range strings.Split(fmt.Sprintf("%v", cell), "\n") {
+				lengths[j] = max(lengths[j], utf8.RuneCountInString(line))
+			}
+		}
+	}
+	if len(lengths) == 0 {
+		return nil // No columns to size, avoid spending an API call
+	}
+
+	sheetID, err := gs.getSheetID()
+	if err != nil {
+		return fmt.Errorf("unable to retrieve sheet ID: %w", err)
+	}
+
+	requests := make([]*sheets.Request, len(lengths))
+	for j, length := range lengths {
+		width := min(max(int64(math.Ceil(float64(length)*pixelsPerChar)), minWidth), maxWidth)
+		column := parsedRange.StartColumn + int64(j)
+		requests[j] = &sheets.Request{
+			UpdateDimensionProperties: &sheets.UpdateDimensionPropertiesRequest{
+				Range:      &sheets.DimensionRange{SheetId: sheetID, Dimension: "COLUMNS", StartIndex: column, EndIndex: column + 1},
+				Properties: &sheets.DimensionProperties{PixelSize: width},
+				Fields:     "pixelSize",
+			},
+		}
+	}
+
+	batchUpdateRequest := &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: requests,
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	_, err = gs.service.Spreadsheets.BatchUpdate(gs.spreadsheetID, batchUpdateRequest).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("unable to set column widths: %w", gs.apiError(err))
+	}
+
+	gs.audit("FitColumnsToContent", gs.sheetName, range_, fmt.Sprintf("%d columns sized", len(lengths)))
+	return nil
+}
+
+// rgbColor converts red, green and blue components, from 0 to 1, to an opaque color of the API.
+func rgbColor(rgb [3]float64) *sheets.Color {
+	return &sheets.Color{
+		Red:             rgb[0],
+		Green:           rgb[1],
+		Blue:            rgb[2],
+		ForceSendFields: []string{"Red", "Green", "Blue"}, // 0 would be omitted otherwise
+	}
+}
+
+// headerBackground is the light gray background FormatHeaderRow sets on the header row.
+var headerBackground = [3]float64{0.9, 0.9, 0.9}
+
+// FormatHeaderRow applies the usual format of a report header to the first row of the current set sheet in the
+// GoogleSheetsClient struct: bold, centered text on a light gray background, with the row frozen so it stays
+// visible while scrolling. The format and the frozen row are set in a single batch update. The other formats of
+// the row (e.g., the number formats or the font) are kept.
+//
+// Returns:
+//   - An error if there was a problem formatting the row, nil otherwise.
+func (gs *GoogleSheetsClient) FormatHeaderRow() error {
+	err := validateClientFields(gs)
+	if err != nil {
+		return err
+	}
+
+	err = gs.requireScope(writeScopes...)
+	if err != nil {
+		return err
+	}
+
+	sheetID, err := gs.getSheetID()
+	if err != nil {
+		return fmt.Errorf("unable to retrieve sheet ID: %w", err)
+	}
+
+	batchUpdateRequest := &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{
+			{
+				RepeatCell: &sheets.RepeatCellRequest{
+					Range: a1Range{StartRow: 0, EndRow: 1, StartColumn: 0, EndColumn: -1}.gridRange(sheetID),
+					Cell: &sheets.CellData{
+						UserEnteredFormat: &sheets.CellFormat{
+							TextFormat:          &sheets.TextFormat{Bold: true},
+							HorizontalAlignment: "CENTER",
+							BackgroundColor:     rgbColor(headerBackground),
+						},
+					},
+					Fields: "userEnteredFormat.textFormat.bold,userEnteredFormat.horizontalAlignment,userEnteredFormat.backgroundColor",
+				},
+			},
+			{
+				UpdateSheetProperties: &sheets.UpdateSheetPropertiesRequest{
+					Properties: &sheets.SheetProperties{
+						SheetId:        sheetID,
+						GridProperties: &sheets.GridProperties{FrozenRowCount: 1},
+					},
+					Fields: "gridProperties.frozenRowCount",
+				},
+			},
+		},
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	_, err = gs.service.Spreadsheets.BatchUpdate(gs.spreadsheetID, batchUpdateRequest).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("unable to format the header row: %w", gs.apiError(err))
+	}
+
+	gs.audit("FormatHeaderRow", gs.sheetName, "row 1", "bold, centered, gray background, frozen")
+	return nil
+}
+
+// ClearRowFormatting resets the format of every cell of a row of the current set sheet in the GoogleSheetsClient
+// struct to the default format, e.g., to drop the formatting carried by imported data. The values, notes and data
+// validation rules of the row and the other rows are kept.
+//
+// Parameters:
+//   - row: The 1-based number of the row.
+//
+// Returns:
+//   - An error if the row number is not valid or there was a problem clearing the format, nil otherwise.
+func (gs *GoogleSheetsClient) ClearRowFormatting(row int64) error {
+	if row < 1 {
+		return fmt.Errorf("invalid row number %d, rows start at 1", row)
+	}
+
+	// Leaving the format out of the cell with the whole format in the mask clears it
+	rowRange := fmt.Sprintf("%d:%d", row, row)
+	err := gs.repeatCellFormat(rowRange, nil, "userEnteredFormat")
+	if err != nil {
+		return err
+	}
+
+	gs.audit("ClearRowFormatting", gs.sheetName, fmt.Sprintf("row %d", row), "format cleared")
+	return nil
+}
+
+// repeatCellFormat applies the same format to every cell of a range of the current set sheet in the
+// GoogleSheetsClient struct with a RepeatCellRequest. Only the format fields in the mask are changed.
+//
+// Parameters:
+//   - rangeA1: The range of cells to format (e.g., "A1:B2").
+//   - format: The format to apply.
+//   - fields: The field mask of the format fields to change (e.g., "userEnteredFormat.wrapStrategy").
+//
+// Returns:
+//   - An error if the range is not valid or there was a problem formatting the cells, nil otherwise.
+func (gs *GoogleSheetsClient) repeatCellFormat(rangeA1 string, format *sheets.CellFormat, fields string) error {
+	parsedRange, err := parseA1Range(rangeA1)
+	if err != nil {
+		return err
+	}
+
+	err = gs.requireScope(writeScopes...)
+	if err != nil {
+		return err
+	}
+
+	sheetID, err := gs.getSheetID()
+	if err != nil {
+		return fmt.Errorf("unable to retrieve sheet ID: %w", err)
+	}
+
+	request := &sheets.Request{
+		RepeatCell: &sheets.RepeatCellRequest{
+			Range:  parsedRange.gridRange(sheetID),
+			Cell:   &sheets.CellData{UserEnteredFormat: format},
+			Fields: fields,
+		},
+	}
+
+	batchUpdateRequest := &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{request},
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	_, err = gs.service.Spreadsheets.BatchUpdate(gs.spreadsheetID, batchUpdateRequest).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("unable to format cells: %w", gs.apiError(err))
+	}
+
+	return nil
+}
+
+// extendedValueToInterface converts a cell value of the API to a Go value.
+//
+// Parameters:
+//   - value: The value to convert.
+//
+// Returns:
+//   - A float64, string or bool, the formula (starting with "=") for formula cells, or nil for empty cells.
+func extendedValueToInterface(value *sheets.ExtendedValue) interface{} {
+	switch {
+	case value == nil:
+		return nil
+	case value.NumberValue != nil:
+		return *value.NumberValue
+	case value.StringValue != nil:
+		return *value.StringValue
+	case value.BoolValue != nil:
+		return *value.BoolValue
+	case value.FormulaValue != nil:
+		return *value.FormulaValue
+	case value.ErrorValue != nil:
+		return value.ErrorValue.Message
+	}
+	return nil
+}
+
+// interfaceToExtendedValue converts a Go value to a cell value of the API, the inverse of extendedValueToInterface.
+// Strings starting with "=" are sent as formulas, and values of other types are sent as their string representation.
+//
+// Parameters:
+//   - value: The value to convert.
+//
+// Returns:
+//   - The cell value, or nil for nil values (which clears the cell).
+func interfaceToExtendedValue(value interface{}) *sheets.ExtendedValue {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case float64:
+		return &sheets.ExtendedValue{NumberValue: &v}
+	case float32:
+		number := float64(v)
+		return &sheets.ExtendedValue{NumberValue: &number}
+	case int:
+		number := float64(v)
+		return &sheets.ExtendedValue{NumberValue: &number}
+	case int64:
+		number := float64(v)
+		return &sheets.ExtendedValue{NumberValue: &number}
+	case bool:
+		return &sheets.ExtendedValue{BoolValue: &v}
+	case string:
+		if strings.HasPrefix(v, "=") {
+			return &sheets.ExtendedValue{FormulaValue: &v}
+		}
+		return &sheets.ExtendedValue{StringValue: &v}
+	}
+
+	text := fmt.Sprintf("%v", value)
+	return &sheets.ExtendedValue{StringValue: &text}
+}
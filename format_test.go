@@ -0,0 +1,861 @@
+package gosheets
+
+import (
+	"reflect"
+	"testing"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+func TestReadRichData(t *testing.T) {
+	fake.reset()
+	t.Cleanup(fake.reset)
+
+	red := &sheets.Color{Red: 1}
+	currency := &sheets.NumberFormat{Type: "CURRENCY", Pattern: "$#,##0.00"}
+	fake.sheet("Sheet1").values = [][]interface{}{{"Item", "Price"}, {"Coffee", 2.5}, {"Total", "=SUM(B2)"}}
+	fake.sheet("Sheet1").setCellData(1, 1, func(cell *sheets.CellData) {
+		cell.UserEnteredFormat = &sheets.CellFormat{NumberFormat: currency, BackgroundColor: red}
+	})
+
+	// Test cases
+	tests := []struct {
+		name      string
+		sheetName string
+		rangeA1   string
+		want      [][]RichCell
+		wantErr   bool
+	}{
+		{
+			name:      "Values and formats",
+			sheetName: "Sheet1",
+			rangeA1:   "A2:B3",
+			want: [][]RichCell{
+				{{Value: "Coffee"}, {Value: 2.5, NumberFormat: currency, BackgroundColor: red}},
+				{{Value: "Total"}, {Value: "=SUM(B2)"}},
+			},
+			wantErr: false,
+		},
+		{
+			name:      "Empty range",
+			sheetName: "Sheet1",
+			rangeA1:   "D10:E12",
+			want:      [][]RichCell{},
+			wantErr:   false,
+		},
+		{
+			name:      "Non-existent sheet",
+			sheetName: "Sheet2",
+			rangeA1:   "A1:B2",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetClient()
+			client.SetSheetName(tt.sheetName)
+
+			got, err := client.ReadRichData(tt.rangeA1)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadRichData() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadRichData() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtendedValueToInterface(t *testing.T) {
+	number, text, boolean, formula := 42.5, "text", true, "=A1+1"
+
+	// Test cases
+	tests := []struct {
+		name  string
+		value *sheets.ExtendedValue
+		want  interface{}
+	}{
+		{name: "Number", value: &sheets.ExtendedValue{NumberValue: &number}, want: 42.5},
+		{name: "String", value: &sheets.ExtendedValue{StringValue: &text}, want: "text"},
+		{name: "Bool", value: &sheets.ExtendedValue{BoolValue: &boolean}, want: true},
+		{name: "Formula", value: &sheets.ExtendedValue{FormulaValue: &formula}, want: "=A1+1"},
+		{name: "Error", value: &sheets.ExtendedValue{ErrorValue: &sheets.ErrorValue{Type: "DIVIDE_BY_ZERO", Message: "Division by zero."}}, want: "Division by zero."},
+		{name: "Empty", value: nil, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := extendedValueToInterface(tt.value); got != tt.want {
+				t.Errorf("extendedValueToInterface() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteRichData(t *testing.T) {
+	fake.reset()
+	t.Cleanup(fake.reset)
+
+	bold := &sheets.TextFormat{Bold: true}
+	cells := [][]RichCell{
+		{{Value: "Item", TextFormat: bold}, {Value: "Price", TextFormat: bold}},
+		{{Value: "Coffee"}, {Value: 2.5, NumberFormat: &sheets.NumberFormat{Type: "CURRENCY"}}},
+	}
+
+	// Test cases
+	tests := []struct {
+		name      string
+		sheetName string
+		startCell string
+		cells     [][]RichCell
+		wantErr   bool
+	}{
+		{
+			name:      "Valid cells",
+			sheetName: "Sheet1",
+			startCell: "C3",
+			cells:     cells,
+			wantErr:   false,
+		},
+		{
+			name:      "Invalid start cell",
+			sheetName: "Sheet1",
+			startCell: "3",
+			cells:     cells,
+			wantErr:   true,
+		},
+		{
+			name:      "Empty sheet name",
+			sheetName: "",
+			startCell: "C3",
+			cells:     cells,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetClient()
+			client.SetSheetName(tt.sheetName)
+
+			err := client.WriteRichData(tt.startCell, tt.cells)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("WriteRichData() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			// Reading the block back must return what was written.
+			got, err := client.ReadRichData("C3:D4")
+			if err != nil {
+				t.Fatalf("ReadRichData() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.cells) {
+				t.Errorf("ReadRichData() = %+v, want %+v", got, tt.cells)
+			}
+		})
+	}
+}
+
+func TestInterfaceToExtendedValue(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name  string
+		value interface{}
+		want  interface{}
+	}{
+		{name: "Float", value: 42.5, want: 42.5},
+		{name: "Int", value: 7, want: float64(7)},
+		{name: "String", value: "text", want: "text"},
+		{name: "Bool", value: false, want: false},
+		{name: "Formula", value: "=A1+1", want: "=A1+1"},
+		{name: "Nil", value: nil, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := extendedValueToInterface(interfaceToExtendedValue(tt.value)); got != tt.want {
+				t.Errorf("interfaceToExtendedValue() round trip = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetTextWrapAndAlignment(t *testing.T) {
+	fake.reset()
+	t.Cleanup(fake.reset)
+
+	// Test cases
+	tests := []struct {
+		name           string
+		operation      func() error
+		wantWrap       string
+		wantHorizontal string
+		wantVertical   string
+		wantErr        bool
+	}{
+		{
+			name:      "Wrap",
+			operation: func() error { return client.SetTextWrap("A1:B2", "WRAP") },
+			wantWrap:  "WRAP",
+		},
+		{
+			name:           "Horizontal alignment keeps the wrap strategy",
+			operation:      func() error { return client.SetAlignment("A1:B2", "CENTER", "") },
+			wantWrap:       "WRAP",
+			wantHorizontal: "CENTER",
+		},
+		{
+			name:           "Both alignments",
+			operation:      func() error { return client.SetAlignment("A1:B2", "RIGHT", "MIDDLE") },
+			wantWrap:       "WRAP",
+			wantHorizontal: "RIGHT",
+			wantVertical:   "MIDDLE",
+		},
+		{
+			name:      "Invalid wrap strategy",
+			operation: func() error { return client.SetTextWrap("A1:B2", "wrap") },
+			wantErr:   true,
+		},
+		{
+			name:      "Invalid vertical alignment",
+			operation: func() error { return client.SetAlignment("A1:B2", "LEFT", "CENTER") },
+			wantErr:   true,
+		},
+		{
+			name:      "No alignment",
+			operation: func() error { return client.SetAlignment("A1:B2", "", "") },
+			wantErr:   true,
+		},
+		{
+			name:      "Invalid range",
+			operation: func() error { return client.SetTextWrap("A0", "CLIP") },
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetClient()
+
+			err := tt.operation()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("operation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			gridData, err := client.getGridData("A1:B2", "rowData(values(userEnteredFormat))")
+			if err != nil {
+				t.Fatalf("getGridData() error = %v", err)
+			}
+			for _, rowData := range gridData.RowData {
+				for _, cell := range rowData.Values {
+					format := cell.UserEnteredFormat
+					if format == nil || format.WrapStrategy != tt.wantWrap ||
+						format.HorizontalAlignment != tt.wantHorizontal || format.VerticalAlignment != tt.wantVertical {
+						t.Errorf("UserEnteredFormat = %+v, want wrap %q and alignment %q, %q", format, tt.wantWrap, tt.wantHorizontal, tt.wantVertical)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestClearRowFormatting(t *testing.T) {
+	t.Cleanup(fake.reset)
+
+	// Test cases
+	tests := []struct {
+		name     string
+		row      int64
+		wantWrap []string
+		wantErr  bool
+	}{
+		{
+			name:     "Row in the middle keeps the rows around it",
+			row:      2,
+			wantWrap: []string{"WRAP", "", "WRAP"},
+		},
+		{
+			name:     "Header row",
+			row:      1,
+			wantWrap: []string{"", "WRAP", "WRAP"},
+		},
+		{
+			name:    "Row zero",
+			row:     0,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.reset()
+			resetClient()
+			err := client.SetTextWrap("A1:B3", "WRAP")
+			if err != nil {
+				t.Fatalf("SetTextWrap() error = %v", err)
+			}
+
+			err = client.ClearRowFormatting(tt.row)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ClearRowFormatting() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			gridData, err := client.getGridData("A1:B3", "rowData(values(userEnteredFormat))")
+			if err != nil {
+				t.Fatalf("getGridData() error = %v", err)
+			}
+			for i, rowData := range gridData.RowData {
+				for _, cell := range rowData.Values {
+					wrap := ""
+					if cell.UserEnteredFormat != nil {
+						wrap = cell.UserEnteredFormat.WrapStrategy
+					}
+					if wrap != tt.wantWrap[i] {
+						t.Errorf("row %d wrap strategy = %q, want %q", i+1, wrap, tt.wantWrap[i])
+					}
+				}
+			}
+
+			values, err := client.ReadData("A1:B2")
+			if err != nil {
+				t.Fatalf("ReadData() error = %v", err)
+			}
+			if want := [][]interface{}{{"Header1", "Header2"}, {"Value1", "Value2"}}; !reflect.DeepEqual(values, want) {
+				t.Errorf("ReadData() = %v, want %v", values, want)
+			}
+		})
+	}
+}
+
+func TestSetBorders(t *testing.T) {
+	fake.reset()
+	t.Cleanup(fake.reset)
+
+	solid := &Border{Style: "SOLID"}
+	dashed := &Border{Style: "DASHED", Color: &sheets.Color{Red: 1}}
+
+	// Test cases
+	tests := []struct {
+		name    string
+		rangeA1 string
+		border  BorderSpec
+		cell    string
+		want    *sheets.Borders
+		wantErr bool
+	}{
+		{
+			name:    "Outer borders on the top left cell",
+			rangeA1: "A1:B2",
+			border:  BorderSpec{Top: solid, Bottom: solid, Left: solid, Right: solid, InnerHorizontal: dashed, InnerVertical: dashed},
+			cell:    "A1",
+			want: &sheets.Borders{
+				Top:    &sheets.Border{Style: "SOLID"},
+				Bottom: &sheets.Border{Style: "DASHED", Color: &sheets.Color{Red: 1}},
+				Left:   &sheets.Border{Style: "SOLID"},
+				Right:  &sheets.Border{Style: "DASHED", Color: &sheets.Color{Red: 1}},
+			},
+		},
+		{
+			name:    "Nil sides are left unchanged",
+			rangeA1: "A1:A1",
+			border:  BorderSpec{Top: &Border{Style: "NONE"}},
+			cell:    "A1",
+			want: &sheets.Borders{
+				Top:    &sheets.Border{Style: "NONE"},
+				Bottom: &sheets.Border{Style: "DASHED", Color: &sheets.Color{Red: 1}},
+				Left:   &sheets.Border{Style: "SOLID"},
+				Right:  &sheets.Border{Style: "DASHED", Color: &sheets.Color{Red: 1}},
+			},
+		},
+		{
+			name:    "Invalid style",
+			rangeA1: "A1:B2",
+			border:  BorderSpec{Top: &Border{Style: "solid"}},
+			wantErr: true,
+		},
+		{
+			name:    "No sides",
+			rangeA1: "A1:B2",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid range",
+			rangeA1: "B2:A1",
+			border:  BorderSpec{Top: solid},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetClient()
+
+			err := client.SetBorders(tt.rangeA1, tt.border)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SetBorders() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			gridData, err := client.getGridData(tt.cell, "rowData(values(userEnteredFormat))")
+			if err != nil {
+				t.Fatalf("getGridData() error = %v", err)
+			}
+			got := gridData.RowData[0].Values[0].UserEnteredFormat.Borders
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Borders = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddBanding(t *testing.T) {
+	fake.reset()
+	t.Cleanup(fake.reset)
+
+	header, white, grey := [3]float64{0.2, 0.4, 0.8}, [3]float64{1, 1, 1}, [3]float64{0.95, 0.95, 0.95}
+
+	// Test cases
+	tests := []struct {
+		name        string
+		rangeA1     string
+		headerColor [3]float64
+		band1Color  [3]float64
+		band2Color  [3]float64
+		wantErr     bool
+	}{
+		{
+			name:        "Valid banding",
+			rangeA1:     "A1:C10",
+			headerColor: header,
+			band1Color:  white,
+			band2Color:  grey,
+			wantErr:     false,
+		},
+		{
+			name:        "Overlapping banding",
+			rangeA1:     "B5:D20",
+			headerColor: header,
+			band1Color:  white,
+			band2Color:  grey,
+			wantErr:     true,
+		},
+		{
+			name:        "Black colors",
+			rangeA1:     "E1:F10",
+			headerColor: [3]float64{},
+			band1Color:  [3]float64{},
+			band2Color:  [3]float64{},
+			wantErr:     false,
+		},
+		{
+			name:        "Color out of range",
+			rangeA1:     "H1:I10",
+			headerColor: [3]float64{0, 0, 255},
+			band1Color:  white,
+			band2Color:  grey,
+			wantErr:     true,
+		},
+		{
+			name:        "Invalid range",
+			rangeA1:     "C10:A1",
+			headerColor: header,
+			band1Color:  white,
+			band2Color:  grey,
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetClient()
+			before := len(fake.sheet("Sheet1").bandings)
+
+			err := client.AddBanding(tt.rangeA1, tt.headerColor, tt.band1Color, tt.band2Color)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AddBanding() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			bandings := fake.sheet("Sheet1").bandings
+			if len(bandings) != before+1 {
+				t.Fatalf("AddBanding() added %d banded ranges, want 1", len(bandings)-before)
+			}
+			properties := bandings[len(bandings)-1].RowProperties
+			got := [3]float64{properties.HeaderColor.Red, properties.HeaderColor.Green, properties.HeaderColor.Blue}
+			if got != tt.headerColor {
+				t.Errorf("AddBanding() header color = %v, want %v", got, tt.headerColor)
+			}
+		})
+	}
+}
+
+func TestAppendBanded(t *testing.T) {
+	t.Cleanup(fake.reset)
+
+	white, grey := [3]float64{1, 1, 1}, [3]float64{0.95, 0.95, 0.95}
+	rows := [][]interface{}{{"Ink", "1"}, {"Pad", "3"}}
+
+	// Test cases
+	tests := []struct {
+		name        string
+		bandings    []string
+		wantRanges  []string
+		wantUpdates int
+	}{
+		{
+			name:        "Banding extended to the new rows",
+			bandings:    []string{"A1:B3", "D1:E10"},
+			wantRanges:  []string{"A1:B5", "D1:E10"},
+			wantUpdates: 1,
+		},
+		{
+			name:       "Banding over whole columns",
+			bandings:   []string{"A:B"},
+			wantRanges: []string{"A:B"},
+		},
+		{
+			name: "No banding",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.reset()
+			fake.seedSheet("Stock", [][]interface{}{{"Item", "Qty"}, {"Pen", "2"}, {"Cap", "5"}})
+			resetClient()
+			client.SetSheetName("Stock")
+			for _, banding := range tt.bandings {
+				if err := client.AddBanding(banding, white, white, grey); err != nil {
+					t.Fatalf("AddBanding() error = %v", err)
+				}
+			}
+			fake.requests = nil
+
+			err := client.AppendBanded(rows, "A1")
+			if err != nil {
+				t.Fatalf("AppendBanded() error = %v", err)
+			}
+
+			sheet := fake.sheet("Stock")
+			if len(sheet.values) != 5 || sheet.values[4][0] != "Pad" {
+				t.Errorf("sheet values = %v, want the rows appended", sheet.values)
+			}
+			var got []string
+			for _, banding := range sheet.bandings {
+				got = append(got, gridRangeA1(banding.Range, nil))
+			}
+			if !reflect.DeepEqual(got, tt.wantRanges) {
+				t.Errorf("banded ranges = %v, want %v", got, tt.wantRanges)
+			}
+			if len(fake.requests) != tt.wantUpdates {
+				t.Errorf("AppendBanded() sent %d batch update requests, want %d", len(fake.requests), tt.wantUpdates)
+			}
+		})
+	}
+}
+
+func TestGetEffectiveFormat(t *testing.T) {
+	fake.reset()
+	t.Cleanup(fake.reset)
+
+	red := &sheets.Color{Red: 1}
+	conditional := &sheets.CellFormat{BackgroundColor: red, TextFormat: &sheets.TextFormat{Bold: true}}
+	fake.seedSheet("Formats", [][]interface{}{{"Plain", "Centered", "Flagged"}})
+	fake.sheet("Formats").cells = map[[2]int64]*sheets.CellData{
+		{0, 1}: {UserEnteredFormat: &sheets.CellFormat{HorizontalAlignment: "CENTER"}},
+		{0, 2}: {EffectiveFormat: conditional}, // As if set by a conditional formatting rule
+	}
+
+	// Test cases
+	tests := []struct {
+		name    string
+		cell    string
+		check   func(t *testing.T, info *CellFormatInfo)
+		wantErr bool
+	}{
+		{
+			name: "Default format",
+			cell: "A1",
+			check: func(t *testing.T, info *CellFormatInfo) {
+				if info.VerticalAlignment != "BOTTOM" || info.TextFormat == nil || info.TextFormat.FontFamily != "Arial" {
+					t.Errorf("GetEffectiveFormat() = %+v, want the default format", info)
+				}
+			},
+		},
+		{
+			name: "Entered format resolved with the defaults",
+			cell: "B1",
+			check: func(t *testing.T, info *CellFormatInfo) {
+				if info.HorizontalAlignment != "CENTER" || info.WrapStrategy != "OVERFLOW_CELL" {
+					t.Errorf("GetEffectiveFormat() = %+v, want centered with the default wrap strategy", info)
+				}
+			},
+		},
+		{
+			name: "Conditional format",
+			cell: "C1",
+			check: func(t *testing.T, info *CellFormatInfo) {
+				if !reflect.DeepEqual(info.BackgroundColor, red) || !info.TextFormat.Bold {
+					t.Errorf("GetEffectiveFormat() = %+v, want red and bold", info)
+				}
+			},
+		},
+		{
+			name: "Empty cell",
+			cell: "Z99",
+			check: func(t *testing.T, info *CellFormatInfo) {
+				if !reflect.DeepEqual(info, &CellFormatInfo{}) {
+					t.Errorf("GetEffectiveFormat() = %+v, want an empty format", info)
+				}
+			},
+		},
+		{
+			name:    "Range instead of a cell",
+			cell:    "A1:B2",
+			wantErr: true,
+		},
+		{
+			name:    "Column instead of a cell",
+			cell:    "A",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetClient()
+			client.SetSheetName("Formats")
+
+			got, err := client.GetEffectiveFormat(tt.cell)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetEffectiveFormat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.check != nil {
+				tt.check(t, got)
+			}
+		})
+	}
+}
+
+func TestFitColumnsToContent(t *testing.T) {
+	t.Cleanup(fake.reset)
+
+	// Test cases
+	tests := []struct {
+		name          string
+		range_        string
+		pixelsPerChar float64
+		min, max      int64
+		wantWidths    map[int64]int64
+		wantErr       bool
+	}{
+		{
+			name:   "Widths by rune count, clamped",
+			range_: "A:D",
+			min:    20,
+			max:    100,
+			// "日本語" is 3 runes, "Description" 11 and the long text is clamped; column D is empty
+			wantWidths: map[int64]int64{0: 30, 1: 100, 2: 40, 3: 20},
+		},
+		{
+			name:       "Longest line of a cell",
+			range_:     "C1:C3",
+			min:        10,
+			max:        500,
+			wantWidths: map[int64]int64{2: 40},
+		},
+		{
+			name:    "Minimum above maximum",
+			range_:  "A:B",
+			min:     200,
+			max:     100,
+			wantErr: true,
+		},
+		{
+			name:       "Range without end column",
+			range_:     "1:1",
+			min:        10,
+			max:        500,
+			wantWidths: map[int64]int64{0: 30, 1: 110, 2: 30},
+		},
+		{
+			name:          "Width per character not positive",
+			range_:        "A:B",
+			pixelsPerChar: -1,
+			min:           10,
+			max:           100,
+			wantErr:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.reset()
+			fake.seedSheet("Notes", [][]interface{}{
+				{"日本語", "Description", "Tag"},
+				{"ab", "A very long description of the item", "x\nlong"},
+			})
+			resetClient()
+			client.SetSheetName("Notes")
+
+			pixelsPerChar := tt.pixelsPerChar
+			if pixelsPerChar == 0 {
+				pixelsPerChar = 10
+			}
+			err := client.FitColumnsToContent(tt.range_, pixelsPerChar, tt.min, tt.max)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FitColumnsToContent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			widths := map[int64]int64{}
+			for _, req := range fake.requests {
+				if u := req.UpdateDimensionProperties; u != nil {
+					widths[u.Range.StartIndex] = u.Properties.PixelSize
+				}
+			}
+			if !reflect.DeepEqual(widths, tt.wantWidths) {
+				t.Errorf("widths = %v, want %v", widths, tt.wantWidths)
+			}
+		})
+	}
+}
+
+func TestFormatHeaderRow(t *testing.T) {
+	fake.reset()
+	t.Cleanup(fake.reset)
+	resetClient()
+
+	err := client.SetTextWrap("A1:B2", "WRAP")
+	if err != nil {
+		t.Fatalf("SetTextWrap() error = %v", err)
+	}
+	fake.requests = nil
+
+	err = client.FormatHeaderRow()
+	if err != nil {
+		t.Fatalf("FormatHeaderRow() error = %v", err)
+	}
+	if len(fake.requests) != 2 {
+		t.Errorf("FormatHeaderRow() sent %d requests, want 2 in a single batch update", len(fake.requests))
+	}
+
+	gridData, err := client.getGridData("A1:B2", "rowData(values(userEnteredFormat))")
+	if err != nil {
+		t.Fatalf("getGridData() error = %v", err)
+	}
+	for i, rowData := range gridData.RowData {
+		for j, cell := range rowData.Values {
+			format := cell.UserEnteredFormat
+			if format == nil || format.WrapStrategy != "WRAP" {
+				t.Errorf("cell (%d, %d) lost its wrap strategy: %+v", i, j, format)
+				continue
+			}
+			header := format.TextFormat != nil && format.TextFormat.Bold && format.HorizontalAlignment == "CENTER" &&
+				format.BackgroundColor != nil && format.BackgroundColor.Red == headerBackground[0]
+			if header != (i == 0) {
+				t.Errorf("cell (%d, %d) has header format %t, want %t", i, j, header, i == 0)
+			}
+		}
+	}
+
+	if frozen := fake.sheet("Sheet1").properties.GridProperties.FrozenRowCount; frozen != 1 {
+		t.Errorf("frozen rows = %d, want 1", frozen)
+	}
+}
+
+func TestWithInheritRowFormat(t *testing.T) {
+	t.Cleanup(fake.reset)
+
+	currency := &sheets.CellFormat{NumberFormat: &sheets.NumberFormat{Type: "CURRENCY", Pattern: "$#,##0.00"}}
+	checkbox := &sheets.DataValidationRule{Condition: &sheets.BooleanCondition{Type: "BOOLEAN"}}
+
+	// Test cases
+	tests := []struct {
+		name      string
+		seed      [][]interface{}
+		operation func() error
+		wantRows  []int64
+	}{
+		{
+			name: "Appended rows inherit the last data row",
+			seed: [][]interface{}{{"Item", "Paid"}, {"Pen", true}},
+			operation: func() error {
+				return client.AppendData([][]interface{}{{"Ink", false}, {"Pad", true}}, "A1", WithInheritRowFormat())
+			},
+			wantRows: []int64{2, 3},
+		},
+		{
+			name: "Without the option",
+			seed: [][]interface{}{{"Item", "Paid"}, {"Pen", true}},
+			operation: func() error {
+				return client.AppendData([][]interface{}{{"Ink", false}}, "A1")
+			},
+		},
+		{
+			name: "Header row is never copied",
+			seed: [][]interface{}{{"Item", "Paid"}},
+			operation: func() error {
+				return client.AppendData([][]interface{}{{"Ink", false}}, "A1", WithInheritRowFormat())
+			},
+		},
+		{
+			name: "New rows of an upsert",
+			seed: [][]interface{}{{"Item", "Paid"}, {"Pen", true}},
+			operation: func() error {
+				_, _, err := client.BulkUpsert("A", [][]interface{}{{"Pen", false}, {"Ink", true}}, 0, WithInheritRowFormat())
+				return err
+			},
+			wantRows: []int64{2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.reset()
+			fake.seedSheet("Orders", tt.seed)
+			sheet := fake.sheet("Orders")
+			if len(tt.seed) > 1 {
+				sheet.setCellData(1, 0, func(cell *sheets.CellData) { cell.UserEnteredFormat = currency })
+				sheet.setCellData(1, 1, func(cell *sheets.CellData) { cell.DataValidation = checkbox })
+			}
+			resetClient()
+			client.SetSheetName("Orders")
+
+			err := tt.operation()
+			if err != nil {
+				t.Fatalf("operation error = %v", err)
+			}
+
+			var gotRows []int64
+			for row := int64(len(tt.seed)); row < int64(len(sheet.values)); row++ {
+				format, validation := sheet.cells[[2]int64{row, 0}], sheet.cells[[2]int64{row, 1}]
+				if format == nil || validation == nil {
+					continue
+				}
+				if !reflect.DeepEqual(format.UserEnteredFormat, currency) || validation.DataValidation != checkbox {
+					t.Errorf("row %d format = %+v, validation = %+v", row+1, format.UserEnteredFormat, validation.DataValidation)
+				}
+				gotRows = append(gotRows, row)
+			}
+			if !reflect.DeepEqual(gotRows, tt.wantRows) {
+				t.Errorf("rows with the inherited format = %v, want %v", gotRows, tt.wantRows)
+			}
+		})
+	}
+}
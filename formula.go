@@ -0,0 +1,58 @@
+package gosheets
+
+// CellError is a cell whose formula evaluates to an error, as found by FindFormulaErrors.
+//
+//   - The Location field is the position of the cell.
+//   - The Formula field is the formula of the cell (e.g., "=A2/B2").
+//   - The Type field is the type of the error (e.g., "REF", "DIVIDE_BY_ZERO", "N_A" or "VALUE").
+//   - The Message field is the description of the error shown by Google Sheets.
+type CellError struct {
+	Location CellLocation
+	Formula  string
+	Type     string
+	Message  string
+}
+
+// FindFormulaErrors finds the cells of a range of the current set sheet in the GoogleSheetsClient struct whose
+// formulas evaluate to an error (e.g., #REF!, #DIV/0! or #N/A). Use it to check that a structural edit (e.g.,
+// deleting rows or columns) did not break the formulas of the sheet.
+//
+// Parameters:
+//   - readRange: The range of cells to check (e.g., "A1:F100"), or an empty string to check the whole sheet.
+//
+// Returns:
+//   - The cells with errors, in row order. An empty result means no formula of the range has an error.
+//   - An error if there was a problem reading the range, nil otherwise.
+func (gs *GoogleSheetsClient) FindFormulaErrors(readRange string) ([]CellError, error) {
+	if readRange != "" {
+		_, err := parseA1Range(readRange)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	gridData, err := gs.getGridData(readRange, "rowData(values(userEnteredValue,effectiveValue))")
+	if err != nil {
+		return nil, err
+	}
+
+	var cellErrors []CellError
+	for i, rowData := range gridData.RowData {
+		for j, cell := range rowData.Values {
+			if cell.EffectiveValue == nil || cell.EffectiveValue.ErrorValue == nil {
+				continue
+			}
+
+			cellError := CellError{
+				Location: CellLocationFromGrid(gs.sheetName, gridData.StartRow+int64(i), gridData.StartColumn+int64(j)),
+				Type:     cell.EffectiveValue.ErrorValue.Type,
+				Message:  cell.EffectiveValue.ErrorValue.Message,
+			}
+			if cell.UserEnteredValue != nil && cell.UserEnteredValue.FormulaValue != nil {
+				cellError.Formula = *cell.UserEnteredValue.FormulaValue
+			}
+			cellErrors = append(cellErrors, cellError)
+		}
+	}
+	return cellErrors, nil
+}
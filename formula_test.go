@@ -0,0 +1,61 @@
+package gosheets
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindFormulaErrors(t *testing.T) {
+	fake.reset()
+	t.Cleanup(fake.reset)
+	fake.seedSheet("Totals", [][]interface{}{
+		{"Item", "Amount", "Share"},
+		{"A", 10, "=B2/B4"},
+		{"B", 5, "=#REF!/B4"},
+		{"Total", "=SUM(B2:B3)", "=B4/0"},
+		{"Missing", "=NA()"},
+	})
+
+	// Test cases
+	tests := []struct {
+		name      string
+		readRange string
+		want      []CellError
+		wantErr   bool
+	}{
+		{
+			name:      "Whole sheet",
+			readRange: "",
+			want: []CellError{
+				{Location: CellLocation{Sheet: "Totals", Row: 3, Column: "C"}, Formula: "=#REF!/B4", Type: "REF", Message: "Reference does not exist."},
+				{Location: CellLocation{Sheet: "Totals", Row: 4, Column: "C"}, Formula: "=B4/0", Type: "DIVIDE_BY_ZERO", Message: "Function DIVIDE parameter 2 cannot be zero."},
+				{Location: CellLocation{Sheet: "Totals", Row: 5, Column: "B"}, Formula: "=NA()", Type: "N_A", Message: "Value not available."},
+			},
+		},
+		{
+			name:      "Range without errors",
+			readRange: "A1:B4",
+			want:      nil,
+		},
+		{
+			name:      "Invalid range",
+			readRange: "C1:A1",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetClient()
+			client.SetSheetName("Totals")
+
+			got, err := client.FindFormulaErrors(tt.readRange)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FindFormulaErrors() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindFormulaErrors() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
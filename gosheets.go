@@ -2,10 +2,18 @@ package gosheets
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
+	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/option"
 	"google.golang.org/api/sheets/v4"
 )
@@ -15,10 +23,44 @@ import (
 //   - The service field is used to interact with the Google Sheets API.
 //   - The spreadsheetID field is used to store the ID of the Google Sheets spreadsheet to interact with. You can find this ID in the URL of the spreadsheet. For example, the spreadsheet ID in the URL https://docs.google.com/spreadsheets/d/abc1234567/edit#gid=0 is "abc1234567".
 //   - The sheetName field is used to store the name of the sheet to interact with in the Google Sheets spreadsheet.
+//   - The tokenSource field is used to fetch the OAuth2 tokens that authenticate the requests.
+//   - The serviceAccountEmail field is used to store the client_email of the service account credentials.
+//   - The quotaProject field is used to store the project the API usage is billed to (see WithQuotaProject).
+//   - The scopes field is used to store the OAuth2 scopes the client was created with.
+//   - The defaultTimeout field is used to store the timeout applied to the API calls made without a deadline.
+//   - The retryAttempts and retryElapsed fields are used to store the retry budget of the operations (see
+//     WithRetryBudget), and the operation field the budget of the operation the client copy was made for.
+//   - The protectedHeaderRows field is used to store the number of header rows that row insertions and deletions
+//     must not touch, or -1 to use the frozen row count of the sheet.
+//   - The headerCache field is used to store the header rows read by the methods that resolve columns by header.
+//   - The firstSheet field is used to resolve the sheet name to the title of the first sheet (see UseFirstSheet).
+//   - The locale field is used to store the locale the formatted numbers are read with (see WithLocale), or an empty
+//     string to use the locale of the spreadsheet, cached in the localeCache field.
+//   - The valueInputOption field is used to store how the written values are interpreted by default (see
+//     WithValueInputOption), or an empty string for InputRaw.
 type GoogleSheetsClient struct {
-	service       *sheets.Service
-	spreadsheetID string
-	sheetName     string
+	service             *sheets.Service
+	spreadsheetID       string
+	sheetName           string
+	firstSheet          bool
+	tokenSource         oauth2.TokenSource
+	serviceAccountEmail string
+	quotaProject        string
+	scopes              []string
+	defaultTimeout      time.Duration
+	retryAttempts       int
+	retryElapsed        time.Duration
+	operation           *operationBudget
+	protectedHeaderRows int64
+	headerCache         *headerCache
+	sheetIDCache        *sheetIDCache
+	locale              string
+	localeCache         *localeCache
+	valueInputOption    string
+	tableRanges         map[[2]string]string
+	auditSheet          string
+	actor               string
+	warningHook         func(error)
 }
 
 // NewGoogleSheetsClient initializes a Google Sheets client with the provided
@@ -28,33 +70,141 @@ type GoogleSheetsClient struct {
 //
 // Parameters:
 //   - credentials: The path to the JSON credentials file for authentication.
+//   - opts: Optional settings for the client (e.g., WithScopes, WithImpersonationSubject, WithEndpoint).
 //
 // Returns:
 //   - A pointer to a GoogleSheetsClient instance representing the initialized client.
 //   - An error if there was a problem initializing the client, nil otherwise.
-func NewGoogleSheetsClient(credentials []byte) (*GoogleSheetsClient, error) {
-	config, err := google.JWTConfigFromJSON(credentials, sheets.SpreadsheetsScope)
+func NewGoogleSheetsClient(credentials []byte, opts ...ClientOption) (*GoogleSheetsClient, error) {
+	cfg := &clientConfig{scopes: []string{ScopeSheets}, headerCacheTTL: DefaultHeaderCacheTTL, maxRetries: DefaultMaxRetries}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	if cfg.valueInputOption != "" {
+		err := checkValueInputOption(cfg.valueInputOption)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	config, err := google.JWTConfigFromJSON(credentials, cfg.scopes...)
 	if err != nil {
-		return nil, fmt.Errorf("unable to create JWT config: %v", err)
+		return nil, fmt.Errorf("unable to create JWT config: %w: %v", ErrInvalidCredentials, err)
+	}
+	config.Subject = cfg.subject
+
+	// The oauth2 package takes the base HTTP client, used for both the tokens and the API requests, from the context
+	ctx := context.Background()
+	if cfg.httpClient != nil {
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, cfg.httpClient)
+	}
+
+	tokenSource := config.TokenSource(ctx)
+	client := oauth2.NewClient(ctx, tokenSource)
+	if cfg.quotaProject != "" {
+		// option.WithQuotaProject is ignored by the API client when it is given its own HTTP client
+		client.Transport = &quotaProjectTransport{base: client.Transport, project: cfg.quotaProject}
+	}
+	client.Transport = &retryTransport{base: client.Transport, maxRetries: max(cfg.maxRetries, 0)}
+
+	serviceOptions := []option.ClientOption{option.WithHTTPClient(client)}
+	if cfg.endpoint != "" {
+		serviceOptions = append(serviceOptions, option.WithEndpoint(cfg.endpoint))
 	}
 
-	client := config.Client(context.Background())
-	svc, err := sheets.NewService(context.Background(), option.WithHTTPClient(client))
+	svc, err := sheets.NewService(context.Background(), serviceOptions...)
 	if err != nil {
-		return nil, fmt.Errorf("unable to create Google Sheets service: %v", err)
+		return nil, fmt.Errorf("unable to create Google Sheets service: %w", err)
 	}
+	svc.UserAgent = cfg.userAgent
 
 	return &GoogleSheetsClient{
-		service: svc,
+		service:             svc,
+		tokenSource:         tokenSource,
+		serviceAccountEmail: config.Email,
+		quotaProject:        cfg.quotaProject,
+		scopes:              cfg.scopes,
+		defaultTimeout:      cfg.defaultTimeout,
+		retryAttempts:       cfg.retryAttempts,
+		retryElapsed:        cfg.retryElapsed,
+		protectedHeaderRows: -1,
+		headerCache:         &headerCache{ttl: cfg.headerCacheTTL},
+		sheetIDCache:        &sheetIDCache{},
+		locale:              cfg.locale,
+		localeCache:         &localeCache{},
+		valueInputOption:    cfg.valueInputOption,
+		auditSheet:          cfg.auditSheet,
+		actor:               cfg.actor,
+		warningHook:         cfg.warningHook,
 	}, nil
 }
 
+// quotaProjectTransport is an http.RoundTripper that bills the requests to a quota project (see WithQuotaProject).
+//
+//   - The base field is used to send the requests.
+//   - The project field is used to store the ID of the quota project.
+type quotaProjectTransport struct {
+	base    http.RoundTripper
+	project string
+}
+
+// RoundTrip sends a copy of the request with the X-Goog-User-Project header set to the quota project.
+func (t *quotaProjectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("X-Goog-User-Project", t.project)
+	return t.base.RoundTrip(req)
+}
+
+// Ping checks that the client is able to authenticate and, when a spreadsheet ID is set, that the spreadsheet
+// can be accessed. It fetches an OAuth2 token and retrieves the spreadsheet ID field of the spreadsheet metadata,
+// which is the cheapest authenticated request available.
+//
+// Parameters:
+//   - ctx: The context used for the spreadsheet metadata request.
+//
+// Returns:
+//   - An error wrapping ErrInvalidCredentials, ErrCredentialsRevoked, ErrAPINotEnabled, ErrNotShared or
+//     ErrSpreadsheetNotFound describing why the client cannot be used, nil otherwise.
+func (gs *GoogleSheetsClient) Ping(ctx context.Context) error {
+	_, err := gs.tokenSource.Token()
+	if err != nil {
+		return classifyTokenError(err)
+	}
+
+	if gs.spreadsheetID == "" {
+		return nil
+	}
+
+	ctx, cancel := gs.withTimeout(ctx)
+	defer cancel()
+
+	_, err = gs.service.Spreadsheets.Get(gs.spreadsheetID).Fields("spreadsheetId").Context(ctx).Do()
+	if err != nil {
+		return gs.apiError(err)
+	}
+
+	return nil
+}
+
+// ServiceAccountEmail returns the email of the service account used to authenticate the requests, taken from
+// the client_email field of the JSON credentials. Share the spreadsheet with this email to grant the client access.
+//
+// Returns:
+//   - The service account email, or an empty string if the credentials do not belong to a service account.
+func (gs *GoogleSheetsClient) ServiceAccountEmail() string {
+	return gs.serviceAccountEmail
+}
+
 // SetSpreadsheetID sets the spreadsheet ID in the GoogleSheetsClient struct.
 //
 // Parameters:
 //   - The ID of the Google Sheets spreadsheet to interact with.
 func (gs *GoogleSheetsClient) SetSpreadsheetID(spreadsheetID string) {
 	gs.spreadsheetID = spreadsheetID
+	if gs.firstSheet {
+		gs.sheetName = "" // The first sheet of the new spreadsheet may have another title
+	}
 }
 
 // SetSheetName sets the sheet name in the GoogleSheetsClient struct.
@@ -63,251 +213,2197 @@ func (gs *GoogleSheetsClient) SetSpreadsheetID(spreadsheetID string) {
 //   - The name of the sheet to interact with in the Google Sheets spreadsheet.
 func (gs *GoogleSheetsClient) SetSheetName(sheetName string) {
 	gs.sheetName = sheetName
+	gs.firstSheet = false
+}
+
+// UseFirstSheet makes the client work on the first sheet (tab) of the spreadsheet, whatever its name, for scripts
+// that only know the spreadsheet ID. The title of the first sheet is retrieved on the first call that needs it and
+// kept until SetSheetName or SetSpreadsheetID is called. If the sheet is renamed, the call that no longer finds it
+// fails and the title is retrieved again on the next call.
+func (gs *GoogleSheetsClient) UseFirstSheet() {
+	gs.sheetName = ""
+	gs.firstSheet = true
+}
+
+// ProtectHeaderRows sets the number of header rows at the top of the sheet that row insertions and deletions must
+// not touch. Deleting one of these rows, or inserting rows above or between them, returns ErrHeaderProtected
+// unless the Force option is passed. By default the protected rows are the frozen rows of the sheet.
+//
+// Parameters:
+//   - n: The number of protected header rows, 0 to disable the protection, or a negative number to go back to
+//     the default (the frozen row count of the sheet).
+func (gs *GoogleSheetsClient) ProtectHeaderRows(n int) {
+	gs.protectedHeaderRows = int64(max(n, -1))
+}
+
+// headerRowCount returns the number of protected header rows of a sheet (see ProtectHeaderRows).
+//
+// Parameters:
+//   - properties: The properties of the sheet, used to get its frozen row count.
+//
+// Returns:
+//   - The number of protected header rows.
+func (gs *GoogleSheetsClient) headerRowCount(properties *sheets.SheetProperties) int64 {
+	if gs.protectedHeaderRows >= 0 {
+		return gs.protectedHeaderRows
+	}
+
+	if properties.GridProperties == nil {
+		return 0
+	}
+
+	return properties.GridProperties.FrozenRowCount
 }
 
-// getSheetID retrieves the sheet ID of current sheet set in the GoogleSheetsClient struct.
+// getSheetID retrieves the sheet ID of current sheet set in the GoogleSheetsClient struct. Most writes start with it,
+// so its lookup is retried on rate limits and server errors like every API call (see WithMaxRetries), and only a
+// successful lookup is cached.
 //
 // Returns:
 //   - The ID of the sheet, or an error if the sheet was not found.
 func (gs *GoogleSheetsClient) getSheetID() (int64, error) {
-	err := validateClientFields(gs)
+	err := gs.resolveSheetName()
+	if err != nil {
+		return -1, err
+	}
+
+	key := [2]string{gs.spreadsheetID, gs.sheetName}
+	if sheetID, ok := gs.sheetIDCache.get(key); ok {
+		return sheetID, nil
+	}
+
+	properties, err := gs.getSheetProperties() // Caches the ID
 	if err != nil {
 		return -1, err
 	}
 
-	spreadsheet, err := gs.service.Spreadsheets.Get(gs.spreadsheetID).Do()
+	return properties.SheetId, nil
+}
+
+// getSheetProperties retrieves the properties (ID, index, grid size, etc.) of current sheet set in the GoogleSheetsClient struct.
+// The sheet ID is cached on the way, so a later getSheetID in the same operation does not retrieve it again.
+//
+// Returns:
+//   - The properties of the sheet, or an error if the sheet was not found.
+func (gs *GoogleSheetsClient) getSheetProperties() (*sheets.SheetProperties, error) {
+	err := validateClientFields(gs)
+	if err != nil {
+		return nil, err
+	}
+
+	sheetProperties, err := gs.listSheetProperties()
 	if err != nil {
-		return -1, fmt.Errorf("unable to retrieve spreadsheet: %v", err)
+		return nil, err
 	}
 
-	for _, sheet := range spreadsheet.Sheets {
-		if sheet.Properties.Title == gs.sheetName {
-			return sheet.Properties.SheetId, nil
+	for _, properties := range sheetProperties {
+		if properties.Title == gs.sheetName {
+			gs.sheetIDCache.set([2]string{gs.spreadsheetID, gs.sheetName}, properties.SheetId)
+			return properties, nil
 		}
 	}
 
-	return -1, fmt.Errorf("sheet with name %s not found", gs.sheetName)
+	gs.forgetFirstSheet() // The first sheet may have been renamed
+	return nil, fmt.Errorf("sheet with name %s not found", gs.sheetName)
 }
 
-// ReadData reads data from the current set sheet in the GoogleSheetsClient struct.
+// listSheetProperties retrieves the properties of every sheet of the spreadsheet set in the GoogleSheetsClient struct, in tab order.
+//
+// Returns:
+//   - The properties of the sheets, or an error if the spreadsheet could not be retrieved.
+func (gs *GoogleSheetsClient) listSheetProperties() ([]*sheets.SheetProperties, error) {
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	spreadsheet, err := gs.service.Spreadsheets.Get(gs.spreadsheetID).Fields("sheets.properties").Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve spreadsheet: %w", gs.apiError(err))
+	}
+
+	sheetProperties := make([]*sheets.SheetProperties, len(spreadsheet.Sheets))
+	for i, sheet := range spreadsheet.Sheets {
+		sheetProperties[i] = sheet.Properties
+	}
+
+	return sheetProperties, nil
+}
+
+// getGridData retrieves the grid data (per-cell values and formatting) of a range of the current set sheet in the
+// GoogleSheetsClient struct. Only the requested fields are returned by the API, which keeps the response small.
 //
 // Parameters:
-//   - readRange: The range of cells to read data from (e.g., "A1:B2").
+//   - readRange: The range of cells to read (e.g., "A1:B2"), or an empty string to read the whole sheet.
+//   - dataFields: The field mask of the GridData fields to return (e.g., "rowData(values(userEnteredValue))").
 //
 // Returns:
-//   - A 2D slice representing the read data, or an error if there was a problem.
-func (gs *GoogleSheetsClient) ReadData(readRange string) ([][]interface{}, error) {
+//   - The grid data of the range, empty if the range has no data, or an error if there was a problem.
+func (gs *GoogleSheetsClient) getGridData(readRange string, dataFields string) (*sheets.GridData, error) {
 	err := validateClientFields(gs)
 	if err != nil {
 		return nil, err
 	}
 
-	readRange = gs.sheetName + "!" + readRange
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	rangeA1 := quoteSheetName(gs.sheetName)
+	if readRange != "" {
+		rangeA1 += "!" + readRange
+	}
 
-	resp, err := gs.service.Spreadsheets.Values.Get(gs.spreadsheetID, readRange).Do()
+	fields := googleapi.Field("sheets(data(startRow,startColumn," + dataFields + "))")
+	spreadsheet, err := gs.service.Spreadsheets.Get(gs.spreadsheetID).
+		Ranges(rangeA1).
+		IncludeGridData(true).
+		Fields(fields).
+		Context(ctx).Do()
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve data from Google Sheets: %v", err)
+		return nil, fmt.Errorf("unable to retrieve grid data from Google Sheets: %w", gs.apiError(err))
+	}
+
+	if len(spreadsheet.Sheets) == 0 || len(spreadsheet.Sheets[0].Data) == 0 {
+		return &sheets.GridData{}, nil
 	}
-	return resp.Values, nil
+
+	return spreadsheet.Sheets[0].Data[0], nil
 }
 
-// AppendData appends data to the end of the current set sheet in the GoogleSheetsClient struct.
+// canEditProbeKey is the developer metadata key deleted by CanEdit to check the edit access. No metadata is ever
+// stored with it, so the deletion does not modify the spreadsheet.
+const canEditProbeKey = "gosheets.canEditProbe"
+
+// CanEdit reports whether the client has edit access to the spreadsheet set in the GoogleSheetsClient struct.
+// It sends a batch update that deletes the developer metadata with a key no one uses (canEditProbeKey), which
+// does not modify the spreadsheet but is still rejected by the API when the service account has only view access
+// (or no access at all) to the spreadsheet.
+//
+// Returns:
+//   - true if the spreadsheet can be edited, false otherwise.
+//   - An error if the check could not be performed for a reason other than missing permissions (e.g., an error
+//     wrapping ErrAPINotEnabled), nil otherwise.
+func (gs *GoogleSheetsClient) CanEdit() (bool, error) {
+	if gs.spreadsheetID == "" {
+		return false, fmt.Errorf("spreadsheet ID not set")
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	probe := &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{{
+			DeleteDeveloperMetadata: &sheets.DeleteDeveloperMetadataRequest{
+				DataFilter: &sheets.DataFilter{DeveloperMetadataLookup: developerMetadataLookup(canEditProbeKey)},
+			},
+		}},
+	}
+
+	_, err := gs.service.Spreadsheets.BatchUpdate(gs.spreadsheetID, probe).Context(ctx).Do()
+	if err != nil {
+		err = gs.apiError(err)
+		if errors.Is(err, ErrNotShared) {
+			return false, nil // The credentials have view access at most
+		}
+		return false, fmt.Errorf("unable to check spreadsheet permissions: %w", err)
+	}
+
+	return true, nil
+}
+
+// ReadData reads data from the current set sheet in the GoogleSheetsClient struct.
+//
 // Parameters:
-//   - data: A 2D slice representing the data to be added. Each inner slice
-//     represents a row of data, with each element representing a cell value.
-//   - range_: The cell used to search for existing data and find a "table"
-//     within that range where the data will be appended (e.g., "A1").
+//   - readRange: The range of cells to read data from (e.g., "A1:B2"), or an empty string to read the table range of the sheet (see SetTableRange).
+//   - opts: Optional settings for the read (e.g., Unformatted or PadRows). By default the values are read as they
+//     are shown in the sheet.
 //
 // Returns:
-//   - An error if there was a problem adding the data to the spreadsheet, nil otherwise.
-func (gs *GoogleSheetsClient) AppendData(data [][]interface{}, range_ string) error {
+//   - A 2D slice representing the read data. On success it is never nil: a range without values (e.g., empty cells
+//     or a range beyond the data) returns an empty slice. The trailing empty rows and the trailing empty cells of
+//     each row are omitted, so rows may have different lengths.
+//   - An error wrapping ErrInvalidRange if the range is not valid A1 notation or names a sheet that does not exist,
+//     or another error if there was a problem reading the data.
+func (gs *GoogleSheetsClient) ReadData(readRange string, opts ...ReadOption) ([][]interface{}, error) {
+	options := newReadOptions(opts)
+
 	err := validateClientFields(gs)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	valueRange := &sheets.ValueRange{
-		Values: data,
+	readRange, err = gs.rangeOrTable(gs.sheetName, readRange)
+	if err != nil {
+		return nil, err
 	}
 
-	range_ = gs.sheetName + "!" + range_
-	_, err = gs.service.Spreadsheets.Values.Append(gs.spreadsheetID, range_, valueRange).ValueInputOption("RAW").Do()
+	readRange = quoteSheetName(gs.sheetName) + "!" + readRange
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	call := gs.service.Spreadsheets.Values.Get(gs.spreadsheetID, readRange)
+	if options.valueRender != "" {
+		call = call.ValueRenderOption(options.valueRender)
+	}
+	resp, err := call.Context(ctx).Do()
 	if err != nil {
-		return fmt.Errorf("unable to add data to Google Sheets: %v", err)
+		return nil, fmt.Errorf("unable to retrieve data from Google Sheets: %w", gs.apiError(err))
 	}
-	return nil
+	if resp.Values == nil {
+		return [][]interface{}{}, nil // The API leaves out the values of ranges without any
+	}
+	return padRows(resp.Values, options.padWidth), nil
 }
 
-// InsertRowsAtPosition inserts a specified number of rows after a specified row in current set sheet in the GoogleSheetsClient struct. The position is 1-based, with 1 being the header row. Note: Is not possible to insert rows before the header row, giving a position of 0 will result in an error.
+// ReadDataPadded reads data from the current set sheet in the GoogleSheetsClient struct like ReadData, but pads
+// every row with nil values up to the width of the range. The API omits the trailing empty cells of each row,
+// so with this method every row has the same length and indexing a column inside the range is always safe.
+// For ranges without an end column (e.g., "2:10") the width is taken from the column count of the sheet.
 //
 // Parameters:
-//   - data: The data to insert into the spreadsheet.
-//   - position: The index of the row after which the new rows will be inserted.
+//   - readRange: The range of cells to read data from (e.g., "A1:B2").
+//   - opts: Optional settings for the read (see ReadData).
 //
 // Returns:
-//   - An error if there was a problem inserting the rows, nil otherwise.
-func (gs *GoogleSheetsClient) InsertRowsAfterPosition(data [][]interface{}, position int64) error {
-	sheetID, err := gs.getSheetID()
+//   - A 2D slice representing the read data with rows of equal length, or an error if there was a problem.
+func (gs *GoogleSheetsClient) ReadDataPadded(readRange string, opts ...ReadOption) ([][]interface{}, error) {
+	readRange, err := gs.rangeOrTable(gs.sheetName, readRange)
 	if err != nil {
-		return fmt.Errorf("unable to retrieve sheet ID: %v", err)
+		return nil, err
 	}
 
-	numRows := int64(len(data))
+	parsedRange, err := parseA1Range(readRange)
+	if err != nil {
+		return nil, err
+	}
 
-	insertRequest := &sheets.Request{
-		InsertDimension: &sheets.InsertDimensionRequest{
-			Range: &sheets.DimensionRange{
-				SheetId:    sheetID,
-				Dimension:  "ROWS",
-				StartIndex: position, // 0 is not a valid index in InsertDimensionRequest
-				EndIndex:   position + numRows,
-			},
-			InheritFromBefore: false,
-		},
+	properties, err := gs.getSheetProperties()
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve sheet properties: %w", err)
 	}
 
-	batchUpdateRequest := &sheets.BatchUpdateSpreadsheetRequest{
-		Requests: []*sheets.Request{insertRequest},
+	data, err := gs.ReadData(readRange, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	width := properties.GridProperties.ColumnCount
+	if parsedRange.EndColumn != -1 {
+		width = min(parsedRange.EndColumn, width)
 	}
 
-	_, err = gs.service.Spreadsheets.BatchUpdate(gs.spreadsheetID, batchUpdateRequest).Do()
+	return padRows(data, int(width-parsedRange.StartColumn)), nil
+}
+
+// Delays between the reads of ReadDataEventual, doubled after every empty read up to the maximum.
+const (
+	eventualReadInitialDelay = 100 * time.Millisecond
+	eventualReadMaxDelay     = 2 * time.Second
+)
+
+// ReadUsedRange reads the used block of the current set sheet in the GoogleSheetsClient struct: the rectangle from
+// A1 to the last row and the last column holding a value, without guessing a range. It takes a single read of the
+// whole sheet, since the API leaves out the empty rows and cells after the data.
+//
+// Parameters:
+//   - opts: Optional settings for the read (see ReadData).
+//
+// Returns:
+//   - A 2D slice with a row per row of the block, each padded with nil values to the width of the block. Empty, not
+//     nil, if the sheet has no values.
+//   - An error if there was a problem reading the sheet, nil otherwise.
+func (gs *GoogleSheetsClient) ReadUsedRange(opts ...ReadOption) ([][]interface{}, error) {
+	options := newReadOptions(opts)
+
+	err := validateClientFields(gs)
 	if err != nil {
-		return fmt.Errorf("unable to insert rows at position: %v", err)
+		return nil, err
 	}
 
-	err = gs.AppendData(data, "A"+fmt.Sprint(position))
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	call := gs.service.Spreadsheets.Values.Get(gs.spreadsheetID, quoteSheetName(gs.sheetName))
+	if options.valueRender != "" {
+		call = call.ValueRenderOption(options.valueRender)
+	}
+	resp, err := call.Context(ctx).Do()
 	if err != nil {
-		return fmt.Errorf("unable to append data to Google Sheets: %v", err)
+		return nil, fmt.Errorf("unable to retrieve data from Google Sheets: %w", gs.apiError(err))
 	}
 
-	return nil
+	if resp.Values == nil {
+		return [][]interface{}{}, nil // The API leaves out the values of sheets without any
+	}
+
+	width := options.padWidth
+	for _, row := range resp.Values {
+		width = max(width, len(row))
+	}
+	return padRows(resp.Values, width), nil
 }
 
-// InsertRowsAtBeginning inserts a specified number of rows at the beginning of current set sheet in the GoogleSheetsClient struct.The beginning of the sheet is considered to be the row after the header row. Note: This function assumes that the header row is the first row in the sheet.
+// ReadLastRows reads the last rows of the current set sheet in the GoogleSheetsClient struct, like the tail of a log:
+// the n rows ending at the last row with a value in the given columns. The header row is assumed to be the first
+// row of the sheet and is never returned. It takes a single read of the columns, since the API leaves out the empty
+// rows after the data, so the last used row and the rows ending there come from the same response.
 //
 // Parameters:
-//   - data: The data to insert into the spreadsheet.
+//   - columnRange: The columns to read, without row numbers (e.g., "A:D").
+//   - n: The number of rows to read.
+//   - opts: Optional settings for the read (see ReadData).
 //
 // Returns:
-//   - An error if there was a problem inserting the rows, nil otherwise.
-func (gs *GoogleSheetsClient) InsertRowsAtBeginning(data [][]interface{}) error {
-	err := gs.InsertRowsAfterPosition(data, 1)
+//   - A 2D slice with the last n data rows, oldest first, or all of them if the sheet has fewer. Empty, not nil, if
+//     the sheet has no data rows. Like ReadData, the trailing empty cells of each row are omitted.
+//   - An error if the column range is not valid, n is not positive or there was a problem reading the data, nil
+//     otherwise.
+func (gs *GoogleSheetsClient) ReadLastRows(columnRange string, n int, opts ...ReadOption) ([][]interface{}, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("invalid number of rows %d: it must be positive", n)
+	}
+
+	_, err := parseA1Range(columnRange)
+	if err != nil || strings.ContainsAny(columnRange, "0123456789") {
+		return nil, fmt.Errorf("invalid column range %q: use column letters only (e.g., \"A:D\")", columnRange)
+	}
+
+	data, err := gs.ReadData(columnRange, opts...)
 	if err != nil {
-		return fmt.Errorf("unable to insert rows at beginning: %v", err)
+		return nil, err
 	}
 
-	return nil
+	if len(data) <= 1 {
+		return [][]interface{}{}, nil // No data rows below the header row
+	}
+
+	rows := data[1:]
+	return rows[max(len(rows)-n, 0):], nil
 }
 
-// DeleteRow deletes the first occurrence of a row from a current set sheet in the GoogleSheetsClient struct. Note: This function assumes that the header row is the first row in the sheet.
+// ReadVisibleData reads data from the current set sheet in the GoogleSheetsClient struct like ReadData, but leaves
+// out the rows hidden by the user or by a filter, so the result matches what is shown in the sheet.
 //
 // Parameters:
-//   - data: The 2D slice representing the data to search through. Use the ReadData method to get this data.
-//   - column: The column in which to apply the filter.
-//   - value: The value to filter on.
+//   - readRange: The range of cells to read data from (e.g., "A1:B2").
 //
 // Returns:
-//   - An error if there was a problem deleting the row, nil otherwise.
-func (gs *GoogleSheetsClient) DeleteRow(data [][]interface{}, column, value string) error {
-	rowIndex := findRowNumber(data, column, value)
-	if rowIndex == -1 {
-		return fmt.Errorf("unable to find the value %v in column %v", value, column)
-	}
+//   - A 2D slice representing the visible rows of the read data, or an error if there was a problem.
+func (gs *GoogleSheetsClient) ReadVisibleData(readRange string) ([][]interface{}, error) {
+	data, _, err := gs.ReadVisibleDataWithRowNumbers(readRange)
+	return data, err
+}
 
-	sheetID, err := gs.getSheetID()
+// ReadVisibleDataWithRowNumbers reads the visible rows of a range like ReadVisibleData, and also returns the row
+// number of each of them in the sheet, so they can still be updated or deleted.
+//
+// Parameters:
+//   - readRange: The range of cells to read data from (e.g., "A1:B2").
+//
+// Returns:
+//   - A 2D slice representing the visible rows of the read data.
+//   - The 1-based row number in the sheet of each returned row.
+//   - An error if there was a problem reading the data or the row metadata, nil otherwise.
+func (gs *GoogleSheetsClient) ReadVisibleDataWithRowNumbers(readRange string) ([][]interface{}, []int64, error) {
+	readRange, err := gs.rangeOrTable(gs.sheetName, readRange)
 	if err != nil {
-		return fmt.Errorf("unable to retrieve sheet ID: %v", err)
+		return nil, nil, err
 	}
 
-	requests := []*sheets.Request{
-		{
-			DeleteDimension: &sheets.DeleteDimensionRequest{
-				Range: &sheets.DimensionRange{
-					SheetId:    sheetID, // Sheet ID (can be 0 for the first sheet)
-					Dimension:  "ROWS",
-					StartIndex: int64(rowIndex - 1), // Index of the row to delete (subtract 1 since rows are 0-based)
-					EndIndex:   int64(rowIndex),     // Index of the next row
-				},
-			},
-		},
+	parsedRange, err := parseA1Range(readRange)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	batchUpdate := &sheets.BatchUpdateSpreadsheetRequest{
-		Requests: requests,
+	data, err := gs.ReadData(readRange)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	_, err = gs.service.Spreadsheets.BatchUpdate(gs.spreadsheetID, batchUpdate).Do()
+	gridData, err := gs.getGridData(readRange, "rowMetadata(hiddenByUser,hiddenByFilter)")
 	if err != nil {
-		return fmt.Errorf("unable to delete row from Google Sheets: %v", err)
+		return nil, nil, err
 	}
-	return nil
+
+	var visible [][]interface{}
+	var rowNumbers []int64
+	for i, row := range data {
+		rowIndex := parsedRange.StartRow + int64(i)
+		offset := rowIndex - gridData.StartRow
+		if offset >= 0 && offset < int64(len(gridData.RowMetadata)) {
+			metadata := gridData.RowMetadata[offset]
+			if metadata.HiddenByUser || metadata.HiddenByFilter {
+				continue
+			}
+		}
+		visible = append(visible, row)
+		rowNumbers = append(rowNumbers, rowIndex+1)
+	}
+	return visible, rowNumbers, nil
 }
 
-// DataToString converts a 2D slice of interface{} values to a string.
+// ReadCells reads a list of scattered cells of the current set sheet in the GoogleSheetsClient struct in a single
+// request, e.g., the fields of a form-style sheet. It is cheaper than reading the range that bounds them when the
+// cells are far apart.
 //
 // Parameters:
-//   - data: The 2D slice of interface{} values to convert.
+//   - cells: The references of the cells to read (e.g., []string{"B2", "D5", "F10"}).
+//   - opts: Optional settings for the read (see ReadData). PadRows has no effect on single cells.
 //
 // Returns:
-//   - A string representation of the data.
-func DataToString(data [][]interface{}) string {
-	var result strings.Builder
+//   - A map from each reference, as given, to the value of the cell, nil for empty cells.
+//   - An error if a reference is not a single cell or there was a problem reading the cells, nil otherwise.
+func (gs *GoogleSheetsClient) ReadCells(cells []string, opts ...ReadOption) (map[string]interface{}, error) {
+	options := newReadOptions(opts)
 
-	for _, row := range data {
-		for _, cell := range row {
-			result.WriteString(fmt.Sprintf("%v\t", cell))
+	values := make(map[string]interface{}, len(cells))
+	if len(cells) == 0 {
+		return values, nil // Nothing to read, avoid spending an API call
+	}
+
+	err := validateClientFields(gs)
+	if err != nil {
+		return nil, err
+	}
+
+	ranges := make([]string, len(cells))
+	for i, cell := range cells {
+		if strings.Contains(cell, ":") {
+			return nil, fmt.Errorf("invalid cell reference %q: use a single cell (e.g., \"B2\")", cell)
 		}
-		result.WriteString("\n")
+		_, err := parseA1Range(cell)
+		if err != nil {
+			return nil, err
+		}
+		ranges[i] = quoteSheetName(gs.sheetName) + "!" + cell
 	}
 
-	return result.String()
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	call := gs.service.Spreadsheets.Values.BatchGet(gs.spreadsheetID).Ranges(ranges...)
+	if options.valueRender != "" {
+		call = call.ValueRenderOption(options.valueRender)
+	}
+	resp, err := call.Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve cells from Google Sheets: %w", gs.apiError(err))
+	}
+
+	for i, cell := range cells {
+		values[cell] = nil
+		if i < len(resp.ValueRanges) {
+			if rows := resp.ValueRanges[i].Values; len(rows) > 0 && len(rows[0]) > 0 {
+				values[cell] = rows[0][0]
+			}
+		}
+	}
+	return values, nil
 }
 
-// findRowNumber finds the row number containing a specific value in a given column.
+// ReadDataEventual reads data from the current set sheet in the GoogleSheetsClient struct like ReadData, but when
+// expectNonEmpty is true it reads the range again with exponential backoff while it is empty, until data appears or
+// maxWait passes. Use it to verify a write right after making it, when an immediate read may not see it yet.
+// It is best-effort: it cannot tell a write that is not visible yet from a write that never happened, so an empty
+// result after maxWait is returned without an error.
 //
 // Parameters:
-//   - data: The 2D slice representing the data to search through. Use the ReadData method to get this data.
-//   - column: The column letter in which to search for the value.
-//   - value: The value to search for.
+//   - readRange: The range of cells to read data from (e.g., "A1:B2").
+//   - expectNonEmpty: Whether to retry the read while the range is empty. If false, the range is read once.
+//   - maxWait: The maximum time to keep retrying the read.
 //
 // Returns:
-//   - The row number (1-based index) containing the value, or -1 if not found.
-func findRowNumber(data [][]interface{}, column, value string) int {
-	for i, row := range data {
-		if len(row) < columnIndex(column)+1 {
-			continue // Avoid index out of range error if the row does not have enough columns
+//   - A 2D slice representing the read data, empty if no data appeared before maxWait.
+//   - An error if there was a problem reading the data. Errors are returned at once, without retrying.
+func (gs *GoogleSheetsClient) ReadDataEventual(readRange string, expectNonEmpty bool, maxWait time.Duration) ([][]interface{}, error) {
+	deadline := time.Now().Add(maxWait)
+	delay := eventualReadInitialDelay
+
+	for {
+		data, err := gs.ReadData(readRange)
+		if err != nil || !expectNonEmpty || len(data) > 0 {
+			return data, err
 		}
-		if fmt.Sprintf("%v", row[columnIndex(column)]) == value {
-			return i + 1 // 1-based row index
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return data, nil
 		}
+
+		time.Sleep(min(delay, remaining))
+		delay = min(delay*2, eventualReadMaxDelay)
 	}
-	return -1
 }
 
-// columnIndex converts a column letter (e.g., "A", "Z", "AA", "BX" ... "ZZZ") to its index (0-based).
+// ReadDataChunked reads data from the current set sheet in the GoogleSheetsClient struct like ReadData, but in
+// pages of chunkSize rows, one request per page, so a huge range is read in several smaller requests. When a page
+// fails, e.g., because the deadline of ctx passed, the rows read before it are returned along with the error, so
+// the caller can make progress with a partial read and resume from the next row.
 //
 // Parameters:
-//   - column: The column letter to convert.
+//   - ctx: The context of the whole read. Its deadline bounds all the pages, and each page is also bounded by the
+//     default timeout of the client (see WithDefaultTimeout).
+//   - readRange: The range of cells to read data from (e.g., "A1:F" or "A:F"), or an empty string to use the table
+//     range of the sheet (see SetTableRange). The ranges without an end row are read up to the last row of the sheet.
+//   - chunkSize: The number of rows read per request, or 0 to use DefaultChunkSize.
+//   - opts: Optional settings for the read (see ReadData).
 //
 // Returns:
-//   - The index of the column.
-func columnIndex(column string) int {
-	column = strings.ToUpper(column)
-	index := 0
-	for _, c := range column {
-		index = index*26 + int(c-'A') + 1
+//   - A 2D slice representing the read data, like ReadData. On error, the rows of the pages read before the failed
+//     one, which are all the rows of the range above the row reported in the error.
+//   - An error if the range is not valid or there was a problem reading a page, for which errors.Is(err,
+//     context.DeadlineExceeded) reports true when the deadline passed, nil otherwise.
+func (gs *GoogleSheetsClient) ReadDataChunked(ctx context.Context, readRange string, chunkSize int, opts ...ReadOption) ([][]interface{}, error) {
+	gs = gs.startOperation() // The calls below share the retry budget of the method
+
+	options := newReadOptions(opts)
+
+	if chunkSize < 0 {
+		return nil, fmt.Errorf("invalid chunk size %d: it must not be negative", chunkSize)
+	}
+	if chunkSize == 0 {
+		chunkSize = DefaultChunkSize
+	}
+
+	err := validateClientFields(gs)
+	if err != nil {
+		return nil, err
+	}
+
+	readRange, err = gs.rangeOrTable(gs.sheetName, readRange)
+	if err != nil {
+		return nil, err
+	}
+
+	parsedRange, err := parseA1Range(readRange)
+	if err != nil {
+		return nil, err
+	}
+	if parsedRange.StartRow == -1 {
+		parsedRange.StartRow = 0
+	}
+	if parsedRange.EndRow == -1 {
+		properties, err := gs.getSheetProperties()
+		if err != nil {
+			return nil, fmt.Errorf("unable to retrieve the row count of the sheet: %w", err)
+		}
+		parsedRange.EndRow = properties.GridProperties.RowCount
+	}
+
+	data := [][]interface{}{}
+	for start := parsedRange.StartRow; start < parsedRange.EndRow; start += int64(chunkSize) {
+		page := parsedRange
+		page.StartRow, page.EndRow = start, min(start+int64(chunkSize), parsedRange.EndRow)
+		pageRange := quoteSheetName(gs.sheetName) + "!" + gridRangeA1(page.gridRange(0), nil)
+
+		call := gs.service.Spreadsheets.Values.Get(gs.spreadsheetID, pageRange)
+		if options.valueRender != "" {
+			call = call.ValueRenderOption(options.valueRender)
+		}
+		pageCtx, cancel := gs.withTimeout(ctx)
+		resp, err := call.Context(pageCtx).Do()
+		cancel()
+		if err != nil {
+			return padRows(trimEmptyRows(data), options.padWidth), fmt.Errorf("unable to read the rows from row %d: %w", start+1, gs.apiError(err))
+		}
+
+		// The API leaves out the empty rows at the end of each page, which are kept but for the last page
+		data = append(data, resp.Values...)
+		for i := int64(len(resp.Values)); i < page.EndRow-page.StartRow; i++ {
+			data = append(data, nil)
+		}
+	}
+	return padRows(trimEmptyRows(data), options.padWidth), nil
+}
+
+// trimEmptyRows removes the empty rows at the end of data, as the API does for the ranges it reads.
+func trimEmptyRows(data [][]interface{}) [][]interface{} {
+	end := len(data)
+	for end > 0 && len(data[end-1]) == 0 {
+		end--
+	}
+	return data[:end]
+}
+
+// LastNonEmptyRow finds the last row with a value in a given column of the current set sheet, reading only that
+// column. Use it to find where a column ends when the columns of the sheet have different lengths. Empty cells
+// between the values (interior gaps) are skipped, see FirstEmptyRowAfter to find them.
+//
+// Parameters:
+//   - column: The column letter to search (e.g., "G").
+//
+// Returns:
+//   - The row number (1-based index) of the last non-empty cell of the column, or 0 if the column is empty.
+//   - An error if there was a problem reading the column.
+func (gs *GoogleSheetsClient) LastNonEmptyRow(column string) (int64, error) {
+	values, err := gs.readColumn(column, 1)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := len(values) - 1; i >= 0; i-- {
+		if !isEmptyCell(values[i]) {
+			return int64(i) + 1, nil
+		}
+	}
+	return 0, nil
+}
+
+// FirstEmptyRowAfter finds the first row with an empty cell in a given column of the current set sheet, starting
+// the search at startRow and reading only that part of the column. Unlike the row after LastNonEmptyRow, the
+// result can be an interior gap of the column.
+//
+// Parameters:
+//   - column: The column letter to search (e.g., "G").
+//   - startRow: The row number (1-based index) where the search starts. The row itself is included.
+//
+// Returns:
+//   - The row number (1-based index) of the first empty cell at or after startRow, which is startRow itself when
+//     the column is empty (e.g., 1 for an empty column searched from the first row).
+//   - An error if startRow is lower than 1 or there was a problem reading the column.
+func (gs *GoogleSheetsClient) FirstEmptyRowAfter(column string, startRow int64) (int64, error) {
+	if startRow < 1 {
+		return 0, fmt.Errorf("invalid start row %d: row numbers start at 1", startRow)
+	}
+
+	values, err := gs.readColumn(column, startRow)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, row := range values {
+		if isEmptyCell(row) {
+			return startRow + int64(i), nil
+		}
+	}
+	return startRow + int64(len(values)), nil
+}
+
+// ReadColumnStrings reads the values of a single column of the current set sheet as text, as they are shown in the
+// sheet, for the columns that hold names or codes (e.g., the values of a dropdown).
+//
+// Parameters:
+//   - column: The column to read: a column letter for the whole column (e.g., "G"), or a range of that column to
+//     skip the header row or stop at a given row (e.g., "G2:G" or "G2:G100").
+//
+// Returns:
+//   - The values of the column from the first row of the range, with the empty cells between values as empty
+//     strings and the empty cells after the last value left out. Empty, not nil, if the column is empty.
+//   - An error if the column is not a single column in A1 notation or there was a problem reading it, nil otherwise.
+func (gs *GoogleSheetsClient) ReadColumnStrings(column string) ([]string, error) {
+	values, _, err := gs.readColumnStrings(column)
+	return values, err
+}
+
+// ReadColumnFloats reads the values of a single column of the current set sheet as numbers. The cells are read as
+// they are shown in the sheet and parsed with the separators of the locale of the client (see CellFloat), so a
+// number format that adds other text (e.g., a currency symbol or a percent sign) makes the cells not numeric.
+//
+// Parameters:
+//   - column: The column to read (see ReadColumnStrings).
+//
+// Returns:
+//   - The numbers of the column from the first row of the range, with the empty cells after the last value left
+//     out. Empty, not nil, if the column is empty.
+//   - A *MultiError with an item per cell that is empty or not a number (e.g., "G7"), in which case no number is
+//     returned, or an error if the column is not valid or there was a problem reading it, nil otherwise.
+func (gs *GoogleSheetsClient) ReadColumnFloats(column string) ([]float64, error) {
+	values, cellName, err := gs.readColumnStrings(column)
+	if err != nil {
+		return nil, err
+	}
+
+	numbers := make([]float64, len(values))
+	errs := &MultiError{}
+	for i, value := range values {
+		if value == "" {
+			errs.Add(cellName(i), fmt.Errorf("empty cell"))
+			continue
+		}
+		numbers[i], err = gs.CellFloat(value)
+		if err != nil {
+			errs.Add(cellName(i), err)
+		}
+	}
+	if err := errs.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+	return numbers, nil
+}
+
+// ReadColumnInts reads the values of a single column of the current set sheet as integers, parsed like
+// ReadColumnFloats. Numbers with a fractional part (e.g., "2.5") are not rounded but reported as errors.
+//
+// Parameters:
+//   - column: The column to read (see ReadColumnStrings).
+//
+// Returns:
+//   - The integers of the column from the first row of the range, with the empty cells after the last value left
+//     out. Empty, not nil, if the column is empty.
+//   - A *MultiError with an item per cell that is empty or not an integer (e.g., "G7"), in which case no integer is
+//     returned, or an error if the column is not valid or there was a problem reading it, nil otherwise.
+func (gs *GoogleSheetsClient) ReadColumnInts(column string) ([]int, error) {
+	values, cellName, err := gs.readColumnStrings(column)
+	if err != nil {
+		return nil, err
+	}
+
+	integers := make([]int, len(values))
+	errs := &MultiError{}
+	for i, value := range values {
+		if value == "" {
+			errs.Add(cellName(i), fmt.Errorf("empty cell"))
+			continue
+		}
+		number, err := gs.CellFloat(value)
+		if err != nil {
+			errs.Add(cellName(i), err)
+			continue
+		}
+		if number != math.Trunc(number) || math.Abs(number) > 1<<53 { // Beyond 2^53 a float64 is not an exact integer
+			errs.Add(cellName(i), fmt.Errorf("invalid integer %q", value))
+			continue
+		}
+		integers[i] = int(number)
+	}
+	if err := errs.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+	return integers, nil
+}
+
+// readColumnStrings reads the values of a single column as text, for ReadColumnStrings and the typed variants.
+//
+// Parameters:
+//   - column: The column to read (see ReadColumnStrings).
+//
+// Returns:
+//   - The values of the column, without the trailing empty cells.
+//   - A function that returns the A1 name of the cell of a value, by index (e.g., "G7").
+//   - An error if the column is not a single column in A1 notation or there was a problem reading it.
+func (gs *GoogleSheetsClient) readColumnStrings(column string) ([]string, func(int) string, error) {
+	if !strings.Contains(column, ":") {
+		column += ":" + column // A whole column, "G" is read as "G:G"
+	}
+
+	parsedRange, err := parseA1Range(column)
+	if err != nil || parsedRange.StartColumn == -1 || parsedRange.EndColumn != parsedRange.StartColumn+1 {
+		return nil, nil, fmt.Errorf("invalid column %q: use a column letter or a range of a single column (e.g., \"G\" or \"G2:G\")", column)
+	}
+
+	data, err := gs.ReadData(column)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	values := make([]string, len(data))
+	for i, row := range data {
+		if len(row) > 0 {
+			values[i] = fmt.Sprintf("%v", row[0])
+		}
+	}
+	for len(values) > 0 && values[len(values)-1] == "" {
+		values = values[:len(values)-1]
+	}
+
+	startRow := max(parsedRange.StartRow, 0)
+	cellName := func(i int) string {
+		return fmt.Sprintf("%s%d", columnLetter(int(parsedRange.StartColumn)), startRow+int64(i)+1)
+	}
+	return values, cellName, nil
+}
+
+// readColumn reads a single column of the current set sheet, from a given row to the end of the sheet.
+//
+// Parameters:
+//   - column: The column letter to read (e.g., "G").
+//   - startRow: The row number (1-based index) of the first row to read.
+//
+// Returns:
+//   - The rows of the column, each of them empty or holding one value, or an error if the column is not valid
+//     or there was a problem reading it.
+func (gs *GoogleSheetsClient) readColumn(column string, startRow int64) ([][]interface{}, error) {
+	columnNumber, rowNumber, err := parseA1Cell(column)
+	if err != nil || columnNumber == -1 || rowNumber != -1 {
+		return nil, fmt.Errorf("invalid column %q: use a column letter (e.g., \"G\")", column)
+	}
+
+	return gs.ReadData(fmt.Sprintf("%s%d:%s", column, startRow, column))
+}
+
+// isEmptyCell reports whether a row read from a single column has no value in it.
+func isEmptyCell(row []interface{}) bool {
+	return len(row) == 0 || fmt.Sprintf("%v", row[0]) == ""
+}
+
+// AppendData appends data to the end of the current set sheet in the GoogleSheetsClient struct.
+// Parameters:
+//   - data: A 2D slice representing the data to be added. Each inner slice
+//     represents a row of data, with each element representing a cell value.
+//   - range_: The cell used to search for existing data and find a "table"
+//     within that range where the data will be appended (e.g., "A1"), or an empty string to use the table
+//     range of the sheet (see SetTableRange).
+//   - opts: Optional settings for the write (e.g., WithSerialDates or WithTimeFormat to write time.Time values).
+//
+// Returns:
+//   - An error if there was a problem adding the data to the spreadsheet, nil otherwise. Empty or nil data is a
+//     no-op that returns nil without calling the API.
+func (gs *GoogleSheetsClient) AppendData(data [][]interface{}, range_ string, opts ...WriteOption) error {
+	if len(data) == 0 {
+		return nil // Nothing to append, avoid spending an API call
+	}
+
+	err := gs.resolveSheetName()
+	if err != nil {
+		return err
+	}
+
+	err = gs.appendToSheet(gs.sheetName, data, range_, opts...)
+	if err != nil {
+		return err
+	}
+
+	target, _ := gs.rangeOrTable(gs.sheetName, range_)
+	gs.audit("AppendData", gs.sheetName, target, fmt.Sprintf("%d rows", len(data)))
+	return nil
+}
+
+// AppendDataToSheet appends data to the end of a given sheet like AppendData, without changing the current set
+// sheet of the GoogleSheetsClient struct. Use it instead of calling SetSheetName before and after the append,
+// which is not safe when the client is shared between goroutines.
+//
+// Parameters:
+//   - sheetName: The name of the sheet to append the data to (e.g., "Audit").
+//   - data: A 2D slice representing the data to be added. Each inner slice
+//     represents a row of data, with each element representing a cell value.
+//   - range_: The cell used to search for existing data and find a "table"
+//     within that range where the data will be appended (e.g., "A1").
+//   - opts: Optional settings for the write (see AppendData).
+//
+// Returns:
+//   - An error if there was a problem adding the data to the spreadsheet, nil otherwise. Empty or nil data is a
+//     no-op that returns nil without calling the API.
+func (gs *GoogleSheetsClient) AppendDataToSheet(sheetName string, data [][]interface{}, range_ string, opts ...WriteOption) error {
+	err := gs.appendToSheet(sheetName, data, range_, opts...)
+	if err != nil || len(data) == 0 {
+		return err
+	}
+
+	target, _ := gs.rangeOrTable(sheetName, range_)
+	gs.audit("AppendDataToSheet", sheetName, target, fmt.Sprintf("%d rows", len(data)))
+	return nil
+}
+
+// AppendDataRows appends data to the end of the current set sheet in the GoogleSheetsClient struct like
+// AppendData, and returns the numbers of the rows it was written to, e.g., to format or link them afterwards
+// without reading the sheet again to find them.
+//
+// Parameters:
+//   - data: A 2D slice representing the data to be added. Each inner slice
+//     represents a row of data, with each element representing a cell value.
+//   - range_: The cell used to search for existing data and find a "table"
+//     within that range where the data will be appended (e.g., "A1"), or an empty string to use the table
+//     range of the sheet (see SetTableRange).
+//
+// Returns:
+//   - The 1-based row number in the sheet of each row of data, in order. Empty if data is empty or holds only
+//     empty rows, since nothing is written then.
+//   - An error if there was a problem adding the data to the spreadsheet, nil otherwise.
+func (gs *GoogleSheetsClient) AppendDataRows(data [][]interface{}, range_ string) ([]int64, error) {
+	if len(data) == 0 {
+		return nil, nil // Nothing to append, avoid spending an API call
+	}
+
+	err := gs.resolveSheetName()
+	if err != nil {
+		return nil, err
+	}
+
+	updatedRange, err := gs.appendRows(gs.sheetName, data, range_)
+	if err != nil {
+		return nil, err
+	}
+
+	target, _ := gs.rangeOrTable(gs.sheetName, range_)
+	gs.audit("AppendDataRows", gs.sheetName, target, fmt.Sprintf("%d rows", len(data)))
+
+	if updatedRange == "" {
+		return nil, nil // Only empty rows, nothing was written
+	}
+
+	firstRow, err := firstRowOfRange(updatedRange)
+	if err != nil {
+		return nil, err
+	}
+
+	// The updated range starts at the first appended row, and spans the rows of data up to the last one with values
+	rowNumbers := make([]int64, len(data))
+	for i := range data {
+		rowNumbers[i] = firstRow + int64(i)
+	}
+	return rowNumbers, nil
+}
+
+// firstRowOfRange returns the number of the first row of a range reported by the API (e.g., "Sheet1!A5:C7").
+//
+// Parameters:
+//   - a1: The range, with or without a sheet name.
+//
+// Returns:
+//   - The 1-based number of the first row of the range, or an error if the range is not valid.
+func firstRowOfRange(a1 string) (int64, error) {
+	// The sheet name may contain "!", the cells never do
+	parsedRange, err := parseA1Range(a1[strings.LastIndex(a1, "!")+1:])
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse the range %q: %w", a1, err)
+	}
+	return parsedRange.StartRow + 1, nil
+}
+
+// appendToSheet appends data to the end of a given sheet of the current set spreadsheet.
+//
+// Parameters:
+//   - sheetName: The name of the sheet to append the data to.
+//   - data: A 2D slice representing the data to be added.
+//   - range_: The cell used to search for existing data and find a "table" where the data will be appended.
+//   - opts: Optional settings for the write.
+//
+// Returns:
+//   - An error if there was a problem adding the data to the spreadsheet, nil otherwise.
+func (gs *GoogleSheetsClient) appendToSheet(sheetName string, data [][]interface{}, range_ string, opts ...WriteOption) error {
+	_, err := gs.appendRows(sheetName, data, range_, opts...)
+	return err
+}
+
+// appendRows appends data to the end of a given sheet of the current set spreadsheet, like appendToSheet.
+//
+// Parameters:
+//   - sheetName: The name of the sheet to append the data to.
+//   - data: A 2D slice representing the data to be added.
+//   - range_: The cell used to search for existing data and find a "table" where the data will be appended.
+//   - opts: Optional settings for the write.
+//
+// Returns:
+//   - The A1 range the data was written to (e.g., "Sheet1!A5:C7"), as reported by the API. Empty if nothing was
+//     written, and for the data written as dates with WithSerialDates, for which the API reports no range.
+//   - An error if there was a problem adding the data to the spreadsheet, nil otherwise.
+func (gs *GoogleSheetsClient) appendRows(sheetName string, data [][]interface{}, range_ string, opts ...WriteOption) (string, error) {
+	if len(data) == 0 {
+		return "", nil // Nothing to append, avoid spending an API call
+	}
+
+	options := newWriteOptions(opts)
+
+	if gs.spreadsheetID == "" {
+		return "", fmt.Errorf("spreadsheet ID not set")
+	}
+
+	if sheetName == "" {
+		return "", fmt.Errorf("sheet name not set")
+	}
+
+	err := gs.requireScope(writeScopes...)
+	if err != nil {
+		return "", err
+	}
+
+	range_, err = gs.rangeOrTable(sheetName, range_)
+	if err != nil {
+		return "", err
+	}
+
+	inputOption, err := gs.inputOption(options)
+	if err != nil {
+		return "", err
+	}
+
+	data, err = gs.prepareValues(sheetName, range_, data, options, inputOption)
+	if err != nil {
+		return "", err
+	}
+	if options.serialDates && containsTime(data) {
+		return "", gs.appendCells(sheetName, data, range_, inputOption)
+	}
+
+	valueRange := &sheets.ValueRange{
+		Values: data,
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	call := gs.service.Spreadsheets.Values.Append(gs.spreadsheetID, quoteSheetName(sheetName)+"!"+range_, valueRange).ValueInputOption(inputOption)
+	if options.insertRows {
+		call = call.InsertDataOption("INSERT_ROWS")
+	}
+	resp, err := call.Context(ctx).Do()
+	if err != nil {
+		return "", fmt.Errorf("unable to add data to Google Sheets: %w", gs.apiError(err))
+	}
+	if resp.Updates == nil || resp.Updates.UpdatedRange == "" {
+		return "", nil
+	}
+
+	if options.inheritRowFormat {
+		err = gs.inheritRowFormat(sheetName, range_, resp.Updates.UpdatedRange)
+		if err != nil {
+			return resp.Updates.UpdatedRange, err
+		}
+	}
+	return resp.Updates.UpdatedRange, nil
+}
+
+// prepareValues applies the conversions of the write options to the values of an append (e.g., InferTypes,
+// WithNumericCoercion and WithTimeFormat), without modifying the data of the caller.
+//
+// Parameters:
+//   - sheetName: The name of the sheet the data is written to.
+//   - range_: The range the data is written to, without the sheet name. Its first column is the column of the
+//     first cell of each row.
+//   - data: The data to write.
+//   - options: The settings of the write.
+//   - inputOption: How the values are interpreted (see inputOption).
+//
+// Returns:
+//   - The converted data, or an error if there was a problem converting it.
+func (gs *GoogleSheetsClient) prepareValues(sheetName, range_ string, data [][]interface{}, options *writeOptions, inputOption string) ([][]interface{}, error) {
+	if options.inferTypes {
+		data = inferTypes(data)
+	}
+	switch {
+	case options.coerceNumbers:
+		var err error
+		data, err = gs.coerceNumbers(sheetName, range_, data, options.coercionExclude)
+		if err != nil {
+			return nil, err
+		}
+	case inputOption == InputRaw && !options.inferTypes:
+		gs.warnNumericText(sheetName, range_, data)
+	}
+	if options.timeLayout != "" {
+		data = formatTimes(data, options.timeLayout, options.timeLocation, inputOption == InputUserEntered)
+	}
+	return data, nil
+}
+
+// inputOption returns how the values of a write are interpreted: the option passed with ValueInput, or else
+// the default of the client (see WithValueInputOption), or else InputRaw.
+//
+// Parameters:
+//   - options: The settings of the write.
+//
+// Returns:
+//   - The value input option, or an error if it is not InputRaw or InputUserEntered.
+func (gs *GoogleSheetsClient) inputOption(options *writeOptions) (string, error) {
+	option := options.valueInputOption
+	if option == "" {
+		option = gs.valueInputOption
+	}
+	if option == "" {
+		return InputRaw, nil
+	}
+	return option, checkValueInputOption(option)
+}
+
+// checkValueInputOption checks that a value input option is one accepted by the API.
+func checkValueInputOption(option string) error {
+	if option != InputRaw && option != InputUserEntered {
+		return fmt.Errorf("invalid value input option %q: use %s or %s", option, InputRaw, InputUserEntered)
+	}
+	return nil
+}
+
+// DefaultChunkSize is the number of rows appended per request by AppendDataChunked when no chunk size is given.
+const DefaultChunkSize = 5000
+
+// AppendDataChunked appends data to the end of the current set sheet in the GoogleSheetsClient struct in chunks of
+// chunkSize rows, one request per chunk, so large amounts of data do not exceed the payload limits of the API.
+// The chunks are appended sequentially and the method stops at the first chunk that fails.
+//
+// Parameters:
+//   - data: A 2D slice representing the data to be added. Each inner slice
+//     represents a row of data, with each element representing a cell value.
+//   - range_: The cell used to search for existing data and find a "table"
+//     within that range where the data will be appended (e.g., "A1").
+//   - chunkSize: The maximum number of rows per request, or 0 to use DefaultChunkSize.
+//   - opts: Optional settings for the write of every chunk (see AppendData).
+//
+// Returns:
+//   - A *ChunkError with the index in data of the first row that was not appended if a chunk failed, nil otherwise.
+func (gs *GoogleSheetsClient) AppendDataChunked(data [][]interface{}, range_ string, chunkSize int, opts ...WriteOption) error {
+	gs = gs.startOperation() // The calls below share the retry budget of the method
+
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+
+	for start := 0; start < len(data); start += chunkSize {
+		end := min(start+chunkSize, len(data))
+
+		err := gs.AppendData(data[start:end], range_, opts...)
+		if err != nil {
+			return &ChunkError{RowIndex: start, Err: err}
+		}
+	}
+
+	return nil
+}
+
+// AppendWithHeader appends data to the end of the current set sheet in the GoogleSheetsClient struct, writing
+// the header row first if the sheet is empty. The sheet is considered empty when its A1 cell has no value, so
+// calling this method repeatedly writes the header only once.
+//
+// Parameters:
+//   - headers: The header row to write when the sheet is empty.
+//   - data: A 2D slice representing the data to be added. Each inner slice
+//     represents a row of data, with each element representing a cell value.
+//   - range_: The cell used to search for existing data and find a "table"
+//     within that range where the data will be appended (e.g., "A1").
+//   - opts: Optional settings for the write (see AppendData).
+//
+// Returns:
+//   - An error if there was a problem adding the data to the spreadsheet, nil otherwise.
+func (gs *GoogleSheetsClient) AppendWithHeader(headers []string, data [][]interface{}, range_ string, opts ...WriteOption) error {
+	range_, err := gs.rangeOrTable(gs.sheetName, range_)
+	if err != nil {
+		return err
+	}
+
+	firstCell, err := gs.ReadData("A1")
+	if err != nil {
+		return fmt.Errorf("unable to check if the sheet is empty: %w", err)
+	}
+
+	if len(firstCell) == 0 || len(firstCell[0]) == 0 {
+		headerRow := make([]interface{}, len(headers))
+		for i, header := range headers {
+			headerRow[i] = header
+		}
+
+		err = gs.AppendData(append([][]interface{}{headerRow}, data...), range_, opts...)
+		if err != nil {
+			return err
+		}
+
+		gs.invalidateHeaders()
+		if range_ == "A1" {
+			gs.headerCache.set(gs.headerCacheKey(), headers) // The header row is known, save a read
+		}
+		return nil
+	}
+
+	return gs.AppendData(data, range_, opts...)
+}
+
+// AppendWithTimestamp appends data to the end of the current set sheet in the GoogleSheetsClient struct like
+// AppendData, inserting the current time in a given column of every row. All the rows get the same time, taken
+// once when the method is called. The time is written as RFC 3339 text in UTC, unless opts hold WithSerialDates or
+// WithTimeFormat.
+//
+// Parameters:
+//   - data: A 2D slice representing the data to be added, without the timestamps.
+//   - range_: The cell used to search for existing data and find a "table" within that range where the data will be
+//     appended (e.g., "A1"), or an empty string to use the table range of the sheet (see SetTableRange).
+//   - timestampColumn: The column letter of the timestamps in the sheet (e.g., "A"). The cells of each row from this
+//     column on are shifted one column to the right, and rows shorter than it are padded with empty cells.
+//   - opts: Optional settings for the write (see AppendData).
+//
+// Returns:
+//   - An error if the timestamp column is not valid or is before the first column of the range, or there was a
+//     problem appending the data, nil otherwise.
+func (gs *GoogleSheetsClient) AppendWithTimestamp(data [][]interface{}, range_ string, timestampColumn string, opts ...WriteOption) error {
+	column, row, err := parseA1Cell(timestampColumn)
+	if err != nil || column == -1 || row != -1 {
+		return fmt.Errorf("invalid column %q: use a column letter (e.g., \"A\")", timestampColumn)
+	}
+
+	anchor, err := gs.rangeOrTable(gs.sheetName, range_)
+	if err != nil {
+		return err
+	}
+	parsedRange, err := parseA1Range(anchor)
+	if err != nil {
+		return err
+	}
+
+	index := int(column - parsedRange.StartColumn)
+	if index < 0 {
+		return fmt.Errorf("invalid column %q: it is before the first column of the range %s", timestampColumn, anchor)
+	}
+
+	now := time.Now()
+	stamped := make([][]interface{}, len(data))
+	for i, values := range data {
+		row := make([]interface{}, max(len(values), index), max(len(values), index)+1)
+		copy(row, values) // Do not modify the data of the caller
+		stamped[i] = slices.Insert(row, index, interface{}(now))
+	}
+
+	if options := newWriteOptions(opts); !options.serialDates && options.timeLayout == "" {
+		opts = append(opts, WithTimeFormat(time.RFC3339, time.UTC))
+	}
+	return gs.AppendData(stamped, range_, opts...)
+}
+
+// UpdateColumns writes column-oriented data to a range of the current set sheet in the GoogleSheetsClient struct:
+// each inner slice is a column, written from the top of the range down, so the data does not need to be transposed
+// before the write. The cells of the range outside the data keep their values.
+//
+// Parameters:
+//   - writeRange: The range to write (e.g., "B2:D100"), starting at its top-left cell. The API rejects data that
+//     does not fit in it.
+//   - columns: A 2D slice representing the data to write. Each inner slice represents a column of data, with each
+//     element representing a cell value.
+//   - opts: Optional settings for the write (e.g., ValueInput to parse formulas and dates).
+//
+// Returns:
+//   - An error if the range is not valid or there was a problem writing the data, nil otherwise. Empty or nil data
+//     is a no-op that returns nil without calling the API.
+func (gs *GoogleSheetsClient) UpdateColumns(writeRange string, columns [][]interface{}, opts ...WriteOption) error {
+	options := newWriteOptions(opts)
+
+	parsedRange, err := parseA1Range(writeRange)
+	if err != nil {
+		return err
+	}
+
+	if len(columns) == 0 {
+		return nil // Nothing to write, avoid spending an API call
+	}
+
+	err = validateClientFields(gs)
+	if err != nil {
+		return err
+	}
+
+	err = gs.requireScope(writeScopes...)
+	if err != nil {
+		return err
+	}
+
+	inputOption, err := gs.inputOption(options)
+	if err != nil {
+		return err
+	}
+
+	if options.protectionCheck {
+		err = gs.checkProtection(gs.sheetName, quoteSheetName(gs.sheetName)+"!"+writeRange, parsedRange)
+		if err != nil {
+			return err
+		}
+	}
+
+	valueRange := &sheets.ValueRange{
+		MajorDimension: "COLUMNS",
+		Values:         columns,
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	target := quoteSheetName(gs.sheetName) + "!" + writeRange
+	_, err = gs.service.Spreadsheets.Values.Update(gs.spreadsheetID, target, valueRange).ValueInputOption(inputOption).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("unable to write data to Google Sheets: %w", gs.apiError(err))
+	}
+
+	if parsedRange.StartRow == 0 {
+		gs.invalidateHeaders() // The header row was overwritten
+	}
+	gs.audit("UpdateColumns", gs.sheetName, writeRange, fmt.Sprintf("%d columns", len(columns)))
+	return nil
+}
+
+// InsertRowsAtPosition inserts a specified number of rows after a specified row in current set sheet in the GoogleSheetsClient struct. The position is 1-based, with 1 being the header row. Note: Is not possible to insert rows before the header row, giving a position of 0 will result in an error.
+//
+// Parameters:
+//   - data: The data to insert into the spreadsheet.
+//   - position: The index of the row after which the new rows will be inserted.
+//   - opts: Optional settings for the write (e.g., Force to insert between protected header rows, or the options
+//     of AppendData for the values). With WithSerialDates, the rows are inserted and written in a single batch
+//     update.
+//
+// Returns:
+//   - ErrHeaderProtected if the rows would be inserted above or between the protected header rows (see ProtectHeaderRows).
+//   - An error if there was a problem inserting the rows, nil otherwise. Empty or nil data is a no-op that returns
+//     nil without calling the API.
+func (gs *GoogleSheetsClient) InsertRowsAfterPosition(data [][]interface{}, position int64, opts ...WriteOption) error {
+	if len(data) == 0 {
+		return nil // Nothing to insert, avoid spending an API call
+	}
+
+	options := newWriteOptions(opts)
+
+	err := gs.requireScope(writeScopes...)
+	if err != nil {
+		return err
+	}
+
+	properties, err := gs.getSheetProperties()
+	if err != nil {
+		return fmt.Errorf("unable to retrieve sheet ID: %w", err)
+	}
+
+	if headerRows := gs.headerRowCount(properties); position < headerRows && !options.force {
+		return fmt.Errorf("%w: cannot insert rows after row %d, the first %d rows are protected", ErrHeaderProtected, position, headerRows)
+	}
+
+	numRows := int64(len(data))
+
+	// The values written as dates cannot be appended at a position (an AppendCellsRequest always writes after the
+	// last row of the sheet), so they are written over the inserted rows in the same batch update
+	var dateRows []*sheets.RowData
+	if options.serialDates {
+		dateRows, err = gs.serialDateRows(data, "A"+fmt.Sprint(position+1), options)
+		if err != nil {
+			return err
+		}
+	}
+
+	insertRequest := &sheets.Request{
+		InsertDimension: &sheets.InsertDimensionRequest{
+			Range: &sheets.DimensionRange{
+				SheetId:    properties.SheetId,
+				Dimension:  "ROWS",
+				StartIndex: position, // 0 is not a valid index in InsertDimensionRequest
+				EndIndex:   position + numRows,
+			},
+			InheritFromBefore: false,
+		},
+	}
+
+	requests := []*sheets.Request{insertRequest}
+	if dateRows != nil {
+		requests = append(requests, &sheets.Request{
+			UpdateCells: &sheets.UpdateCellsRequest{
+				Start:  &sheets.GridCoordinate{SheetId: properties.SheetId, RowIndex: position},
+				Rows:   dateRows,
+				Fields: dateCellFields,
+			},
+		})
+	}
+
+	batchUpdateRequest := &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: requests,
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	_, err = gs.service.Spreadsheets.BatchUpdate(gs.spreadsheetID, batchUpdateRequest).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("unable to insert rows at position: %w", gs.apiError(err))
+	}
+
+	if position == 0 {
+		gs.invalidateHeaders() // The header row moved down
+	}
+
+	if dateRows == nil {
+		err = gs.appendToSheet(gs.sheetName, data, "A"+fmt.Sprint(position), opts...)
+		if err != nil {
+			return fmt.Errorf("unable to append data to Google Sheets: %w", err)
+		}
+	}
+
+	gs.audit("InsertRowsAfterPosition", gs.sheetName, fmt.Sprintf("after row %d", position), fmt.Sprintf("%d rows", numRows))
+	return nil
+}
+
+// InsertRowsAtBeginning inserts a specified number of rows at the beginning of current set sheet in the GoogleSheetsClient struct.The beginning of the sheet is considered to be the row after the header row. Note: This function assumes that the header row is the first row in the sheet.
+//
+// Parameters:
+//   - data: The data to insert into the spreadsheet.
+//   - opts: Optional settings for the write (e.g., Force to insert between protected header rows).
+//
+// Returns:
+//   - An error if there was a problem inserting the rows, nil otherwise.
+func (gs *GoogleSheetsClient) InsertRowsAtBeginning(data [][]interface{}, opts ...WriteOption) error {
+	err := gs.InsertRowsAfterPosition(data, 1, opts...)
+	if err != nil {
+		return fmt.Errorf("unable to insert rows at beginning: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteRow deletes the first occurrence of a row from a current set sheet in the GoogleSheetsClient struct. Note: This function assumes that the header row is the first row in the sheet.
+//
+// Parameters:
+//   - data: The 2D slice representing the data to search through. Use the ReadData method to get this data.
+//   - column: The column in which to apply the filter.
+//   - value: The value to filter on.
+//   - opts: Optional settings for the write (e.g., Force to delete a protected header row, or WithMatch to
+//     change how the cells are compared with the value).
+//
+// Returns:
+//   - ErrEmptyData if data is empty or nil, which usually means ReadData returned nothing.
+//   - ErrHeaderProtected if the row is one of the protected header rows (see ProtectHeaderRows).
+//   - A *ReferenceError, matching ErrReferenced, if the row is referenced by formulas and WithReferenceCheck is set.
+//   - An error if there was a problem deleting the row, nil otherwise.
+func (gs *GoogleSheetsClient) DeleteRow(data [][]interface{}, column, value string, opts ...WriteOption) error {
+	options := newWriteOptions(opts)
+
+	err := gs.requireScope(writeScopes...)
+	if err != nil {
+		return err
+	}
+
+	if len(data) == 0 {
+		return ErrEmptyData
+	}
+
+	matcher, err := gs.newMatcher(value, options.match)
+	if err != nil {
+		return err
+	}
+
+	rowIndex := findRow(data, column, matcher)
+	if rowIndex == -1 {
+		return fmt.Errorf("unable to find the value %v in column %v", value, column)
+	}
+
+	properties, err := gs.getSheetProperties()
+	if err != nil {
+		return fmt.Errorf("unable to retrieve sheet ID: %w", err)
+	}
+	sheetID := properties.SheetId
+
+	if headerRows := gs.headerRowCount(properties); int64(rowIndex) <= headerRows && !options.force {
+		return fmt.Errorf("%w: row %d matches the value %v, but the first %d rows are protected", ErrHeaderProtected, rowIndex, value, headerRows)
+	}
+
+	if options.referenceCheck {
+		err = gs.checkRowReferences(rowIndex)
+		if err != nil {
+			return err
+		}
+	}
+
+	if options.protectionCheck {
+		err = gs.checkRowProtection(int64(rowIndex))
+		if err != nil {
+			return err
+		}
+	}
+
+	requests := []*sheets.Request{
+		{
+			DeleteDimension: &sheets.DeleteDimensionRequest{
+				Range: &sheets.DimensionRange{
+					SheetId:    sheetID, // Sheet ID (can be 0 for the first sheet)
+					Dimension:  "ROWS",
+					StartIndex: int64(rowIndex - 1), // Index of the row to delete (subtract 1 since rows are 0-based)
+					EndIndex:   int64(rowIndex),     // Index of the next row
+				},
+			},
+		},
+	}
+
+	batchUpdate := &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: requests,
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	_, err = gs.service.Spreadsheets.BatchUpdate(gs.spreadsheetID, batchUpdate).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("unable to delete row from Google Sheets: %w", gs.apiError(err))
+	}
+
+	if rowIndex == 1 {
+		gs.invalidateHeaders() // The header row was deleted
+	}
+	gs.audit("DeleteRow", gs.sheetName, fmt.Sprintf("row %d", rowIndex), fmt.Sprintf("column %s matched %v", column, value))
+	return nil
+}
+
+// DeleteRowByValue deletes the first row of the current set sheet in the GoogleSheetsClient struct whose cell in a
+// given column matches a value, like DeleteRow, but without the data of the sheet: it reads only the column to find
+// the row, so deleting a row from a large sheet costs one narrow read and one batch update.
+//
+// Parameters:
+//   - column: The column letter in which to search for the value (e.g., "C").
+//   - value: The value to search for.
+//   - opts: Optional settings for the write (e.g., Force to delete a protected header row, or WithMatch to
+//     change how the cells are compared with the value, like in FindRowNumber).
+//
+// Returns:
+//   - ErrRowNotFound if no cell of the column matches the value.
+//   - ErrHeaderProtected if the row is one of the protected header rows (see ProtectHeaderRows).
+//   - A *ReferenceError, matching ErrReferenced, if the row is referenced by formulas and WithReferenceCheck is set.
+//   - An error if there was a problem deleting the row, nil otherwise.
+func (gs *GoogleSheetsClient) DeleteRowByValue(column, value string, opts ...WriteOption) error {
+	options := newWriteOptions(opts)
+
+	err := gs.requireScope(writeScopes...)
+	if err != nil {
+		return err
+	}
+
+	matcher, err := gs.newMatcher(value, options.match)
+	if err != nil {
+		return err
+	}
+
+	cells, err := gs.readColumn(column, 1)
+	if err != nil {
+		return err
+	}
+
+	row := findRow(cells, "A", matcher) // The column read is the only one of cells
+	if row == -1 {
+		return fmt.Errorf("%w: no cell of column %s matches the value %v", ErrRowNotFound, column, value)
+	}
+
+	properties, err := gs.getSheetProperties()
+	if err != nil {
+		return fmt.Errorf("unable to retrieve sheet ID: %w", err)
+	}
+
+	if headerRows := gs.headerRowCount(properties); int64(row) <= headerRows && !options.force {
+		return fmt.Errorf("%w: row %d matches the value %v, but the first %d rows are protected", ErrHeaderProtected, row, value, headerRows)
+	}
+
+	if options.referenceCheck {
+		err = gs.checkRowReferences(row)
+		if err != nil {
+			return err
+		}
+	}
+
+	if options.protectionCheck {
+		err = gs.checkRowProtection(int64(row))
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = gs.deleteRowNumbers(properties.SheetId, []int64{int64(row)})
+	if err != nil {
+		return err
+	}
+
+	if row == 1 {
+		gs.invalidateHeaders() // The header row was deleted
+	}
+	gs.audit("DeleteRowByValue", gs.sheetName, fmt.Sprintf("row %d", row), fmt.Sprintf("column %s matched %v", column, value))
+	return nil
+}
+
+// ClearRow clears the values of a row of the current set sheet in the GoogleSheetsClient struct, keeping the row
+// and its formatting. Unlike DeleteRow, the rows below are not shifted up, so references to them stay valid.
+//
+// Parameters:
+//   - row: The 1-based number of the row to clear.
+//
+// Returns:
+//   - An error if the row number is not valid or there was a problem clearing the row, nil otherwise.
+func (gs *GoogleSheetsClient) ClearRow(row int64) error {
+	if row < 1 {
+		return fmt.Errorf("invalid row number %d, rows start at 1", row)
+	}
+
+	err := validateClientFields(gs)
+	if err != nil {
+		return err
+	}
+
+	err = gs.requireScope(writeScopes...)
+	if err != nil {
+		return err
+	}
+
+	clearRange := fmt.Sprintf("%s!%d:%d", quoteSheetName(gs.sheetName), row, row)
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	_, err = gs.service.Spreadsheets.Values.Clear(gs.spreadsheetID, clearRange, &sheets.ClearValuesRequest{}).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("unable to clear row from Google Sheets: %w", gs.apiError(err))
+	}
+
+	if row == 1 {
+		gs.invalidateHeaders() // The header row was cleared
+	}
+	gs.audit("ClearRow", gs.sheetName, fmt.Sprintf("row %d", row), "values cleared")
+	return nil
+}
+
+// ReplaceData replaces all the data of the current set sheet in the GoogleSheetsClient struct with new data. The
+// existing values are cleared and the new ones written in a single batch update, so readers never see the sheet
+// empty or half written. The formatting of the cells is kept. Strings starting with "=" are written as formulas.
+//
+// Parameters:
+//   - data: A 2D slice representing the new data, written starting at column A.
+//   - keepHeader: Whether to keep the first row of the sheet, writing the data from the second row.
+//
+// Returns:
+//   - An error if there was a problem replacing the data, nil otherwise.
+func (gs *GoogleSheetsClient) ReplaceData(data [][]interface{}, keepHeader bool) error {
+	err := validateClientFields(gs)
+	if err != nil {
+		return err
+	}
+
+	err = gs.requireScope(writeScopes...)
+	if err != nil {
+		return err
+	}
+
+	properties, err := gs.getSheetProperties()
+	if err != nil {
+		return fmt.Errorf("unable to retrieve sheet ID: %w", err)
+	}
+
+	startRow := int64(0)
+	if keepHeader {
+		startRow = 1
+	}
+
+	rows := make([]*sheets.RowData, len(data))
+	width := int64(0)
+	for i, row := range data {
+		rowData := &sheets.RowData{Values: make([]*sheets.CellData, len(row))}
+		for j, value := range row {
+			rowData.Values[j] = &sheets.CellData{UserEnteredValue: interfaceToExtendedValue(value)}
+		}
+		rows[i] = rowData
+		width = max(width, int64(len(row)))
+	}
+
+	// UpdateCells cannot write outside the grid, so grow it first if the new data does not fit.
+	var requests []*sheets.Request
+	grid := properties.GridProperties
+	if missing := startRow + int64(len(data)) - grid.RowCount; missing > 0 {
+		requests = append(requests, &sheets.Request{
+			AppendDimension: &sheets.AppendDimensionRequest{SheetId: properties.SheetId, Dimension: "ROWS", Length: missing},
+		})
+	}
+	if missing := width - grid.ColumnCount; missing > 0 {
+		requests = append(requests, &sheets.Request{
+			AppendDimension: &sheets.AppendDimensionRequest{SheetId: properties.SheetId, Dimension: "COLUMNS", Length: missing},
+		})
+	}
+
+	// The cells of the range not covered by the rows are cleared.
+	requests = append(requests, &sheets.Request{
+		UpdateCells: &sheets.UpdateCellsRequest{
+			Range:  a1Range{StartRow: startRow, EndRow: -1, EndColumn: -1}.gridRange(properties.SheetId),
+			Rows:   rows,
+			Fields: "userEnteredValue",
+		},
+	})
+
+	batchUpdateRequest := &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: requests,
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	_, err = gs.service.Spreadsheets.BatchUpdate(gs.spreadsheetID, batchUpdateRequest).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("unable to replace data in Google Sheets: %w", gs.apiError(err))
+	}
+
+	if !keepHeader {
+		gs.invalidateHeaders() // The header row was replaced
+	}
+	gs.audit("ReplaceData", gs.sheetName, fmt.Sprintf("from row %d", startRow+1), fmt.Sprintf("%d rows", len(data)))
+	return nil
+}
+
+// DeleteRowsWhere deletes every row for which the predicate returns true from the current set sheet in the GoogleSheetsClient struct.
+// The rows are deleted bottom-up, so the deletion of a row does not shift the position of the rows still to be
+// deleted, with one request per run of adjacent rows and at most 100 requests per batch update; larger deletions
+// continue in further batch updates. Note: This function assumes that data was read starting at the first row of the sheet.
+//
+// Parameters:
+//   - data: The 2D slice representing the data to search through. Use the ReadData method to get this data.
+//   - predicate: The function that reports whether a row must be deleted (e.g., one built with MatchColumn).
+//   - opts: Optional settings for the write (e.g., Force to delete protected header rows).
+//
+// Returns:
+//   - The number of rows deleted. If a batch update fails, the rows deleted by the previous ones are the bottom
+//     matching rows.
+//   - ErrHeaderProtected if any of the matching rows is a protected header row (see ProtectHeaderRows), in which case no row is deleted.
+//   - An error if there was a problem deleting the rows, nil otherwise.
+func (gs *GoogleSheetsClient) DeleteRowsWhere(data [][]interface{}, predicate func(row []interface{}) bool, opts ...WriteOption) (int, error) {
+	gs = gs.startOperation() // The calls below share the retry budget of the method
+
+	options := newWriteOptions(opts)
+
+	err := gs.requireScope(writeScopes...)
+	if err != nil {
+		return 0, err
+	}
+
+	var rowIndexes []int64
+	for i := len(data) - 1; i >= 0; i-- {
+		if predicate(data[i]) {
+			rowIndexes = append(rowIndexes, int64(i)) // 0-based, bottom-up
+		}
+	}
+
+	if len(rowIndexes) == 0 {
+		return 0, nil
+	}
+
+	properties, err := gs.getSheetProperties()
+	if err != nil {
+		return 0, fmt.Errorf("unable to retrieve sheet ID: %w", err)
+	}
+	sheetID := properties.SheetId
+
+	// rowIndexes is sorted bottom-up, so the last one is the topmost row.
+	if headerRows := gs.headerRowCount(properties); rowIndexes[len(rowIndexes)-1] < headerRows && !options.force {
+		return 0, fmt.Errorf("%w: row %d matches the predicate, but the first %d rows are protected", ErrHeaderProtected, rowIndexes[len(rowIndexes)-1]+1, headerRows)
+	}
+
+	rowNumbers := make([]int64, len(rowIndexes))
+	for i, rowIndex := range rowIndexes {
+		rowNumbers[len(rowIndexes)-1-i] = rowIndex + 1 // 1-based, ascending
+	}
+
+	if options.protectionCheck {
+		err = gs.checkRowProtection(rowNumbers...)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	deleted, err := gs.deleteRowNumbers(sheetID, rowNumbers)
+	if err != nil {
+		return deleted, err
+	}
+
+	if rowIndexes[len(rowIndexes)-1] == 0 {
+		gs.invalidateHeaders() // The header row was deleted
+	}
+	gs.audit("DeleteRowsWhere", gs.sheetName, fmt.Sprintf("rows %v", rowNumbers), fmt.Sprintf("%d rows deleted", len(rowNumbers)))
+	return len(rowIndexes), nil
+}
+
+// maxDeleteRequests is the maximum number of DeleteDimensionRequests sent in a single batch update by
+// deleteRowNumbers. Larger deletions continue in further batch updates.
+var maxDeleteRequests = 100
+
+// deleteRowNumbers deletes rows of a sheet bottom-up, so the deletion of a row does not shift the position of the
+// rows still to be deleted. Runs of adjacent rows are deleted with a single DeleteDimensionRequest, and the requests
+// are sent in batch updates of at most maxDeleteRequests requests, the bottom ones first.
+//
+// Parameters:
+//   - sheetID: The ID of the sheet.
+//   - rowNumbers: The 1-based numbers of the rows to delete, in ascending order.
+//
+// Returns:
+//   - The number of rows deleted. If a batch update fails, they are the last ones of rowNumbers.
+//   - An error if there was a problem deleting the rows, nil otherwise.
+func (gs *GoogleSheetsClient) deleteRowNumbers(sheetID int64, rowNumbers []int64) (int, error) {
+	spans := coalesceRowNumbers(rowNumbers)
+
+	deleted := 0
+	for len(spans) > 0 {
+		batch := spans[:min(len(spans), maxDeleteRequests)]
+		spans = spans[len(batch):]
+
+		requests := make([]*sheets.Request, len(batch))
+		batchRows := 0
+		for i, span := range batch {
+			requests[i] = &sheets.Request{
+				DeleteDimension: &sheets.DeleteDimensionRequest{
+					Range: &sheets.DimensionRange{
+						SheetId:    sheetID,
+						Dimension:  "ROWS",
+						StartIndex: span[0],
+						EndIndex:   span[1],
+					},
+				},
+			}
+			batchRows += int(span[1] - span[0])
+		}
+
+		batchUpdate := &sheets.BatchUpdateSpreadsheetRequest{
+			Requests: requests,
+		}
+
+		ctx, cancel := gs.withTimeout(context.Background())
+		_, err := gs.service.Spreadsheets.BatchUpdate(gs.spreadsheetID, batchUpdate).Context(ctx).Do()
+		cancel()
+		if err != nil {
+			return deleted, fmt.Errorf("unable to delete rows from Google Sheets (%d of %d rows deleted): %w", deleted, len(rowNumbers), gs.apiError(err))
+		}
+		deleted += batchRows
+	}
+	return deleted, nil
+}
+
+// coalesceRowNumbers converts row numbers to the spans of adjacent rows they form, bottom-up.
+//
+// Parameters:
+//   - rowNumbers: The 1-based numbers of the rows, in ascending order.
+//
+// Returns:
+//   - The 0-based [start, end) row indexes of each span, from the bottom span to the top one.
+func coalesceRowNumbers(rowNumbers []int64) [][2]int64 {
+	var spans [][2]int64
+	for i := len(rowNumbers) - 1; i >= 0; i-- {
+		index := rowNumbers[i] - 1
+		if n := len(spans); n > 0 && spans[n-1][0] == index+1 {
+			spans[n-1][0] = index
+			continue
+		}
+		if n := len(spans); n > 0 && spans[n-1][0] == index {
+			continue // Repeated row number
+		}
+		spans = append(spans, [2]int64{index, index + 1})
+	}
+	return spans
+}
+
+// DataToString converts a 2D slice of interface{} values to a string.
+//
+// Parameters:
+//   - data: The 2D slice of interface{} values to convert.
+//
+// Returns:
+//   - A string representation of the data.
+func DataToString(data [][]interface{}) string {
+	var result strings.Builder
+
+	for _, row := range data {
+		for _, cell := range row {
+			result.WriteString(fmt.Sprintf("%v\t", cell))
+		}
+		result.WriteString("\n")
+	}
+
+	return result.String()
+}
+
+// FindRowNumber finds the row number containing a specific value in a given column. By default the string
+// representation of the cell must be exactly equal to the value; pass MatchOption values (e.g., IgnoreCase,
+// TrimSpace, NumericEqual or Regexp) to relax the comparison.
+//
+// Parameters:
+//   - data: The 2D slice representing the data to search through. Use the ReadData method to get this data.
+//   - column: The column letter in which to search for the value.
+//   - value: The value to search for.
+//   - opts: Optional settings for the comparison of the cells with the value.
+//
+// Returns:
+//   - The row number (1-based index) containing the value, or -1 if not found.
+func FindRowNumber(data [][]interface{}, column, value string, opts ...MatchOption) int {
+	return findRow(data, column, newMatcher(value, opts))
+}
+
+// findRow finds the number of the first row whose cell in a given column satisfies a matcher.
+//
+// Parameters:
+//   - data: The 2D slice representing the data to search through.
+//   - column: The column letter in which to search.
+//   - matcher: The function that reports whether a cell matches.
+//
+// Returns:
+//   - The row number (1-based index) of the first matching row, or -1 if not found.
+func findRow(data [][]interface{}, column string, matcher valueMatcher) int {
+	for i, row := range data {
+		if cellMatches(row, column, matcher) {
+			return i + 1 // 1-based row index
+		}
+	}
+	return -1
+}
+
+// CountRowsWhere counts the rows for which the predicate returns true, which are the rows DeleteRowsWhere would
+// delete given the same data and predicate, e.g., to ask the user to confirm a deletion before running it.
+//
+// Parameters:
+//   - data: The 2D slice representing the data to search through. Use the ReadData method to get this data.
+//   - predicate: The function that reports whether a row matches (e.g., one built with MatchColumn).
+//
+// Returns:
+//   - The number of rows for which the predicate returns true, 0 if the predicate is nil.
+func CountRowsWhere(data [][]interface{}, predicate func(row []interface{}) bool) int {
+	if predicate == nil {
+		return 0
+	}
+
+	count := 0
+	for _, row := range data {
+		if predicate(row) {
+			count++
+		}
+	}
+	return count
+}
+
+// CountInColumn counts the rows containing a specific value in a given column.
+//
+// Parameters:
+//   - data: The 2D slice representing the data to search through. Use the ReadData method to get this data.
+//   - column: The column letter in which to search for the value.
+//   - value: The value to search for.
+//   - opts: Optional settings for the comparison of the cells with the value (see FindRowNumber).
+//
+// Returns:
+//   - The number of rows containing the value in the column.
+func CountInColumn(data [][]interface{}, column, value string, opts ...MatchOption) int {
+	matcher := newMatcher(value, opts)
+
+	count := 0
+	for _, row := range data {
+		if cellMatches(row, column, matcher) {
+			count++
+		}
+	}
+	return count
+}
+
+// DistinctColumnValues returns the unique values of a given column, e.g., to build a dropdown or a pivot.
+//
+// Parameters:
+//   - data: The 2D slice representing the data to search through. Use the ReadData method to get this data.
+//   - column: The column letter from which to collect the values.
+//
+// Returns:
+//   - The string representations of the unique values, in order of first appearance. Empty cells are skipped.
+func DistinctColumnValues(data [][]interface{}, column string) []string {
+	index := columnIndex(column)
+	seen := make(map[string]bool)
+	values := []string{}
+
+	for _, row := range data {
+		if len(row) < index+1 || row[index] == nil {
+			continue // Rows shorter than the column and padded rows (see PadRows) have an empty cell in it
+		}
+		value := fmt.Sprintf("%v", row[index])
+		if value == "" || seen[value] {
+			continue
+		}
+		seen[value] = true
+		values = append(values, value)
+	}
+	return values
+}
+
+// FindDuplicates finds the values that appear more than once in a given column, e.g., to check that the column can
+// be used as a unique key. The cells are compared like in FindRowNumber: by default their string representations
+// must be exactly equal.
+//
+// Parameters:
+//   - data: The 2D slice representing the data to search through. Use the ReadData method to get this data.
+//   - column: The column letter in which to search for duplicates.
+//   - opts: Optional settings for the comparison of the cells (IgnoreCase, TrimSpace, NumericEqual and
+//     NumberLocale; Regexp is ignored).
+//
+// Returns:
+//   - A map from each duplicated value, as it first appears, to the 1-based numbers of the rows where it appears,
+//     in ascending order. Empty, not nil, if there are no duplicates. Empty cells are skipped.
+func FindDuplicates(data [][]interface{}, column string, opts ...MatchOption) map[string][]int {
+	options := newMatchOptions(opts)
+	index := columnIndex(column)
+
+	first := make(map[string]string) // The value of the first appearance of each key
+	rows := make(map[string][]int)
+	for i, row := range data {
+		if len(row) < index+1 || row[index] == nil {
+			continue // Rows shorter than the column and padded rows (see PadRows) have an empty cell in it
+		}
+		value := fmt.Sprintf("%v", row[index])
+		if value == "" || (options.trimSpace && strings.TrimSpace(value) == "") {
+			continue
+		}
+
+		key := duplicateKey(value, options)
+		if _, ok := first[key]; !ok {
+			first[key] = value
+		}
+		rows[key] = append(rows[key], i+1)
+	}
+
+	duplicates := make(map[string][]int)
+	for key, rowNumbers := range rows {
+		if len(rowNumbers) > 1 {
+			duplicates[first[key]] = rowNumbers
+		}
+	}
+	return duplicates
+}
+
+// duplicateKey normalizes a cell value, so the values that match each other with the comparison options have the
+// same key.
+//
+// Parameters:
+//   - value: The string representation of the cell.
+//   - options: The comparison options.
+//
+// Returns:
+//   - The key of the value.
+func duplicateKey(value string, options *matchOptions) string {
+	if options.trimSpace {
+		value = strings.TrimSpace(value)
+	}
+	if options.numeric {
+		if number, err := parseMatchNumber(value, options.locale); err == nil {
+			return strconv.FormatFloat(number, 'g', -1, 64)
+		}
+	}
+	if options.ignoreCase {
+		value = strings.ToLower(value)
+	}
+	return value
+}
+
+// columnIndex converts a column letter (e.g., "A", "Z", "AA", "BX" ... "ZZZ") to its index (0-based).
+//
+// Parameters:
+//   - column: The column letter to convert.
+//
+// Returns:
+//   - The index of the column.
+func columnIndex(column string) int {
+	column = strings.ToUpper(column)
+	index := 0
+	for _, c := range column {
+		index = index*26 + int(c-'A') + 1
 	}
 	return index - 1
 }
 
+// columnLetter converts a 0-based column index to its column letter, the inverse of columnIndex.
+//
+// Parameters:
+//   - index: The 0-based index of the column (e.g., 0 for "A", 26 for "AA").
+//
+// Returns:
+//   - The column letter of the index.
+func columnLetter(index int) string {
+	letter := ""
+	for index >= 0 {
+		letter = string(rune('A'+index%26)) + letter
+		index = index/26 - 1
+	}
+	return letter
+}
+
+// padRows pads every row of data with nil values up to the given width. Rows that are already wider are left untouched.
+//
+// Parameters:
+//   - data: The 2D slice to pad.
+//   - width: The number of cells every row must have.
+//
+// Returns:
+//   - The padded data.
+func padRows(data [][]interface{}, width int) [][]interface{} {
+	for i, row := range data {
+		for len(row) < width {
+			row = append(row, nil)
+		}
+		data[i] = row
+	}
+	return data
+}
+
+// withTimeout applies the default timeout of the client to ctx when ctx has no deadline of its own, and attaches the
+// retry budget of the operation, if any (see startOperation), so the attempts of the call are drawn from it.
+//
+// Parameters:
+//   - ctx: The context of the API call.
+//
+// Returns:
+//   - The context to use for the API call and the function that releases its resources.
+func (gs *GoogleSheetsClient) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if gs.operation != nil {
+		return gs.operation.context(ctx, gs.defaultTimeout)
+	}
+
+	if _, ok := ctx.Deadline(); ok || gs.defaultTimeout <= 0 {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeout(ctx, gs.defaultTimeout)
+}
+
+// requireScope checks that the client was created with at least one of the given OAuth2 scopes.
+//
+// Parameters:
+//   - accepted: The scopes that allow the operation, the first one being the one suggested in the error.
+//
+// Returns:
+//   - A *MissingScopeError if the client has none of the scopes, nil otherwise.
+func (gs *GoogleSheetsClient) requireScope(accepted ...string) error {
+	for _, scope := range gs.scopes {
+		for _, a := range accepted {
+			if scope == a {
+				return nil
+			}
+		}
+	}
+
+	return &MissingScopeError{Scope: accepted[0]}
+}
+
 func validateClientFields(gs *GoogleSheetsClient) error {
 	if gs.spreadsheetID == "" {
 		return fmt.Errorf("spreadsheet ID not set")
 	}
 
+	err := gs.resolveSheetName()
+	if err != nil {
+		return err
+	}
+
 	if gs.sheetName == "" {
 		return fmt.Errorf("sheet name not set")
 	}
 
 	return nil
 }
+
+// resolveSheetName retrieves the title of the first sheet if the client uses the first sheet (see UseFirstSheet) and
+// has not retrieved it yet. The methods that use the sheet name before validating the client fields call it first.
+//
+// Returns:
+//   - An error if there was a problem retrieving the title of the first sheet, nil otherwise.
+func (gs *GoogleSheetsClient) resolveSheetName() error {
+	if !gs.firstSheet || gs.sheetName != "" || gs.spreadsheetID == "" {
+		return nil
+	}
+	return gs.resolveFirstSheet()
+}
+
+// resolveFirstSheet sets the sheet name of the GoogleSheetsClient struct to the title of the first sheet of the
+// spreadsheet, for clients that use the first sheet (see UseFirstSheet).
+//
+// Returns:
+//   - An error if the spreadsheet has no sheets or there was a problem retrieving them, nil otherwise.
+func (gs *GoogleSheetsClient) resolveFirstSheet() error {
+	sheetProperties, err := gs.listSheetProperties()
+	if err != nil {
+		return err
+	}
+
+	var first *sheets.SheetProperties
+	for _, properties := range sheetProperties {
+		if first == nil || properties.Index < first.Index {
+			first = properties
+		}
+	}
+	if first == nil {
+		return fmt.Errorf("the spreadsheet has no sheets")
+	}
+
+	gs.sheetName = first.Title
+	return nil
+}
+
+// forgetFirstSheet discards the resolved title of the first sheet after a call failed to find the sheet, so the
+// next call retrieves it again. It does nothing unless the client uses the first sheet (see UseFirstSheet).
+func (gs *GoogleSheetsClient) forgetFirstSheet() {
+	if gs.firstSheet {
+		gs.sheetName = ""
+	}
+}
@@ -1,21 +1,47 @@
 package gosheets
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
+
+	"google.golang.org/api/googleapi"
 )
 
 var client *GoogleSheetsClient
 
+// fake is the in-memory Google Sheets API the client talks to.
+var fake *fakeSheetsServer
+
+// testCredentials and testEndpoint are used to create clients with other options than the shared one.
+var testCredentials []byte
+var testEndpoint string
+
 func TestMain(m *testing.M) {
-	// Read JSON file
-	credentials, err := os.ReadFile("PATH/TO/CREDENTIALS.json")
+	// Start the fake Google Sheets API
+	fake = newFakeSheetsServer()
+	server := httptest.NewServer(fake)
+
+	testEndpoint = server.URL + "/"
+	credentials, err := fake.credentials(server.URL)
 	if err != nil {
-		panic("Error reading credentials file: " + err.Error())
+		panic("Error creating credentials: " + err.Error())
 	}
+	testCredentials = credentials
+
+	// Retry the transient errors of the fake at once, so the tests stay fast
+	retryInitialDelay = time.Millisecond
 
 	// Create a new Google Sheets client
-	client, err = NewGoogleSheetsClient(credentials)
+	client, err = NewGoogleSheetsClient(credentials, WithEndpoint(testEndpoint))
 	if err != nil {
 		panic("Error creating Google Sheets client: " + err.Error())
 	}
@@ -24,12 +50,14 @@ func TestMain(m *testing.M) {
 	code := m.Run()
 
 	// Exit with the code from the tests
+	server.Close()
 	os.Exit(code)
 }
 
 func resetClient() {
 	client.SetSpreadsheetID("SPREADSHEET_ID")
 	client.SetSheetName("Sheet1")
+	client.InvalidateCaches() // The fake data may have been reset since the last test
 }
 
 func TestNewGoogleSheetsClient(t *testing.T) {
@@ -58,6 +86,253 @@ func TestNewGoogleSheetsClient(t *testing.T) {
 	}
 }
 
+func TestServiceAccountEmail(t *testing.T) {
+	if got := client.ServiceAccountEmail(); got == "" {
+		t.Errorf("ServiceAccountEmail() = %q, want the client_email of the credentials", got)
+	}
+}
+
+func TestRequireScope(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name     string
+		scopes   []string
+		accepted []string
+		wantErr  bool
+	}{
+		{
+			name:     "Scope granted",
+			scopes:   []string{ScopeSheets},
+			accepted: writeScopes,
+			wantErr:  false,
+		},
+		{
+			name:     "Alternative scope granted",
+			scopes:   []string{ScopeSheetsReadOnly, ScopeDriveFile},
+			accepted: writeScopes,
+			wantErr:  false,
+		},
+		{
+			name:     "Read-only client",
+			scopes:   []string{ScopeSheetsReadOnly},
+			accepted: writeScopes,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gs := &GoogleSheetsClient{scopes: tt.scopes}
+
+			err := gs.requireScope(tt.accepted...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("requireScope() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrMissingScope) {
+				t.Errorf("requireScope() error = %v, want ErrMissingScope", err)
+			}
+		})
+	}
+}
+
+func TestPing(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name          string
+		spreadsheetID string
+		wantErr       bool
+	}{
+		{
+			name:          "Valid spreadsheet ID",
+			spreadsheetID: "SPREADSHEET_ID",
+			wantErr:       false,
+		},
+		{
+			name:          "Empty spreadsheet ID (token only)",
+			spreadsheetID: "",
+			wantErr:       false,
+		},
+		{
+			name:          "Invalid spreadsheet ID",
+			spreadsheetID: "123456789",
+			wantErr:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetClient()
+			client.SetSpreadsheetID(tt.spreadsheetID)
+
+			err := client.Ping(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Ping() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWithDefaultTimeout(t *testing.T) {
+	fake.delay = 50 * time.Millisecond
+	t.Cleanup(func() { fake.delay = 0 })
+
+	// Test cases
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		wantErr error
+	}{
+		{
+			name:    "Call slower than the timeout",
+			timeout: 10 * time.Millisecond,
+			wantErr: context.DeadlineExceeded,
+		},
+		{
+			name:    "Call faster than the timeout",
+			timeout: time.Second,
+			wantErr: nil,
+		},
+		{
+			name:    "No timeout",
+			timeout: 0,
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gs, err := NewGoogleSheetsClient(testCredentials, WithEndpoint(testEndpoint), WithDefaultTimeout(tt.timeout))
+			if err != nil {
+				t.Fatalf("NewGoogleSheetsClient() error = %v", err)
+			}
+			gs.SetSpreadsheetID("SPREADSHEET_ID")
+			gs.SetSheetName("Sheet1")
+
+			_, err = gs.ReadData("A1:B2")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ReadData() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// countingTransport records the paths and headers of the requests it sends through http.DefaultTransport.
+type countingTransport struct {
+	mu      sync.Mutex
+	paths   []string
+	headers []http.Header
+}
+
+func (c *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	c.paths = append(c.paths, r.URL.Path)
+	c.headers = append(c.headers, r.Header.Clone())
+	c.mu.Unlock()
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestWithHTTPClient(t *testing.T) {
+	transport := &countingTransport{}
+	gs, err := NewGoogleSheetsClient(testCredentials, WithEndpoint(testEndpoint), WithHTTPClient(&http.Client{Transport: transport}))
+	if err != nil {
+		t.Fatalf("NewGoogleSheetsClient() error = %v", err)
+	}
+	gs.SetSpreadsheetID("SPREADSHEET_ID")
+
+	err = gs.Ping(context.Background())
+	if err != nil {
+		t.Fatalf("Ping() error = %v", err)
+	}
+
+	// The token request and the spreadsheet request must both go through the transport
+	if len(transport.paths) != 2 {
+		t.Errorf("WithHTTPClient() transport sent %d requests (%v), want 2", len(transport.paths), transport.paths)
+	}
+}
+
+func TestWithQuotaProjectAndUserAgent(t *testing.T) {
+	transport := &countingTransport{}
+	gs, err := NewGoogleSheetsClient(testCredentials, WithEndpoint(testEndpoint), WithHTTPClient(&http.Client{Transport: transport}),
+		WithQuotaProject("billing-project"), WithUserAgent("exporter/1.0"))
+	if err != nil {
+		t.Fatalf("NewGoogleSheetsClient() error = %v", err)
+	}
+	gs.SetSpreadsheetID("SPREADSHEET_ID")
+
+	err = gs.Ping(context.Background())
+	if err != nil {
+		t.Fatalf("Ping() error = %v", err)
+	}
+
+	// The first request fetches the token, the last one is the API request
+	if len(transport.headers) != 2 {
+		t.Fatalf("transport sent %d requests, want 2", len(transport.headers))
+	}
+	header := transport.headers[1]
+	if got := header.Get("X-Goog-User-Project"); got != "billing-project" {
+		t.Errorf("X-Goog-User-Project = %q, want %q", got, "billing-project")
+	}
+	if got := header.Get("User-Agent"); !strings.HasSuffix(got, " exporter/1.0") {
+		t.Errorf("User-Agent = %q, want it to end with %q", got, " exporter/1.0")
+	}
+
+	// The quota project is named in the errors it causes
+	err = gs.apiError(&googleapi.Error{Code: http.StatusForbidden, Errors: []googleapi.ErrorItem{{Reason: "USER_PROJECT_DENIED"}}})
+	if !errors.Is(err, ErrQuotaProjectDenied) || !strings.Contains(err.Error(), "billing-project") {
+		t.Errorf("apiError() = %v, want ErrQuotaProjectDenied naming the quota project", err)
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	deadline := time.Now().Add(time.Hour)
+	withDeadline, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+
+	// Test cases
+	tests := []struct {
+		name         string
+		timeout      time.Duration
+		ctx          context.Context
+		wantDeadline bool
+	}{
+		{
+			name:         "Default timeout applied",
+			timeout:      time.Minute,
+			ctx:          context.Background(),
+			wantDeadline: true,
+		},
+		{
+			name:         "Caller deadline kept",
+			timeout:      time.Minute,
+			ctx:          withDeadline,
+			wantDeadline: true,
+		},
+		{
+			name:         "No default timeout",
+			timeout:      0,
+			ctx:          context.Background(),
+			wantDeadline: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gs := &GoogleSheetsClient{defaultTimeout: tt.timeout}
+
+			ctx, cancel := gs.withTimeout(tt.ctx)
+			defer cancel()
+
+			got, ok := ctx.Deadline()
+			if ok != tt.wantDeadline {
+				t.Errorf("withTimeout() has deadline = %v, want %v", ok, tt.wantDeadline)
+			}
+			if tt.ctx == withDeadline && !got.Equal(deadline) {
+				t.Errorf("withTimeout() deadline = %v, want %v", got, deadline)
+			}
+		})
+	}
+}
+
 func TestGetSheetID(t *testing.T) {
 	// Test cases
 	tests := []struct {
@@ -115,6 +390,140 @@ func TestGetSheetID(t *testing.T) {
 	}
 }
 
+func TestGetSheetIDCachedByPropertyLookup(t *testing.T) {
+	fake.reset()
+	t.Cleanup(fake.reset)
+	resetClient()
+
+	err := client.InsertRowsAfterPosition([][]interface{}{{"New1", "New2"}}, 1)
+	if err != nil {
+		t.Fatalf("InsertRowsAfterPosition() error = %v", err)
+	}
+
+	calls := fake.callCount()
+	sheetID, err := client.getSheetID()
+	if err != nil {
+		t.Fatalf("getSheetID() error = %v", err)
+	}
+	if sheetID != fake.sheet("Sheet1").properties.SheetId {
+		t.Errorf("getSheetID() = %d, want %d", sheetID, fake.sheet("Sheet1").properties.SheetId)
+	}
+	if fake.callCount() != calls {
+		t.Errorf("getSheetID() made %d API calls after the sheet properties were read, want 0", fake.callCount()-calls)
+	}
+}
+
+func TestGetSheetIDTransientErrors(t *testing.T) {
+	rateLimited := &fakeError{http.StatusTooManyRequests, "Quota exceeded for quota metric 'Read requests'."}
+	unavailable := &fakeError{http.StatusServiceUnavailable, "The service is currently unavailable."}
+
+	// Test cases
+	tests := []struct {
+		name      string
+		errs      []*fakeError
+		wantCalls int
+		wantErr   bool
+	}{
+		{
+			name:      "Recovered",
+			errs:      []*fakeError{unavailable, rateLimited},
+			wantCalls: 3,
+		},
+		{
+			name:      "Retries exhausted",
+			errs:      []*fakeError{unavailable, unavailable, unavailable, unavailable},
+			wantCalls: 1 + DefaultMaxRetries,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.reset()
+			t.Cleanup(fake.reset)
+			resetClient()
+
+			fake.mu.Lock()
+			fake.transientErrs = tt.errs
+			fake.mu.Unlock()
+
+			sheetID, err := client.getSheetID()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getSheetID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := fake.callCount(); got != tt.wantCalls {
+				t.Errorf("getSheetID() made %d API calls, want %d", got, tt.wantCalls)
+			}
+			if tt.wantErr {
+				// The failed lookup is not cached, so the next one reaches the API again
+				sheetID, err = client.getSheetID()
+				if err != nil {
+					t.Fatalf("getSheetID() after the errors error = %v", err)
+				}
+			}
+			if sheetID != fake.sheet("Sheet1").properties.SheetId {
+				t.Errorf("getSheetID() = %d, want %d", sheetID, fake.sheet("Sheet1").properties.SheetId)
+			}
+		})
+	}
+}
+
+func TestCanEdit(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name          string
+		spreadsheetID string
+		updateErr     *fakeError
+		want          bool
+		wantErr       bool
+	}{
+		{
+			name:          "Editable spreadsheet",
+			spreadsheetID: "SPREADSHEET_ID",
+			want:          true,
+			wantErr:       false,
+		},
+		{
+			name:          "View access only",
+			spreadsheetID: "SPREADSHEET_ID",
+			updateErr:     &fakeError{http.StatusForbidden, "The caller does not have permission"},
+			want:          false,
+			wantErr:       false,
+		},
+		{
+			name:          "API not enabled",
+			spreadsheetID: "SPREADSHEET_ID",
+			updateErr:     &fakeError{http.StatusForbidden, "Google Sheets API has not been used in project 123 before or it is disabled. SERVICE_DISABLED"},
+			want:          false,
+			wantErr:       true,
+		},
+		{
+			name:          "Empty spreadsheet ID",
+			spreadsheetID: "",
+			want:          false,
+			wantErr:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.reset()
+			t.Cleanup(fake.reset)
+			fake.batchUpdateErr = tt.updateErr
+			resetClient()
+			client.SetSpreadsheetID(tt.spreadsheetID)
+
+			got, err := client.CanEdit()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CanEdit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("CanEdit() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestReadData(t *testing.T) {
 	resetClient()
 
@@ -173,112 +582,1244 @@ func TestReadData(t *testing.T) {
 	}
 }
 
-func TestAppendData(t *testing.T) {
-	resetClient()
+func TestReadDataEmptyAndInvalidRanges(t *testing.T) {
+	fake.reset()
+	t.Cleanup(fake.reset)
 
 	// Test cases
 	tests := []struct {
-		name                  string
-		data                  [][]interface{}
-		range_                string
-		sheetName             string
-		spreadsheetID         string
-		validateSpreadsheetID bool
-		wantErr               bool
+		name      string
+		sheetName string
+		readRange string
+		wantErr   error
 	}{
 		{
-			name:      "Valid data",
-			data:      [][]interface{}{{"Value1", "Value2"}, {"Value3", "Value4"}},
-			range_:    "A1",
+			name:      "Empty range",
 			sheetName: "Sheet1",
-			wantErr:   false,
+			readRange: "D1:F10",
 		},
 		{
-			name:      "Valid data (empty)",
-			data:      [][]interface{}{},
-			range_:    "A1",
+			name:      "Range beyond the data",
 			sheetName: "Sheet1",
-			wantErr:   false,
+			readRange: "A100:B200",
 		},
 		{
-			name:      "Invalid range",
-			data:      [][]interface{}{{"Value1", "Value2"}, {"Value3", "Value4"}},
-			range_:    "",
+			name:      "Single empty cell",
 			sheetName: "Sheet1",
-			wantErr:   true,
+			readRange: "C1",
 		},
 		{
-			name:      "Empty sheet name",
-			data:      [][]interface{}{{"Value1", "Value2"}, {"Value3", "Value4"}},
-			range_:    "A1",
-			sheetName: "",
-			wantErr:   true,
+			name:      "Invalid syntax",
+			sheetName: "Sheet1",
+			readRange: "A1:1B",
+			wantErr:   ErrInvalidRange,
 		},
 		{
-			name:                  "Empty spreadsheet ID",
-			data:                  [][]interface{}{{"Value1", "Value2"}, {"Value3", "Value4"}},
-			range_:                "A1",
-			sheetName:             "Sheet1",
-			spreadsheetID:         "",
-			validateSpreadsheetID: true,
-			wantErr:               true,
+			name:      "Missing sheet",
+			sheetName: "Missing",
+			readRange: "A1",
+			wantErr:   ErrInvalidRange,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if tt.validateSpreadsheetID {
-				client.SetSpreadsheetID(tt.spreadsheetID)
-			}
-
+			resetClient()
 			client.SetSheetName(tt.sheetName)
 
-			err := client.AppendData(tt.data, tt.range_)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("AppendData() error = %v, wantErr %v", err, tt.wantErr)
+			data, err := client.ReadData(tt.readRange)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ReadData() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if data == nil || len(data) != 0 {
+				t.Errorf("ReadData() = %#v, want an empty non-nil slice", data)
 			}
 		})
 	}
 }
 
-func TestInsertRowsAfterPosition(t *testing.T) {
-	resetClient()
+func TestReadUsedRange(t *testing.T) {
+	t.Cleanup(fake.reset)
 
 	// Test cases
 	tests := []struct {
-		name                  string
-		data                  [][]interface{}
-		position              int
-		sheetName             string
-		spreadsheetID         string
-		validateSpreadsheetID bool
-		wantErr               bool
+		name   string
+		values [][]interface{}
+		want   [][]interface{}
 	}{
 		{
-			name:      "Valid position 3",
-			data:      [][]interface{}{{"111", "222"}, {"333", "444"}, {"555", "666"}},
-			position:  3,
-			sheetName: "Sheet1",
-			wantErr:   false,
+			name:   "Ragged rows padded to the widest one",
+			values: [][]interface{}{{"ID", "Name"}, {"1"}, nil, {"3", "Carol", "note"}},
+			want:   [][]interface{}{{"ID", "Name", nil}, {"1", nil, nil}, {nil, nil, nil}, {"3", "Carol", "note"}},
 		},
 		{
-			name:      "Empty sheet name",
-			data:      [][]interface{}{{"111", "222"}, {"333", "444"}, {"555", "666"}},
-			position:  3,
-			sheetName: "",
-			wantErr:   true,
+			name:   "Data away from A1 keeps the cells before it",
+			values: [][]interface{}{nil, {nil, "B2"}},
+			want:   [][]interface{}{{nil, nil}, {"", "B2"}}, // The API reads the empty cells before a value as ""
 		},
 		{
-			name:                  "Empty spreadsheet ID",
-			data:                  [][]interface{}{{"111", "222"}, {"333", "444"}, {"555", "666"}},
-			position:              3,
-			sheetName:             "Sheet1",
-			spreadsheetID:         "",
-			validateSpreadsheetID: true,
-			wantErr:               true,
+			name:   "Empty sheet",
+			values: nil,
+			want:   [][]interface{}{},
 		},
-		{
-			name:      "Invalid position 0",
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.reset()
+			fake.seedSheet("Used", tt.values)
+			resetClient()
+			client.SetSheetName("Used")
+
+			got, err := client.ReadUsedRange()
+			if err != nil {
+				t.Fatalf("ReadUsedRange() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadUsedRange() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadLastRows(t *testing.T) {
+	t.Cleanup(fake.reset)
+
+	log := [][]interface{}{{"Time", "Event"}, {"09:00", "start"}, {"09:05", "load"}, {"09:10", "save"}, {"09:15", "stop"}}
+
+	// Test cases
+	tests := []struct {
+		name        string
+		values      [][]interface{}
+		columnRange string
+		n           int
+		want        [][]interface{}
+		wantErr     bool
+	}{
+		{
+			name:        "Last rows",
+			values:      log,
+			columnRange: "A:B",
+			n:           2,
+			want:        [][]interface{}{{"09:10", "save"}, {"09:15", "stop"}},
+		},
+		{
+			name:        "Fewer data rows than requested",
+			values:      log[:3],
+			columnRange: "A:B",
+			n:           10,
+			want:        [][]interface{}{{"09:00", "start"}, {"09:05", "load"}},
+		},
+		{
+			name:        "Last row of a single column",
+			values:      log,
+			columnRange: "B:B",
+			n:           1,
+			want:        [][]interface{}{{"stop"}},
+		},
+		{
+			name:        "Header row only",
+			values:      log[:1],
+			columnRange: "A:B",
+			n:           3,
+			want:        [][]interface{}{},
+		},
+		{
+			name:        "Range with row numbers",
+			values:      log,
+			columnRange: "A1:B10",
+			n:           2,
+			wantErr:     true,
+		},
+		{
+			name:        "Zero rows",
+			values:      log,
+			columnRange: "A:B",
+			n:           0,
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.reset()
+			fake.seedSheet("Log", tt.values)
+			resetClient()
+			client.SetSheetName("Log")
+
+			got, err := client.ReadLastRows(tt.columnRange, tt.n)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadLastRows() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadLastRows() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadColumnTyped(t *testing.T) {
+	t.Cleanup(fake.reset)
+
+	fake.reset()
+	fake.seedSheet("Stock", [][]interface{}{
+		{"Item", "Qty", "Price", "Note"},
+		{"Pen", 2.0, "1,234.50", "n/a"},
+		{"Ink", "7", 3.25, nil},
+		{"Pad", 12.0, 0.5, "spare"},
+		{nil, nil, nil, nil},
+	})
+	resetClient()
+	client.SetSheetName("Stock")
+
+	// Test cases
+	tests := []struct {
+		name      string
+		read      func() (interface{}, error)
+		want      interface{}
+		wantErr   bool
+		wantItems []string
+	}{
+		{
+			name: "Strings of a whole column",
+			read: func() (interface{}, error) { return client.ReadColumnStrings("D") },
+			want: []string{"Note", "n/a", "", "spare"},
+		},
+		{
+			name: "Strings below the header",
+			read: func() (interface{}, error) { return client.ReadColumnStrings("A2:A") },
+			want: []string{"Pen", "Ink", "Pad"},
+		},
+		{
+			name: "Strings of an empty column",
+			read: func() (interface{}, error) { return client.ReadColumnStrings("F") },
+			want: []string{},
+		},
+		{
+			name: "Floats with thousands separators",
+			read: func() (interface{}, error) { return client.ReadColumnFloats("C2:C") },
+			want: []float64{1234.5, 3.25, 0.5},
+		},
+		{
+			name: "Ints",
+			read: func() (interface{}, error) { return client.ReadColumnInts("B2:B") },
+			want: []int{2, 7, 12},
+		},
+		{
+			name:      "Ints with fractions",
+			read:      func() (interface{}, error) { return client.ReadColumnInts("C2:C") },
+			wantErr:   true,
+			wantItems: []string{"C2", "C3", "C4"},
+		},
+		{
+			name:      "Floats with text and empty cells",
+			read:      func() (interface{}, error) { return client.ReadColumnFloats("D") },
+			wantErr:   true,
+			wantItems: []string{"D1", "D2", "D3", "D4"},
+		},
+		{
+			name:    "Several columns",
+			read:    func() (interface{}, error) { return client.ReadColumnStrings("A:B") },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.read()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("read error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantItems != nil {
+				var multiErr *MultiError
+				if !errors.As(err, &multiErr) || !reflect.DeepEqual(multiErr.Items(), tt.wantItems) {
+					t.Errorf("read error = %v, want failed items %v", err, tt.wantItems)
+				}
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("read = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadDataPadded(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name      string
+		sheetName string
+		readRange string
+		wantWidth int
+		wantErr   bool
+	}{
+		{
+			name:      "Bounded read range",
+			sheetName: "Sheet1",
+			readRange: "A1:C4",
+			wantWidth: 3,
+			wantErr:   false,
+		},
+		{
+			name:      "Invalid read range",
+			sheetName: "Sheet1",
+			readRange: "A0",
+			wantErr:   true,
+		},
+		{
+			name:      "Non-existent sheet",
+			sheetName: "Sheet2",
+			readRange: "A1:C4",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetClient()
+			client.SetSheetName(tt.sheetName)
+
+			data, err := client.ReadDataPadded(tt.readRange)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadDataPadded() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, row := range data {
+				if len(row) != tt.wantWidth {
+					t.Errorf("ReadDataPadded() row %v has %d cells, want %d", row, len(row), tt.wantWidth)
+				}
+			}
+		})
+	}
+}
+
+func TestReadVisibleData(t *testing.T) {
+	fake.reset()
+	t.Cleanup(fake.reset)
+	fake.seedSheet("Orders", [][]interface{}{
+		{"ID", "Status"},
+		{"1", "open"},
+		{"2", "closed"},
+		{"3", "open"},
+		{"4", "closed"},
+	})
+	fake.hideRows("Orders", false, 2)
+	fake.hideRows("Orders", true, 4)
+
+	// Test cases
+	tests := []struct {
+		name           string
+		readRange      string
+		wantData       [][]interface{}
+		wantRowNumbers []int64
+		wantErr        bool
+	}{
+		{
+			name:           "Whole columns",
+			readRange:      "A:B",
+			wantData:       [][]interface{}{{"ID", "Status"}, {"1", "open"}, {"3", "open"}},
+			wantRowNumbers: []int64{1, 2, 4},
+		},
+		{
+			name:           "Range starting below the header",
+			readRange:      "A3:B5",
+			wantData:       [][]interface{}{{"3", "open"}},
+			wantRowNumbers: []int64{4},
+		},
+		{
+			name:      "Invalid range",
+			readRange: "B2:A1",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetClient()
+			client.SetSheetName("Orders")
+
+			data, rowNumbers, err := client.ReadVisibleDataWithRowNumbers(tt.readRange)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadVisibleDataWithRowNumbers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(data, tt.wantData) {
+				t.Errorf("ReadVisibleDataWithRowNumbers() data = %v, want %v", data, tt.wantData)
+			}
+			if !reflect.DeepEqual(rowNumbers, tt.wantRowNumbers) {
+				t.Errorf("ReadVisibleDataWithRowNumbers() row numbers = %v, want %v", rowNumbers, tt.wantRowNumbers)
+			}
+		})
+	}
+}
+
+func TestReadCells(t *testing.T) {
+	fake.reset()
+	t.Cleanup(fake.reset)
+	// A sheet name that needs quotes in A1 notation
+	fake.seedSheet("Form (2024)", [][]interface{}{
+		{"Name", "Alice"},
+		{},
+		{"Age", "", "", "42"},
+	})
+
+	// Test cases
+	tests := []struct {
+		name      string
+		cells     []string
+		want      map[string]interface{}
+		wantCalls int
+		wantErr   bool
+	}{
+		{
+			name:      "Scattered cells",
+			cells:     []string{"B1", "D3", "A3"},
+			want:      map[string]interface{}{"B1": "Alice", "D3": "42", "A3": "Age"},
+			wantCalls: 1,
+		},
+		{
+			name:      "Empty cells",
+			cells:     []string{"B2", "Z100"},
+			want:      map[string]interface{}{"B2": nil, "Z100": nil},
+			wantCalls: 1,
+		},
+		{
+			name:      "No cells",
+			cells:     nil,
+			want:      map[string]interface{}{},
+			wantCalls: 0,
+		},
+		{
+			name:    "Range instead of a cell",
+			cells:   []string{"A1:B2"},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid reference",
+			cells:   []string{"A0"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetClient()
+			client.SetSheetName("Form (2024)")
+
+			calls := fake.callCount()
+			got, err := client.ReadCells(tt.cells)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadCells() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadCells() = %v, want %v", got, tt.want)
+			}
+			if calls := fake.callCount() - calls; calls != tt.wantCalls {
+				t.Errorf("ReadCells() made %d API calls, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestReadAndWriteOptions(t *testing.T) {
+	fake.reset()
+	t.Cleanup(fake.reset)
+	fake.seedSheet("Prices", [][]interface{}{{"Item", "Price", "Paid"}, {"Pen", 1.5, true}})
+
+	resetClient()
+	client.SetSheetName("Prices")
+
+	// Test cases
+	tests := []struct {
+		name string
+		opts []ReadOption
+		want [][]interface{}
+	}{
+		{
+			name: "Formatted by default",
+			want: [][]interface{}{{"Item", "Price", "Paid"}, {"Pen", "1.5", "TRUE"}},
+		},
+		{
+			name: "Unformatted",
+			opts: []ReadOption{Unformatted()},
+			want: [][]interface{}{{"Item", "Price", "Paid"}, {"Pen", 1.5, true}},
+		},
+		{
+			name: "Unformatted and padded",
+			opts: []ReadOption{Unformatted(), PadRows(4)},
+			want: [][]interface{}{{"Item", "Price", "Paid", nil}, {"Pen", 1.5, true, nil}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.ReadData("A1:D2", tt.opts...)
+			if err != nil {
+				t.Fatalf("ReadData() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadData() = %v, want %v", got, tt.want)
+			}
+
+			chunked, err := client.ReadDataChunked(context.Background(), "A1:D2", 1, tt.opts...)
+			if err != nil {
+				t.Fatalf("ReadDataChunked() error = %v", err)
+			}
+			if !reflect.DeepEqual(chunked, tt.want) {
+				t.Errorf("ReadDataChunked() = %v, want %v", chunked, tt.want)
+			}
+		})
+	}
+
+	t.Run("Other read methods", func(t *testing.T) {
+		row := []interface{}{"Pen", 1.5, true}
+
+		cells, err := client.ReadCells([]string{"B2", "C2"}, Unformatted())
+		if want := map[string]interface{}{"B2": 1.5, "C2": true}; err != nil || !reflect.DeepEqual(cells, want) {
+			t.Errorf("ReadCells() = %v, %v, want %v", cells, err, want)
+		}
+
+		_, rows, err := client.ReadWithHeader("A1:C2", Unformatted())
+		if want := [][]interface{}{row}; err != nil || !reflect.DeepEqual(rows, want) {
+			t.Errorf("ReadWithHeader() rows = %v, %v, want %v", rows, err, want)
+		}
+
+		last, err := client.ReadLastRows("A:C", 1, Unformatted())
+		if want := [][]interface{}{row}; err != nil || !reflect.DeepEqual(last, want) {
+			t.Errorf("ReadLastRows() = %v, %v, want %v", last, err, want)
+		}
+
+		used, err := client.ReadUsedRange(Unformatted(), PadRows(4))
+		if want := [][]interface{}{{"Item", "Price", "Paid", nil}, {"Pen", 1.5, true, nil}}; err != nil || !reflect.DeepEqual(used, want) {
+			t.Errorf("ReadUsedRange() = %v, %v, want %v", used, err, want)
+		}
+
+		padded, err := client.ReadDataPadded("A2:D2", Unformatted())
+		if want := [][]interface{}{{"Pen", 1.5, true, nil}}; err != nil || !reflect.DeepEqual(padded, want) {
+			t.Errorf("ReadDataPadded() = %v, %v, want %v", padded, err, want)
+		}
+	})
+
+	t.Run("Append options", func(t *testing.T) {
+		err := client.AppendData([][]interface{}{{"Ink", "=1+1", false}}, "A1", UserEntered(), InsertRows())
+		if err != nil {
+			t.Fatalf("AppendData() error = %v", err)
+		}
+		got, err := client.ReadData("A3:C3", Formulas())
+		if err != nil {
+			t.Fatalf("ReadData() error = %v", err)
+		}
+		if want := [][]interface{}{{"Ink", "=1+1", false}}; !reflect.DeepEqual(got, want) {
+			t.Errorf("appended row = %v, want %v", got, want)
+		}
+	})
+}
+
+func TestReadDataChunked(t *testing.T) {
+	fake.reset()
+	t.Cleanup(fake.reset)
+	fake.seedSheet("Events", [][]interface{}{
+		{"id", "kind"},
+		{"1", "open"},
+		{},
+		{"3", "close"},
+		{"4", "open"},
+	})
+
+	resetClient()
+	client.SetSheetName("Events")
+
+	all := [][]interface{}{{"id", "kind"}, {"1", "open"}, nil, {"3", "close"}, {"4", "open"}}
+
+	// Test cases
+	tests := []struct {
+		name      string
+		readRange string
+		chunkSize int
+		want      [][]interface{}
+		wantErr   bool
+	}{
+		{
+			name:      "Pages across an empty row",
+			readRange: "A1:B5",
+			chunkSize: 2,
+			want:      all,
+		},
+		{
+			name:      "Range without an end row",
+			readRange: "A:B",
+			chunkSize: 400,
+			want:      all,
+		},
+		{
+			name:      "Page past the data",
+			readRange: "B2:B10",
+			chunkSize: 3,
+			want:      [][]interface{}{{"open"}, nil, {"close"}, {"open"}},
+		},
+		{
+			name:      "Negative chunk size",
+			readRange: "A1:B5",
+			chunkSize: -1,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.ReadDataChunked(context.Background(), tt.readRange, tt.chunkSize)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadDataChunked() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadDataChunked() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("Partial read on deadline", func(t *testing.T) {
+		fake.delay = 100 * time.Millisecond
+		t.Cleanup(func() { fake.delay = 0 })
+
+		// The third page starts after 200ms and cannot finish before the deadline
+		ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
+		defer cancel()
+
+		got, err := client.ReadDataChunked(ctx, "A1:B6", 2)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("ReadDataChunked() error = %v, want %v", err, context.DeadlineExceeded)
+		}
+		if want := all[:4]; !reflect.DeepEqual(got, want) {
+			t.Errorf("ReadDataChunked() = %v, want %v", got, want)
+		}
+	})
+}
+
+func TestReadDataEventual(t *testing.T) {
+	fake.reset()
+	t.Cleanup(fake.reset)
+	fake.seedSheet("Pending", [][]interface{}{})
+
+	// Test cases
+	tests := []struct {
+		name           string
+		sheetName      string
+		expectNonEmpty bool
+		writeAfter     time.Duration
+		wantRows       int
+		wantErr        bool
+	}{
+		{
+			name:           "Data already present",
+			sheetName:      "Sheet1",
+			expectNonEmpty: true,
+			wantRows:       2,
+		},
+		{
+			name:           "Data never appears",
+			sheetName:      "Pending",
+			expectNonEmpty: true,
+			wantRows:       0,
+		},
+		{
+			name:      "Empty range read once",
+			sheetName: "Pending",
+			wantRows:  0,
+		},
+		{
+			name:           "Data appears while retrying",
+			sheetName:      "Pending",
+			expectNonEmpty: true,
+			writeAfter:     50 * time.Millisecond,
+			wantRows:       1,
+		},
+		{
+			name:           "Non-existent sheet",
+			sheetName:      "Sheet2",
+			expectNonEmpty: true,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetClient()
+			client.SetSheetName(tt.sheetName)
+
+			if tt.writeAfter > 0 {
+				timer := time.AfterFunc(tt.writeAfter, func() {
+					fake.mu.Lock()
+					defer fake.mu.Unlock()
+					fake.spreadsheets["SPREADSHEET_ID"].sheetByTitle(tt.sheetName).values = [][]interface{}{{"Written"}}
+				})
+				t.Cleanup(func() { timer.Stop() })
+			}
+
+			data, err := client.ReadDataEventual("A:B", tt.expectNonEmpty, 500*time.Millisecond)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadDataEventual() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(data) != tt.wantRows {
+				t.Errorf("ReadDataEventual() = %v, want %d rows", data, tt.wantRows)
+			}
+		})
+	}
+}
+
+func TestColumnEnds(t *testing.T) {
+	fake.reset()
+	t.Cleanup(fake.reset)
+	fake.seedSheet("Metrics", [][]interface{}{
+		{"Visits", "Sales", ""},
+		{10, 3},
+		{"", 4},
+		{12},
+		{13},
+	})
+
+	// Test cases
+	tests := []struct {
+		name          string
+		column        string
+		startRow      int64
+		wantLast      int64
+		wantFirstFree int64
+		wantErr       bool
+	}{
+		{
+			name:          "Column with an interior gap",
+			column:        "A",
+			startRow:      1,
+			wantLast:      5,
+			wantFirstFree: 3,
+		},
+		{
+			name:          "Search started after the gap",
+			column:        "A",
+			startRow:      4,
+			wantLast:      5,
+			wantFirstFree: 6,
+		},
+		{
+			name:          "Column shorter than the others",
+			column:        "B",
+			startRow:      1,
+			wantLast:      3,
+			wantFirstFree: 4,
+		},
+		{
+			name:          "Empty column",
+			column:        "C",
+			startRow:      1,
+			wantLast:      0,
+			wantFirstFree: 1,
+		},
+		{
+			name:     "Invalid column",
+			column:   "A1",
+			startRow: 1,
+			wantErr:  true,
+		},
+		{
+			name:     "Invalid start row",
+			column:   "A",
+			startRow: 0,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetClient()
+			client.SetSheetName("Metrics")
+
+			firstFree, err := client.FirstEmptyRowAfter(tt.column, tt.startRow)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FirstEmptyRowAfter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if firstFree != tt.wantFirstFree {
+				t.Errorf("FirstEmptyRowAfter() = %v, want %v", firstFree, tt.wantFirstFree)
+			}
+
+			last, err := client.LastNonEmptyRow(tt.column)
+			if err != nil {
+				t.Fatalf("LastNonEmptyRow() error = %v", err)
+			}
+			if last != tt.wantLast {
+				t.Errorf("LastNonEmptyRow() = %v, want %v", last, tt.wantLast)
+			}
+		})
+	}
+}
+
+func TestAppendData(t *testing.T) {
+	resetClient()
+
+	// Test cases
+	tests := []struct {
+		name                  string
+		data                  [][]interface{}
+		range_                string
+		sheetName             string
+		spreadsheetID         string
+		validateSpreadsheetID bool
+		wantErr               bool
+	}{
+		{
+			name:      "Valid data",
+			data:      [][]interface{}{{"Value1", "Value2"}, {"Value3", "Value4"}},
+			range_:    "A1",
+			sheetName: "Sheet1",
+			wantErr:   false,
+		},
+		{
+			name:      "Valid data (empty)",
+			data:      [][]interface{}{},
+			range_:    "A1",
+			sheetName: "Sheet1",
+			wantErr:   false,
+		},
+		{
+			name:      "Invalid range",
+			data:      [][]interface{}{{"Value1", "Value2"}, {"Value3", "Value4"}},
+			range_:    "",
+			sheetName: "Sheet1",
+			wantErr:   true,
+		},
+		{
+			name:      "Empty sheet name",
+			data:      [][]interface{}{{"Value1", "Value2"}, {"Value3", "Value4"}},
+			range_:    "A1",
+			sheetName: "",
+			wantErr:   true,
+		},
+		{
+			name:                  "Empty spreadsheet ID",
+			data:                  [][]interface{}{{"Value1", "Value2"}, {"Value3", "Value4"}},
+			range_:                "A1",
+			sheetName:             "Sheet1",
+			spreadsheetID:         "",
+			validateSpreadsheetID: true,
+			wantErr:               true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.validateSpreadsheetID {
+				client.SetSpreadsheetID(tt.spreadsheetID)
+			}
+
+			client.SetSheetName(tt.sheetName)
+
+			err := client.AppendData(tt.data, tt.range_)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AppendData() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAppendDataChunked(t *testing.T) {
+	fake.reset()
+	t.Cleanup(fake.reset)
+
+	data := make([][]interface{}, 25)
+	for i := range data {
+		data[i] = []interface{}{fmt.Sprintf("Row%d", i), float64(i)}
+	}
+
+	// Test cases
+	tests := []struct {
+		name         string
+		data         [][]interface{}
+		chunkSize    int
+		sheetName    string
+		wantRows     int
+		wantRowIndex int
+		wantErr      bool
+	}{
+		{
+			name:      "Several chunks",
+			data:      data,
+			chunkSize: 10,
+			sheetName: "Sheet1",
+			wantRows:  2 + 25,
+			wantErr:   false,
+		},
+		{
+			name:      "Default chunk size",
+			data:      data,
+			chunkSize: 0,
+			sheetName: "Sheet1",
+			wantRows:  2 + 25 + 25,
+			wantErr:   false,
+		},
+		{
+			name:         "Failing chunk",
+			data:         data,
+			chunkSize:    10,
+			sheetName:    "",
+			wantRowIndex: 0,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetClient()
+			client.SetSheetName(tt.sheetName)
+
+			err := client.AppendDataChunked(tt.data, "A1", tt.chunkSize)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AppendDataChunked() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				var chunkErr *ChunkError
+				if !errors.As(err, &chunkErr) || chunkErr.RowIndex != tt.wantRowIndex {
+					t.Errorf("AppendDataChunked() error = %v, want a ChunkError at row index %d", err, tt.wantRowIndex)
+				}
+				return
+			}
+			if got := len(fake.sheet("Sheet1").values); got != tt.wantRows {
+				t.Errorf("AppendDataChunked() sheet has %d rows, want %d", got, tt.wantRows)
+			}
+		})
+	}
+}
+
+func TestAppendDataRows(t *testing.T) {
+	t.Cleanup(fake.reset)
+
+	// Test cases
+	tests := []struct {
+		name     string
+		data     [][]interface{}
+		range_   string
+		want     []int64
+		wantRows int
+	}{
+		{
+			name:     "Rows after the existing data",
+			data:     [][]interface{}{{"Value3", "Value4"}, {"Value5", "Value6"}},
+			range_:   "A1",
+			want:     []int64{3, 4},
+			wantRows: 4,
+		},
+		{
+			name:     "Empty row in the middle",
+			data:     [][]interface{}{{"Value3"}, {}, {"Value5"}},
+			range_:   "A1",
+			want:     []int64{3, 4, 5},
+			wantRows: 5,
+		},
+		{
+			name:     "Empty data",
+			data:     nil,
+			range_:   "A1",
+			want:     nil,
+			wantRows: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.reset()
+			resetClient()
+
+			got, err := client.AppendDataRows(tt.data, tt.range_)
+			if err != nil {
+				t.Fatalf("AppendDataRows() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AppendDataRows() = %v, want %v", got, tt.want)
+			}
+			if rows := len(fake.sheet("Sheet1").values); rows != tt.wantRows {
+				t.Errorf("sheet has %d rows, want %d", rows, tt.wantRows)
+			}
+		})
+	}
+}
+
+func TestAppendDataToSheet(t *testing.T) {
+	fake.reset()
+	t.Cleanup(fake.reset)
+	fake.seedSheet("Audit", [][]interface{}{{"Event"}})
+
+	// Test cases
+	tests := []struct {
+		name      string
+		sheetName string
+		data      [][]interface{}
+		wantRows  int
+		wantErr   bool
+	}{
+		{
+			name:      "Named sheet",
+			sheetName: "Audit",
+			data:      [][]interface{}{{"login"}},
+			wantRows:  2,
+			wantErr:   false,
+		},
+		{
+			name:      "Empty sheet name",
+			sheetName: "",
+			data:      [][]interface{}{{"login"}},
+			wantRows:  2,
+			wantErr:   true,
+		},
+		{
+			name:      "Non-existent sheet",
+			sheetName: "Sheet2",
+			data:      [][]interface{}{{"login"}},
+			wantRows:  2,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetClient()
+
+			err := client.AppendDataToSheet(tt.sheetName, tt.data, "A1")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AppendDataToSheet() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if client.sheetName != "Sheet1" {
+				t.Errorf("AppendDataToSheet() changed the sheet name of the client to %q", client.sheetName)
+			}
+			if rows := len(fake.sheet("Audit").values); rows != tt.wantRows {
+				t.Errorf("AppendDataToSheet() left %d rows in the named sheet, want %d", rows, tt.wantRows)
+			}
+		})
+	}
+}
+
+func TestAppendWithHeader(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name      string
+		headers   []string
+		data      [][]interface{}
+		range_    string
+		sheetName string
+		wantErr   bool
+	}{
+		{
+			name:      "Valid data",
+			headers:   []string{"Header1", "Header2"},
+			data:      [][]interface{}{{"Value1", "Value2"}},
+			range_:    "A1",
+			sheetName: "Sheet1",
+			wantErr:   false,
+		},
+		{
+			name:      "Empty sheet name",
+			headers:   []string{"Header1", "Header2"},
+			data:      [][]interface{}{{"Value1", "Value2"}},
+			range_:    "A1",
+			sheetName: "",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetClient()
+			client.SetSheetName(tt.sheetName)
+
+			err := client.AppendWithHeader(tt.headers, tt.data, tt.range_)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AppendWithHeader() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAppendWithTimestamp(t *testing.T) {
+	t.Cleanup(fake.reset)
+
+	// Test cases
+	tests := []struct {
+		name            string
+		data            [][]interface{}
+		range_          string
+		timestampColumn string
+		wantIndex       int
+		wantErr         bool
+	}{
+		{
+			name:            "Timestamp in the first column",
+			data:            [][]interface{}{{"Alice", "30"}, {"Bob", "25"}},
+			range_:          "A1",
+			timestampColumn: "A",
+			wantIndex:       0,
+			wantErr:         false,
+		},
+		{
+			name:            "Timestamp after a short row",
+			data:            [][]interface{}{{"Alice"}},
+			range_:          "A1",
+			timestampColumn: "D",
+			wantIndex:       3,
+			wantErr:         false,
+		},
+		{
+			name:            "Timestamp before the range",
+			data:            [][]interface{}{{"Alice"}},
+			range_:          "B1",
+			timestampColumn: "A",
+			wantErr:         true,
+		},
+		{
+			name:            "Invalid column",
+			data:            [][]interface{}{{"Alice"}},
+			range_:          "A1",
+			timestampColumn: "A1",
+			wantErr:         true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.reset()
+			fake.seedSheet("Log", nil)
+			resetClient()
+			client.SetSheetName("Log")
+			original := fmt.Sprint(tt.data)
+
+			err := client.AppendWithTimestamp(tt.data, tt.range_, tt.timestampColumn)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AppendWithTimestamp() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if fmt.Sprint(tt.data) != original {
+				t.Errorf("AppendWithTimestamp() modified the data: %v, want %v", tt.data, original)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			values := fake.sheet("Log").values
+			if len(values) != len(tt.data) {
+				t.Fatalf("AppendWithTimestamp() appended %d rows, want %d", len(values), len(tt.data))
+			}
+			for _, row := range values {
+				if len(row) <= tt.wantIndex {
+					t.Fatalf("AppendWithTimestamp() row %v has no timestamp at index %d", row, tt.wantIndex)
+				}
+				if _, err := time.Parse(time.RFC3339, fmt.Sprint(row[tt.wantIndex])); err != nil {
+					t.Errorf("AppendWithTimestamp() timestamp %v is not RFC 3339: %v", row[tt.wantIndex], err)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateColumns(t *testing.T) {
+	t.Cleanup(fake.reset)
+
+	// Test cases
+	tests := []struct {
+		name       string
+		writeRange string
+		columns    [][]interface{}
+		wantValues [][]interface{}
+		wantErr    bool
+	}{
+		{
+			name:       "Columns written top to bottom",
+			writeRange: "A3:B4",
+			columns:    [][]interface{}{{"Name3", "Name4"}, {"Amount3", "Amount4"}},
+			wantValues: [][]interface{}{{"Header1", "Header2"}, {"Value1", "Value2"}, {"Name3", "Amount3"}, {"Name4", "Amount4"}},
+		},
+		{
+			name:       "Single column over the header",
+			writeRange: "B1:B2",
+			columns:    [][]interface{}{{"Total", "42"}},
+			wantValues: [][]interface{}{{"Header1", "Total"}, {"Value1", "42"}},
+		},
+		{
+			name:       "Empty data is a no-op",
+			writeRange: "A1:B2",
+			columns:    nil,
+			wantValues: [][]interface{}{{"Header1", "Header2"}, {"Value1", "Value2"}},
+		},
+		{
+			name:       "Invalid range",
+			writeRange: "B2:A1",
+			columns:    [][]interface{}{{"Name3"}},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.reset()
+			resetClient()
+
+			err := client.UpdateColumns(tt.writeRange, tt.columns)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UpdateColumns() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if got := fake.sheet("Sheet1").values; !reflect.DeepEqual(got, tt.wantValues) {
+				t.Errorf("values = %v, want %v", got, tt.wantValues)
+			}
+		})
+	}
+}
+
+func TestInsertRowsAfterPosition(t *testing.T) {
+	resetClient()
+
+	// Test cases
+	tests := []struct {
+		name                  string
+		data                  [][]interface{}
+		position              int
+		sheetName             string
+		spreadsheetID         string
+		validateSpreadsheetID bool
+		wantErr               bool
+	}{
+		{
+			name:      "Valid position 3",
+			data:      [][]interface{}{{"111", "222"}, {"333", "444"}, {"555", "666"}},
+			position:  3,
+			sheetName: "Sheet1",
+			wantErr:   false,
+		},
+		{
+			name:      "Empty sheet name",
+			data:      [][]interface{}{{"111", "222"}, {"333", "444"}, {"555", "666"}},
+			position:  3,
+			sheetName: "",
+			wantErr:   true,
+		},
+		{
+			name:                  "Empty spreadsheet ID",
+			data:                  [][]interface{}{{"111", "222"}, {"333", "444"}, {"555", "666"}},
+			position:              3,
+			sheetName:             "Sheet1",
+			spreadsheetID:         "",
+			validateSpreadsheetID: true,
+			wantErr:               true,
+		},
+		{
+			name:      "Invalid position 0",
 			data:      [][]interface{}{{"111", "222"}, {"333", "444"}, {"555", "666"}},
 			position:  0,
 			sheetName: "Sheet1",
@@ -294,41 +1835,120 @@ func TestInsertRowsAfterPosition(t *testing.T) {
 
 			client.SetSheetName(tt.sheetName)
 
-			err := client.InsertRowsAfterPosition(tt.data, int64(tt.position))
+			err := client.InsertRowsAfterPosition(tt.data, int64(tt.position))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("InsertRowsAfterPosition() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestInsertRowsAtBeginning(t *testing.T) {
+	resetClient()
+
+	// Test cases
+	tests := []struct {
+		name                  string
+		data                  [][]interface{}
+		sheetName             string
+		spreadsheetID         string
+		validateSpreadsheetID bool
+		wantErr               bool
+	}{
+		{
+			name:      "Valid data",
+			sheetName: "Sheet1",
+			data:      [][]interface{}{{"555", "666"}, {"777", "888"}, {"999", "000"}},
+			wantErr:   false,
+		},
+		{
+			name:      "Empty sheet name",
+			data:      [][]interface{}{{"555", "666"}, {"777", "888"}, {"999", "000"}},
+			sheetName: "",
+			wantErr:   true,
+		},
+		{
+			name:                  "Empty spreadsheet ID",
+			data:                  [][]interface{}{{"555", "666"}, {"777", "888"}, {"999", "000"}},
+			sheetName:             "Sheet1",
+			spreadsheetID:         "",
+			validateSpreadsheetID: true,
+			wantErr:               true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.validateSpreadsheetID {
+				client.SetSpreadsheetID(tt.spreadsheetID)
+			}
+
+			client.SetSheetName(tt.sheetName)
+
+			err := client.InsertRowsAtBeginning(tt.data)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("InsertRowsAfterPosition() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("InsertRowsAtBeginnig() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
 
-func TestInsertRowsAtBeginning(t *testing.T) {
+func TestDeleteRow(t *testing.T) {
 	resetClient()
 
 	// Test cases
+	data, err := client.ReadData("A:B")
+	if err != nil {
+		t.Fatalf("Error reading data from Google Sheets: %v", err)
+	}
+
 	tests := []struct {
 		name                  string
 		data                  [][]interface{}
+		column                string
+		value                 string
 		sheetName             string
 		spreadsheetID         string
 		validateSpreadsheetID bool
 		wantErr               bool
 	}{
 		{
-			name:      "Valid data",
+			name:      "Existing value in given column",
+			data:      data,
+			column:    "A",
+			value:     "Value1", // Make sure this value exists in the data
 			sheetName: "Sheet1",
-			data:      [][]interface{}{{"555", "666"}, {"777", "888"}, {"999", "000"}},
 			wantErr:   false,
 		},
+		{
+			name:      "Invalid data (empty)",
+			data:      [][]interface{}{},
+			column:    "A",
+			value:     "Value1",
+			sheetName: "Sheet1",
+			wantErr:   true,
+		},
+		{
+			name:      "Non-existent value in given column",
+			data:      data,
+			column:    "A",
+			value:     "Value99",
+			sheetName: "Sheet1",
+			wantErr:   true,
+		},
 		{
 			name:      "Empty sheet name",
-			data:      [][]interface{}{{"555", "666"}, {"777", "888"}, {"999", "000"}},
+			data:      data,
+			column:    "A",
+			value:     "Value1",
 			sheetName: "",
 			wantErr:   true,
 		},
 		{
 			name:                  "Empty spreadsheet ID",
-			data:                  [][]interface{}{{"555", "666"}, {"777", "888"}, {"999", "000"}},
+			data:                  data,
+			column:                "A",
+			value:                 "Value1",
 			sheetName:             "Sheet1",
 			spreadsheetID:         "",
 			validateSpreadsheetID: true,
@@ -344,90 +1964,470 @@ func TestInsertRowsAtBeginning(t *testing.T) {
 
 			client.SetSheetName(tt.sheetName)
 
-			err := client.InsertRowsAtBeginning(tt.data)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("InsertRowsAtBeginnig() error = %v, wantErr %v", err, tt.wantErr)
+			err := client.DeleteRow(tt.data, tt.column, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DeleteRow() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClearRow(t *testing.T) {
+	fake.reset()
+	t.Cleanup(fake.reset)
+	fake.seedSheet("Orders", [][]interface{}{
+		{"ID", "Status"},
+		{"1", "open"},
+		{"2", "closed"},
+	})
+	fake.seedSheet("2024-06-01", [][]interface{}{{"ID"}, {"7"}})
+
+	// Test cases
+	tests := []struct {
+		name       string
+		sheet      string
+		row        int64
+		wantValues [][]interface{}
+		wantErr    bool
+	}{
+		{
+			name:       "Row in the middle keeps the rows below in place",
+			row:        2,
+			wantValues: [][]interface{}{{"ID", "Status"}, {}, {"2", "closed"}},
+		},
+		{
+			name:       "Sheet name that needs quotes",
+			sheet:      "2024-06-01",
+			row:        2,
+			wantValues: [][]interface{}{{"ID"}},
+		},
+		{
+			name:    "Row zero",
+			row:     0,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetClient()
+			client.SetSheetName("Orders")
+			if tt.sheet != "" {
+				client.SetSheetName(tt.sheet)
+			}
+
+			err := client.ClearRow(tt.row)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ClearRow() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			got, err := client.ReadData("A:B")
+			if err != nil {
+				t.Fatalf("ReadData() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.wantValues) {
+				t.Errorf("ReadData() = %v, want %v", got, tt.wantValues)
+			}
+		})
+	}
+}
+
+func TestReplaceData(t *testing.T) {
+	fake.reset()
+	t.Cleanup(fake.reset)
+	fake.seedSheet("Report", [][]interface{}{
+		{"Name", "Total"},
+		{"Alice", "10"},
+		{"Bob", "20", "note"},
+		{"Carol", "30"},
+	})
+
+	wide := make([]interface{}, 30)
+	for i := range wide {
+		wide[i] = "x"
+	}
+
+	// Test cases, run in order against the same sheet
+	tests := []struct {
+		name       string
+		data       [][]interface{}
+		keepHeader bool
+		want       [][]interface{}
+	}{
+		{
+			name:       "Keep the header",
+			data:       [][]interface{}{{"Dave", "40"}},
+			keepHeader: true,
+			want:       [][]interface{}{{"Name", "Total"}, {"Dave", "40"}},
+		},
+		{
+			name: "Replace the header too",
+			data: [][]interface{}{{"Item"}, {"Pen"}},
+			want: [][]interface{}{{"Item"}, {"Pen"}},
+		},
+		{
+			name: "Wider than the sheet",
+			data: [][]interface{}{wide},
+			want: [][]interface{}{wide},
+		},
+		{
+			name: "No data clears the sheet",
+			data: nil,
+			want: [][]interface{}{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetClient()
+			client.SetSheetName("Report")
+
+			err := client.ReplaceData(tt.data, tt.keepHeader)
+			if err != nil {
+				t.Fatalf("ReplaceData() error = %v", err)
+			}
+
+			got, err := client.ReadData("A:AD")
+			if err != nil {
+				t.Fatalf("ReadData() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadData() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeleteRowByValue(t *testing.T) {
+	t.Cleanup(fake.reset)
+	t.Cleanup(func() { client.ProtectHeaderRows(-1) })
+
+	// Test cases
+	tests := []struct {
+		name        string
+		column      string
+		value       string
+		opts        []WriteOption
+		protectRows int
+		wantValues  [][]interface{}
+		wantErr     error
+	}{
+		{
+			name:        "First matching row",
+			column:      "B",
+			value:       "open",
+			protectRows: -1,
+			wantValues:  [][]interface{}{{"ID", "Status"}, {"2", "closed"}, {"3", "open"}},
+		},
+		{
+			name:        "Loose match",
+			column:      "B",
+			value:       " CLOSED",
+			opts:        []WriteOption{WithMatch(IgnoreCase(), TrimSpace())},
+			protectRows: -1,
+			wantValues:  [][]interface{}{{"ID", "Status"}, {"1", "open"}, {"3", "open"}},
+		},
+		{
+			name:        "No match",
+			column:      "B",
+			value:       "missing",
+			protectRows: -1,
+			wantErr:     ErrRowNotFound,
+		},
+		{
+			name:        "Protected header row",
+			column:      "A",
+			value:       "ID",
+			protectRows: 1,
+			wantErr:     ErrHeaderProtected,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.reset()
+			fake.seedSheet("Orders", [][]interface{}{{"ID", "Status"}, {"1", "open"}, {"2", "closed"}, {"3", "open"}})
+			resetClient()
+			client.SetSheetName("Orders")
+			client.ProtectHeaderRows(tt.protectRows)
+
+			err := client.DeleteRowByValue(tt.column, tt.value, tt.opts...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DeleteRowByValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if got := fake.sheet("Orders").values; !reflect.DeepEqual(got, tt.wantValues) {
+				t.Errorf("values = %v, want %v", got, tt.wantValues)
+			}
+		})
+	}
+}
+
+func TestDeleteRowsWhere(t *testing.T) {
+	resetClient()
+
+	// Test cases
+	data := [][]interface{}{{"Header1", "Header2"}, {"111", "222"}, {"333", "444"}, {"111", "666"}}
+
+	tests := []struct {
+		name      string
+		data      [][]interface{}
+		predicate func(row []interface{}) bool
+		sheetName string
+		want      int
+		wantErr   bool
+	}{
+		{
+			name:      "Matching rows",
+			data:      data,
+			predicate: func(row []interface{}) bool { return len(row) > 0 && row[0] == "111" },
+			sheetName: "Sheet1",
+			want:      2,
+			wantErr:   false,
+		},
+		{
+			name:      "No matching rows",
+			data:      data,
+			predicate: func(row []interface{}) bool { return false },
+			sheetName: "Sheet1",
+			want:      0,
+			wantErr:   false,
+		},
+		{
+			name:      "Empty sheet name",
+			data:      data,
+			predicate: func(row []interface{}) bool { return true },
+			sheetName: "",
+			want:      0,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client.SetSheetName(tt.sheetName)
+
+			got, err := client.DeleteRowsWhere(tt.data, tt.predicate)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DeleteRowsWhere() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("DeleteRowsWhere() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeleteRowsWhereCoalesced(t *testing.T) {
+	fake.reset()
+	t.Cleanup(fake.reset)
+
+	maxRequests := maxDeleteRequests
+	maxDeleteRequests = 2
+	t.Cleanup(func() { maxDeleteRequests = maxRequests })
+
+	data := [][]interface{}{{"ID"}, {"x"}, {"x"}, {"x"}, {"4"}, {"x"}, {"6"}, {"x"}, {"x"}, {"9"}}
+	fake.seedSheet("Rows", data)
+	resetClient()
+	client.SetSheetName("Rows")
+
+	got, err := client.DeleteRowsWhere(data, func(row []interface{}) bool { return row[0] == "x" })
+	if err != nil {
+		t.Fatalf("DeleteRowsWhere() error = %v", err)
+	}
+	if got != 6 {
+		t.Errorf("DeleteRowsWhere() = %v, want 6", got)
+	}
+
+	want := [][]interface{}{{"ID"}, {"4"}, {"6"}, {"9"}}
+	if values := fake.sheet("Rows").values; !reflect.DeepEqual(values, want) {
+		t.Errorf("DeleteRowsWhere() left %v, want %v", values, want)
+	}
+
+	// The 3 runs of adjacent rows take 3 requests, sent in 2 batch updates after reading the sheet properties
+	if len(fake.requests) != 3 {
+		t.Errorf("DeleteRowsWhere() sent %d requests, want 3", len(fake.requests))
+	}
+	if fake.callCount() != 3 {
+		t.Errorf("DeleteRowsWhere() made %d API calls, want 3", fake.callCount())
+	}
+}
+
+func TestCoalesceRowNumbers(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name       string
+		rowNumbers []int64
+		want       [][2]int64
+	}{
+		{
+			name:       "Scattered rows",
+			rowNumbers: []int64{2, 5, 9},
+			want:       [][2]int64{{8, 9}, {4, 5}, {1, 2}},
+		},
+		{
+			name:       "Adjacent rows",
+			rowNumbers: []int64{2, 3, 4, 7, 8},
+			want:       [][2]int64{{6, 8}, {1, 4}},
+		},
+		{
+			name:       "Repeated rows",
+			rowNumbers: []int64{3, 3, 4},
+			want:       [][2]int64{{2, 4}},
+		},
+		{
+			name:       "No rows",
+			rowNumbers: nil,
+			want:       nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := coalesceRowNumbers(tt.rowNumbers); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("coalesceRowNumbers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProtectHeaderRows(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name        string
+		frozenRows  int64
+		protectRows int
+		operation   func() error
+		wantErr     error
+	}{
+		{
+			name:        "Delete frozen header row",
+			frozenRows:  1,
+			protectRows: -1,
+			operation:   func() error { return client.DeleteRow([][]interface{}{{"Header1"}}, "A", "Header1") },
+			wantErr:     ErrHeaderProtected,
+		},
+		{
+			name:        "Delete frozen header row with Force",
+			frozenRows:  1,
+			protectRows: -1,
+			operation:   func() error { return client.DeleteRow([][]interface{}{{"Header1"}}, "A", "Header1", Force()) },
+			wantErr:     nil,
+		},
+		{
+			name:        "Delete data row below the frozen header",
+			frozenRows:  1,
+			protectRows: -1,
+			operation:   func() error { return client.DeleteRow([][]interface{}{{"Header1"}, {"Value1"}}, "A", "Value1") },
+			wantErr:     nil,
+		},
+		{
+			name:        "Delete header row with protection disabled",
+			frozenRows:  1,
+			protectRows: 0,
+			operation:   func() error { return client.DeleteRow([][]interface{}{{"Header1"}}, "A", "Header1") },
+			wantErr:     nil,
+		},
+		{
+			name:        "Delete explicitly protected rows",
+			frozenRows:  0,
+			protectRows: 2,
+			operation: func() error {
+				_, err := client.DeleteRowsWhere([][]interface{}{{"Header1"}, {"Value1"}}, func(row []interface{}) bool { return row[0] == "Value1" })
+				return err
+			},
+			wantErr: ErrHeaderProtected,
+		},
+		{
+			name:        "Insert rows above the frozen header",
+			frozenRows:  1,
+			protectRows: -1,
+			operation:   func() error { return client.InsertRowsAfterPosition([][]interface{}{{"111", "222"}}, 0) },
+			wantErr:     ErrHeaderProtected,
+		},
+		{
+			name:        "Insert rows after the frozen header",
+			frozenRows:  1,
+			protectRows: -1,
+			operation:   func() error { return client.InsertRowsAtBeginning([][]interface{}{{"111", "222"}}) },
+			wantErr:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.reset()
+			fake.sheet("Sheet1").properties.GridProperties.FrozenRowCount = tt.frozenRows
+			resetClient()
+			client.ProtectHeaderRows(tt.protectRows)
+			t.Cleanup(func() {
+				client.ProtectHeaderRows(-1)
+				fake.reset()
+			})
+
+			err := tt.operation()
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
 			}
 		})
 	}
 }
 
-func TestDeleteRow(t *testing.T) {
-	resetClient()
-
+func TestEmptyData(t *testing.T) {
 	// Test cases
-	data, err := client.ReadData("A:B")
-	if err != nil {
-		t.Fatalf("Error reading data from Google Sheets: %v", err)
-	}
-
 	tests := []struct {
-		name                  string
-		data                  [][]interface{}
-		column                string
-		value                 string
-		sheetName             string
-		spreadsheetID         string
-		validateSpreadsheetID bool
-		wantErr               bool
+		name      string
+		operation func(data [][]interface{}) error
+		wantErr   error
 	}{
 		{
-			name:      "Existing value in given column",
-			data:      data,
-			column:    "A",
-			value:     "Value1", // Make sure this value exists in the data
-			sheetName: "Sheet1",
-			wantErr:   false,
+			name:      "AppendData",
+			operation: func(data [][]interface{}) error { return client.AppendData(data, "A1") },
+			wantErr:   nil,
 		},
 		{
-			name:      "Invalid data (empty)",
-			data:      [][]interface{}{},
-			column:    "A",
-			value:     "Value1",
-			sheetName: "Sheet1",
-			wantErr:   true,
+			name:      "AppendDataChunked",
+			operation: func(data [][]interface{}) error { return client.AppendDataChunked(data, "A1", 10) },
+			wantErr:   nil,
 		},
 		{
-			name:      "Non-existent value in given column",
-			data:      data,
-			column:    "A",
-			value:     "Value99",
-			sheetName: "Sheet1",
-			wantErr:   true,
+			name:      "InsertRowsAfterPosition",
+			operation: func(data [][]interface{}) error { return client.InsertRowsAfterPosition(data, 3) },
+			wantErr:   nil,
 		},
 		{
-			name:      "Empty sheet name",
-			data:      data,
-			column:    "A",
-			value:     "Value1",
-			sheetName: "",
-			wantErr:   true,
+			name:      "InsertRowsAtBeginning",
+			operation: func(data [][]interface{}) error { return client.InsertRowsAtBeginning(data) },
+			wantErr:   nil,
 		},
 		{
-			name:                  "Empty spreadsheet ID",
-			data:                  data,
-			column:                "A",
-			value:                 "Value1",
-			sheetName:             "Sheet1",
-			spreadsheetID:         "",
-			validateSpreadsheetID: true,
-			wantErr:               true,
+			name:      "DeleteRow",
+			operation: func(data [][]interface{}) error { return client.DeleteRow(data, "A", "Value1") },
+			wantErr:   ErrEmptyData,
 		},
 	}
 
 	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if tt.validateSpreadsheetID {
-				client.SetSpreadsheetID(tt.spreadsheetID)
-			}
+		for _, data := range [][][]interface{}{nil, {}} {
+			t.Run(fmt.Sprintf("%s with %#v", tt.name, data), func(t *testing.T) {
+				fake.reset()
+				resetClient()
 
-			client.SetSheetName(tt.sheetName)
-
-			err := client.DeleteRow(tt.data, tt.column, tt.value)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("DeleteRow() error = %v, wantErr %v", err, tt.wantErr)
-			}
-		})
+				err := tt.operation(data)
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("%s() error = %v, want %v", tt.name, err, tt.wantErr)
+				}
+				if calls := fake.callCount(); calls != 0 {
+					t.Errorf("%s() made %d API calls, want 0", tt.name, calls)
+				}
+			})
+		}
 	}
 }
 
@@ -466,6 +2466,43 @@ func TestDataToString(t *testing.T) {
 	}
 }
 
+func TestPadRows(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name  string
+		data  [][]interface{}
+		width int
+		want  [][]interface{}
+	}{
+		{
+			name:  "Ragged rows",
+			data:  [][]interface{}{{"Value1", "Value2"}, {"Value3"}, {}},
+			width: 3,
+			want:  [][]interface{}{{"Value1", "Value2", nil}, {"Value3", nil, nil}, {nil, nil, nil}},
+		},
+		{
+			name:  "Rows wider than the width",
+			data:  [][]interface{}{{"Value1", "Value2"}},
+			width: 1,
+			want:  [][]interface{}{{"Value1", "Value2"}},
+		},
+		{
+			name:  "Empty data",
+			data:  [][]interface{}{},
+			width: 2,
+			want:  [][]interface{}{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := padRows(tt.data, tt.width); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("padRows() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestFindRowNumber(t *testing.T) {
 	// Test cases
 	tests := []struct {
@@ -507,13 +2544,238 @@ func TestFindRowNumber(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := findRowNumber(tt.data, tt.column, tt.value); got != tt.want {
+			if got := FindRowNumber(tt.data, tt.column, tt.value); got != tt.want {
 				t.Errorf("FindRowNumber() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
+func TestCountInColumn(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name   string
+		data   [][]interface{}
+		column string
+		value  string
+		want   int
+	}{
+		{
+			name:   "No matches",
+			data:   [][]interface{}{{"Value1", "Value2"}, {"Value3", "Value4"}},
+			column: "A",
+			value:  "Value99",
+			want:   0,
+		},
+		{
+			name:   "One match",
+			data:   [][]interface{}{{"Value1", "Value2"}, {"Value3", "Value4"}},
+			column: "B",
+			value:  "Value4",
+			want:   1,
+		},
+		{
+			name:   "Multiple matches",
+			data:   [][]interface{}{{"Value1", "Value2"}, {"Value1", "Value4"}, {"Value1"}},
+			column: "A",
+			value:  "Value1",
+			want:   3,
+		},
+		{
+			name:   "Ragged rows",
+			data:   [][]interface{}{{"Value1", "Value2"}, {"Value3"}, {}, {"Value5", "Value2"}},
+			column: "B",
+			value:  "Value2",
+			want:   2,
+		},
+		{
+			name:   "Invalid data (empty)",
+			data:   [][]interface{}{},
+			column: "A",
+			value:  "Value1",
+			want:   0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CountInColumn(tt.data, tt.column, tt.value); got != tt.want {
+				t.Errorf("CountInColumn() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountRowsWhere(t *testing.T) {
+	data := [][]interface{}{{"Status", "Amount"}, {"done", 10}, {"todo"}, {}, {"DONE ", 5}}
+
+	// Test cases
+	tests := []struct {
+		name      string
+		data      [][]interface{}
+		predicate func(row []interface{}) bool
+		want      int
+	}{
+		{
+			name:      "Exact match",
+			data:      data,
+			predicate: MatchColumn("A", "done"),
+			want:      1,
+		},
+		{
+			name:      "Loose match",
+			data:      data,
+			predicate: MatchColumn("A", "done", IgnoreCase(), TrimSpace()),
+			want:      2,
+		},
+		{
+			name:      "Ragged and empty rows",
+			data:      data,
+			predicate: func(row []interface{}) bool { return len(row) < 2 },
+			want:      2,
+		},
+		{
+			name:      "Every row, header included",
+			data:      data,
+			predicate: func(row []interface{}) bool { return true },
+			want:      5,
+		},
+		{
+			name:      "No match",
+			data:      data,
+			predicate: MatchColumn("B", "missing"),
+			want:      0,
+		},
+		{
+			name:      "Empty data",
+			data:      nil,
+			predicate: func(row []interface{}) bool { return true },
+			want:      0,
+		},
+		{
+			name:      "Nil predicate",
+			data:      data,
+			predicate: nil,
+			want:      0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CountRowsWhere(tt.data, tt.predicate); got != tt.want {
+				t.Errorf("CountRowsWhere() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDistinctColumnValues(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name   string
+		data   [][]interface{}
+		column string
+		want   []string
+	}{
+		{
+			name:   "Duplicates and blanks",
+			data:   [][]interface{}{{"Status"}, {"done"}, {""}, {"todo"}, {"done"}, {}, {"blocked"}, {"todo"}},
+			column: "A",
+			want:   []string{"Status", "done", "todo", "blocked"},
+		},
+		{
+			name:   "Non-string values",
+			data:   [][]interface{}{{"Value1", 1}, {"Value2", 2.5}, {"Value3", 1}, {"Value4", true}},
+			column: "B",
+			want:   []string{"1", "2.5", "true"},
+		},
+		{
+			name:   "Padded rows",
+			data:   padRows([][]interface{}{{"a"}, {"b"}, {"c", "x"}}, 2),
+			column: "B",
+			want:   []string{"x"},
+		},
+		{
+			name:   "Invalid data (empty)",
+			data:   [][]interface{}{},
+			column: "A",
+			want:   []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DistinctColumnValues(tt.data, tt.column); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DistinctColumnValues() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindDuplicates(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name   string
+		data   [][]interface{}
+		column string
+		opts   []MatchOption
+		want   map[string][]int
+	}{
+		{
+			name:   "Duplicates and blanks",
+			data:   [][]interface{}{{"ID", "Name"}, {"A1", "x"}, {""}, {"B2"}, {"A1"}, {}, {"C3"}, {"B2"}, {"A1"}},
+			column: "A",
+			want:   map[string][]int{"A1": {2, 5, 9}, "B2": {4, 8}},
+		},
+		{
+			name:   "No duplicates",
+			data:   [][]interface{}{{"ID"}, {"1"}, {"2"}},
+			column: "A",
+			want:   map[string][]int{},
+		},
+		{
+			name:   "Exact comparison by default",
+			data:   [][]interface{}{{"alice"}, {"Alice"}, {" alice"}},
+			column: "A",
+			want:   map[string][]int{},
+		},
+		{
+			name:   "Comparison options",
+			data:   [][]interface{}{{"alice"}, {"Alice"}, {" alice"}, {"100"}, {"1e2"}},
+			column: "A",
+			opts:   []MatchOption{IgnoreCase(), TrimSpace(), NumericEqual()},
+			want:   map[string][]int{"alice": {1, 2, 3}, "100": {4, 5}},
+		},
+		{
+			name:   "Non-string values in another column",
+			data:   [][]interface{}{{"a", 1}, {"b", 2.5}, {"c", 1}},
+			column: "B",
+			want:   map[string][]int{"1": {1, 3}},
+		},
+		{
+			name:   "Padded rows",
+			data:   padRows([][]interface{}{{"a"}, {"b"}, {"c", "x"}}, 2),
+			column: "B",
+			want:   map[string][]int{},
+		},
+		{
+			name:   "Blank cells with TrimSpace",
+			data:   [][]interface{}{{" "}, {"  "}, {"x"}},
+			column: "A",
+			opts:   []MatchOption{TrimSpace()},
+			want:   map[string][]int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FindDuplicates(tt.data, tt.column, tt.opts...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindDuplicates() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestColumnIndex(t *testing.T) {
 	// Test cases
 	tests := []struct {
@@ -558,6 +2820,83 @@ func TestColumnIndex(t *testing.T) {
 			if got := columnIndex(tt.column); got != tt.want {
 				t.Errorf("ColumnIndex() = %v, want %v", got, tt.want)
 			}
+			if got := columnLetter(tt.want); got != tt.column {
+				t.Errorf("ColumnLetter() = %v, want %v", got, tt.column)
+			}
+		})
+	}
+}
+
+func TestValueInputOption(t *testing.T) {
+	t.Cleanup(fake.reset)
+	t.Cleanup(func() { client.valueInputOption = "" })
+
+	rows := [][]interface{}{{"Pen", "=1+1"}}
+
+	// Test cases
+	tests := []struct {
+		name          string
+		clientDefault string
+		write         func() error
+		want          []string
+		wantErr       bool
+	}{
+		{
+			name:  "RAW without a default",
+			write: func() error { return client.AppendData(rows, "A1") },
+			want:  []string{InputRaw},
+		},
+		{
+			name:          "Client default",
+			clientDefault: InputUserEntered,
+			write:         func() error { return client.AppendData(rows, "A1") },
+			want:          []string{InputUserEntered},
+		},
+		{
+			name:          "Per-call override of the client default",
+			clientDefault: InputUserEntered,
+			write:         func() error { return client.AppendData(rows, "A1", ValueInput(InputRaw)) },
+			want:          []string{InputRaw},
+		},
+		{
+			name:          "Client default for updates and appends of an upsert",
+			clientDefault: InputUserEntered,
+			write: func() error {
+				_, _, err := client.BulkUpsert("A", [][]interface{}{{"Value1", "x"}, {"New", "y"}}, 0)
+				return err
+			},
+			want: []string{InputUserEntered, InputUserEntered},
+		},
+		{
+			name:          "Client default for column updates",
+			clientDefault: InputUserEntered,
+			write:         func() error { return client.UpdateColumns("C1:C2", [][]interface{}{{"a", "b"}}) },
+			want:          []string{InputUserEntered},
+		},
+		{
+			name:    "Invalid per-call option",
+			write:   func() error { return client.AppendData(rows, "A1", ValueInput("PARSED")) },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.reset()
+			resetClient()
+			client.valueInputOption = tt.clientDefault
+
+			err := tt.write()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("write error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(fake.inputOptions, tt.want) {
+				t.Errorf("value input options = %v, want %v", fake.inputOptions, tt.want)
+			}
 		})
 	}
+
+	if _, err := NewGoogleSheetsClient(testCredentials, WithEndpoint(testEndpoint), WithValueInputOption("PARSED")); err == nil {
+		t.Errorf("NewGoogleSheetsClient() with an invalid value input option error = nil, want an error")
+	}
 }
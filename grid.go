@@ -0,0 +1,246 @@
+package gosheets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// Cell represents everything a cell holds: its value together with its formula, note, format, link and data
+// validation rule, as read by ReadGrid.
+//
+//   - The Value field is the value shown in the cell: a float64, string or bool, the error message for formulas
+//     that fail, or nil for empty cells. For formula cells it is the computed value.
+//   - The Formula field is the formula of the cell (e.g., "=SUM(A1:A3)"), empty if the cell has no formula.
+//   - The Note field is the note attached to the cell, empty if it has none.
+//   - The Format field is the format entered for the cell (number format, colors, text format, alignment, etc.),
+//     nil if not set.
+//   - The Hyperlink field is the link of the cell, empty if it has none.
+//   - The Validation field is the data validation rule of the cell, nil if it has none.
+type Cell struct {
+	Value      interface{}
+	Formula    string
+	Note       string
+	Format     *sheets.CellFormat
+	Hyperlink  string
+	Validation *sheets.DataValidationRule
+}
+
+// gridCellFields is the field mask of the cell data read into a Cell.
+const gridCellFields = "userEnteredValue(formulaValue),effectiveValue,note,userEnteredFormat,hyperlink,dataValidation"
+
+// ReadGrid reads the values, formulas, notes, formats, links and data validation rules of a range of the current set
+// sheet in the GoogleSheetsClient struct in a single request, e.g., to snapshot a range before a migration.
+//
+// Parameters:
+//   - range_: The range of cells to read (e.g., "A1:F20"), or an empty string to use the table range of the sheet
+//     (see SetTableRange).
+//
+// Returns:
+//   - A 2D slice of Cell values, indexed from the first cell of the range. Like ReadData, the trailing empty rows and
+//     the trailing empty cells of each row are omitted.
+//   - An error if there was a problem reading the range, nil otherwise.
+func (gs *GoogleSheetsClient) ReadGrid(range_ string) ([][]Cell, error) {
+	range_, err := gs.rangeOrTable(gs.sheetName, range_)
+	if err != nil {
+		return nil, err
+	}
+
+	gridData, err := gs.getGridData(range_, "rowData(values("+gridCellFields+"))")
+	if err != nil {
+		return nil, err
+	}
+
+	grid := make([][]Cell, len(gridData.RowData))
+	for i, rowData := range gridData.RowData {
+		row := make([]Cell, len(rowData.Values))
+		for j, cellData := range rowData.Values {
+			row[j] = Cell{
+				Value:      extendedValueToInterface(cellData.EffectiveValue),
+				Note:       cellData.Note,
+				Format:     cellData.UserEnteredFormat,
+				Hyperlink:  cellData.Hyperlink,
+				Validation: cellData.DataValidation,
+			}
+			if value := cellData.UserEnteredValue; value != nil && value.FormulaValue != nil {
+				row[j].Formula = *value.FormulaValue
+			}
+		}
+		grid[i] = row
+	}
+
+	return grid, nil
+}
+
+// WriteGrid writes a block of cells read by ReadGrid to the current set sheet in the GoogleSheetsClient struct,
+// starting at the given cell, e.g., to restore a snapshot or to copy a formatted range to another spreadsheet. Only
+// the attributes a Cell holds are written: a cell without a value or formula keeps its value, a cell without a note
+// keeps its note, and so on, so the attributes not captured in the snapshot are preserved. The links are written as
+// the link of the text of the cell, unless the cell has a formula (e.g., HYPERLINK) that creates them.
+//
+// It also writes blocks built by hand where each cell has its own value and format (e.g., the negative numbers in
+// red): consecutive rows whose cells all hold the same attributes are sent as a single UpdateCellsRequest, and the
+// whole block goes in a single batch update. The values are written as they are, without the parsing of
+// ValueInput(InputUserEntered), so a date needs a number with a date format rather than a date string.
+//
+// The performance tradeoff: every cell carries its whole format, so the request is several times larger than a
+// value-only write of the same block (e.g., UpdateColumns or AppendData) and takes longer for the API to apply.
+// Prefer a value-only write followed by a single RepeatCell format (e.g., SetAlignment) or conditional formatting
+// when the format does not vary from cell to cell, and keep the blocks written this way to a few thousand cells.
+//
+// Parameters:
+//   - startCell: The top-left cell of the block to write (e.g., "B2").
+//   - cells: A 2D slice of Cell values, each inner slice representing a row.
+//
+// Returns:
+//   - An error if there was a problem writing the cells, nil otherwise.
+func (gs *GoogleSheetsClient) WriteGrid(startCell string, cells [][]Cell) error {
+	start, err := parseA1Range(startCell)
+	if err != nil {
+		return err
+	}
+
+	err = gs.requireScope(writeScopes...)
+	if err != nil {
+		return err
+	}
+
+	sheetID, err := gs.getSheetID()
+	if err != nil {
+		return fmt.Errorf("unable to retrieve sheet ID: %w", err)
+	}
+
+	// The field mask applies to a whole UpdateCellsRequest, so each run of consecutive cells of a row with the same
+	// attributes set gets its own request. The rows written by a single run are added to the request of the row
+	// above when it was written by a single run with the same mask.
+	var requests []*sheets.Request
+	var previous *sheets.UpdateCellsRequest // The request of the row above, if it covers the whole row
+	for i, row := range cells {
+		wholeRow := false
+		for j := 0; j < len(row); {
+			fields := gridCellMask(row[j])
+			k := j + 1
+			for k < len(row) && gridCellMask(row[k]) == fields {
+				k++
+			}
+
+			if fields != "" {
+				rowData := &sheets.RowData{}
+				for _, cell := range row[j:k] {
+					rowData.Values = append(rowData.Values, gridCellData(cell))
+				}
+
+				wholeRow = j == 0 && k == len(row)
+				if wholeRow && previous != nil && previous.Fields == fields {
+					previous.Rows = append(previous.Rows, rowData)
+					break
+				}
+
+				request := &sheets.UpdateCellsRequest{
+					Start: &sheets.GridCoordinate{
+						SheetId:     sheetID,
+						RowIndex:    start.StartRow + int64(i),
+						ColumnIndex: start.StartColumn + int64(j),
+					},
+					Rows:   []*sheets.RowData{rowData},
+					Fields: fields,
+				}
+				requests = append(requests, &sheets.Request{UpdateCells: request})
+				if wholeRow {
+					previous = request
+				}
+			}
+			j = k
+		}
+		if !wholeRow {
+			previous = nil
+		}
+	}
+
+	if len(requests) == 0 {
+		return nil
+	}
+
+	batchUpdateRequest := &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: requests,
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	_, err = gs.service.Spreadsheets.BatchUpdate(gs.spreadsheetID, batchUpdateRequest).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("unable to write cells to Google Sheets: %w", gs.apiError(err))
+	}
+
+	if start.StartRow == 0 {
+		gs.invalidateHeaders() // The header row was overwritten
+	}
+	gs.audit("WriteGrid", gs.sheetName, startCell, fmt.Sprintf("%d rows", len(cells)))
+	return nil
+}
+
+// gridCellMask returns the field mask of the attributes a Cell holds, as written by WriteGrid.
+//
+// Parameters:
+//   - cell: The cell to write.
+//
+// Returns:
+//   - The comma-separated CellData fields to write, empty if the cell holds nothing.
+func gridCellMask(cell Cell) string {
+	var fields []string
+	if cell.Value != nil || cell.Formula != "" {
+		fields = append(fields, "userEnteredValue")
+	}
+	if cell.Note != "" {
+		fields = append(fields, "note")
+	}
+	switch {
+	case cell.Format != nil:
+		fields = append(fields, "userEnteredFormat")
+	case cell.Hyperlink != "" && cell.Formula == "":
+		fields = append(fields, "userEnteredFormat.textFormat.link")
+	}
+	if cell.Validation != nil {
+		fields = append(fields, "dataValidation")
+	}
+	return strings.Join(fields, ",")
+}
+
+// gridCellData converts a Cell to the cell data written by WriteGrid.
+//
+// Parameters:
+//   - cell: The cell to convert.
+//
+// Returns:
+//   - The cell data of the cell. The attributes left out of the mask of gridCellMask are ignored by the API.
+func gridCellData(cell Cell) *sheets.CellData {
+	cellData := &sheets.CellData{
+		UserEnteredValue:  interfaceToExtendedValue(cell.Value),
+		Note:              cell.Note,
+		UserEnteredFormat: cell.Format,
+		DataValidation:    cell.Validation,
+	}
+	if cell.Formula != "" {
+		formula := cell.Formula
+		cellData.UserEnteredValue = &sheets.ExtendedValue{FormulaValue: &formula}
+	}
+
+	if cell.Hyperlink != "" && cell.Formula == "" {
+		// Copy the format so the snapshot of the caller is not modified
+		format := &sheets.CellFormat{}
+		if cell.Format != nil {
+			*format = *cell.Format
+		}
+		textFormat := &sheets.TextFormat{}
+		if format.TextFormat != nil {
+			*textFormat = *format.TextFormat
+		}
+		textFormat.Link = &sheets.Link{Uri: cell.Hyperlink}
+		format.TextFormat = textFormat
+		cellData.UserEnteredFormat = format
+	}
+	return cellData
+}
@@ -0,0 +1,188 @@
+package gosheets
+
+import (
+	"reflect"
+	"testing"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+func TestReadGrid(t *testing.T) {
+	fake.reset()
+	t.Cleanup(fake.reset)
+	fake.seedSheet("Grid", [][]interface{}{{"Item", "Total"}, {"Pen", "=1/0"}, {2.5}})
+
+	bold := &sheets.CellFormat{TextFormat: &sheets.TextFormat{Bold: true}}
+	rule := &sheets.DataValidationRule{Condition: &sheets.BooleanCondition{Type: "BOOLEAN"}}
+	grid := fake.sheet("Grid")
+	grid.cells = map[[2]int64]*sheets.CellData{
+		{0, 0}: {Note: "Product name", UserEnteredFormat: bold},
+		{1, 0}: {Hyperlink: "https://example.com/pen"},
+		{2, 1}: {DataValidation: rule},
+	}
+
+	// Test cases
+	tests := []struct {
+		name    string
+		range_  string
+		want    [][]Cell
+		wantErr bool
+	}{
+		{
+			name:   "Whole block",
+			range_: "A1:B3",
+			want: [][]Cell{
+				{{Value: "Item", Note: "Product name", Format: bold}, {Value: "Total"}},
+				{{Value: "Pen", Hyperlink: "https://example.com/pen"}, {Value: "Function DIVIDE parameter 2 cannot be zero.", Formula: "=1/0"}},
+				{{Value: 2.5}, {Validation: rule}},
+			},
+		},
+		{
+			name:   "Range not starting at A1",
+			range_: "B2:B3",
+			want:   [][]Cell{{{Value: "Function DIVIDE parameter 2 cannot be zero.", Formula: "=1/0"}}, {{Validation: rule}}},
+		},
+		{
+			name:    "No range and no table range",
+			range_:  "",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetClient()
+			client.SetSheetName("Grid")
+
+			got, err := client.ReadGrid(tt.range_)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadGrid() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadGrid() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteGrid(t *testing.T) {
+	t.Cleanup(fake.reset)
+
+	link := &sheets.CellFormat{TextFormat: &sheets.TextFormat{Link: &sheets.Link{Uri: "https://example.com/pen"}}}
+	seed := func() {
+		fake.reset()
+		fake.seedSheet("Source", [][]interface{}{{"Item", "Total"}, {"Pen", "=B3*2"}, {2.5, true}})
+		fake.sheet("Source").cells = map[[2]int64]*sheets.CellData{
+			{0, 0}: {Note: "Product name", UserEnteredFormat: &sheets.CellFormat{TextFormat: &sheets.TextFormat{Bold: true}}},
+			{1, 0}: {UserEnteredFormat: link},
+			{2, 1}: {DataValidation: &sheets.DataValidationRule{Condition: &sheets.BooleanCondition{Type: "BOOLEAN"}}},
+		}
+	}
+
+	// Test cases
+	tests := []struct {
+		name      string
+		startCell string
+		readRange string
+		wantErr   bool
+	}{
+		{
+			name:      "Round trip at A1",
+			startCell: "A1",
+			readRange: "A1:B3",
+		},
+		{
+			name:      "Round trip at another cell",
+			startCell: "C5",
+			readRange: "C5:D7",
+		},
+		{
+			name:      "Invalid start cell",
+			startCell: "5C",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seed()
+			fake.seedSheet("Target", nil)
+			resetClient()
+
+			client.SetSheetName("Source")
+			snapshot, err := client.ReadGrid("A1:B3")
+			if err != nil {
+				t.Fatalf("ReadGrid() error = %v", err)
+			}
+
+			client.SetSheetName("Target")
+			err = client.WriteGrid(tt.startCell, snapshot)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("WriteGrid() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			got, err := client.ReadGrid(tt.readRange)
+			if err != nil {
+				t.Fatalf("ReadGrid() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, snapshot) {
+				t.Errorf("ReadGrid() after WriteGrid() = %+v, want %+v", got, snapshot)
+			}
+		})
+	}
+
+	t.Run("Attributes not in the snapshot are preserved", func(t *testing.T) {
+		fake.reset()
+		fake.seedSheet("Target", [][]interface{}{{"Old"}})
+		fake.sheet("Target").cells = map[[2]int64]*sheets.CellData{{0, 0}: {Note: "Keep me"}}
+		resetClient()
+		client.SetSheetName("Target")
+
+		err := client.WriteGrid("A1", [][]Cell{{{Value: "New"}}})
+		if err != nil {
+			t.Fatalf("WriteGrid() error = %v", err)
+		}
+
+		got, err := client.ReadGrid("A1")
+		if err != nil {
+			t.Fatalf("ReadGrid() error = %v", err)
+		}
+		if want := [][]Cell{{{Value: "New", Note: "Keep me"}}}; !reflect.DeepEqual(got, want) {
+			t.Errorf("ReadGrid() after WriteGrid() = %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("Block of values with their own formats in a single request", func(t *testing.T) {
+		fake.reset()
+		fake.seedSheet("Target", nil)
+		resetClient()
+		client.SetSheetName("Target")
+
+		red := &sheets.CellFormat{TextFormat: &sheets.TextFormat{ForegroundColor: &sheets.Color{Red: 1}}}
+		black := &sheets.CellFormat{TextFormat: &sheets.TextFormat{ForegroundColor: &sheets.Color{}}}
+		block := [][]Cell{
+			{{Value: 12.5, Format: black}, {Value: -3.0, Format: red}},
+			{{Value: -7.0, Format: red}, {Value: 4.0, Format: black}},
+			{{Value: 1.0, Format: black}},
+		}
+
+		err := client.WriteGrid("B2", block)
+		if err != nil {
+			t.Fatalf("WriteGrid() error = %v", err)
+		}
+		if len(fake.requests) != 1 || len(fake.requests[0].UpdateCells.Rows) != 3 {
+			t.Fatalf("WriteGrid() sent %d requests, want a single UpdateCellsRequest with the 3 rows", len(fake.requests))
+		}
+
+		got, err := client.ReadGrid("B2:C4")
+		if err != nil {
+			t.Fatalf("ReadGrid() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, block) {
+			t.Errorf("ReadGrid() after WriteGrid() = %+v, want %+v", got, block)
+		}
+	})
+}
@@ -0,0 +1,368 @@
+package gosheets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// DefaultHeaderCacheTTL is the time the header row of a sheet is cached when no TTL is set with WithHeaderCacheTTL.
+const DefaultHeaderCacheTTL = time.Minute
+
+// errStaleHeaders is returned by the functions passed to withHeaders when the headers they received do not match
+// the sheet, so the cached header row is fetched again.
+var errStaleHeaders = errors.New("cached header row does not match the sheet")
+
+// headerCacheKey identifies the sheet a cached header row belongs to.
+type headerCacheKey struct {
+	spreadsheetID string
+	sheetName     string
+}
+
+// headerCacheEntry is a header row stored in the cache.
+//
+//   - The headers field is used to store the string representation of the cells of the header row.
+//   - The fetched field is used to store the time the header row was read from the sheet.
+type headerCacheEntry struct {
+	headers []string
+	fetched time.Time
+}
+
+// headerCache stores the header rows of the sheets used by a client, shared by the methods that resolve
+// columns by header.
+//
+//   - The ttl field is used to store the time a header row is valid after it is read, 0 to disable the cache.
+//   - The entries field is used to store the cached header rows by sheet.
+type headerCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[headerCacheKey]headerCacheEntry
+}
+
+// get returns the cached header row of a sheet, or false if it is not cached or expired.
+func (c *headerCache) get(key headerCacheKey) ([]string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || time.Since(entry.fetched) >= c.ttl {
+		return nil, false
+	}
+	return entry.headers, true
+}
+
+// set stores the header row of a sheet. It does nothing when the cache is disabled.
+func (c *headerCache) set(key headerCacheKey, headers []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.ttl <= 0 {
+		return
+	}
+	if c.entries == nil {
+		c.entries = make(map[headerCacheKey]headerCacheEntry)
+	}
+	c.entries[key] = headerCacheEntry{headers: headers, fetched: time.Now()}
+}
+
+// invalidate removes the header row of a sheet from the cache.
+func (c *headerCache) invalidate(key headerCacheKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+}
+
+// clear removes every header row from the cache.
+func (c *headerCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = nil
+}
+
+// InvalidateCaches discards the header rows, the sheet IDs and the spreadsheet locales cached by the client, so the
+// next methods that resolve columns by header or need the ID of a sheet or the locale read them again. Call it after
+// another process changes the columns of a sheet used by the client, or deletes and recreates a sheet with the same
+// name; the changes made by the client itself invalidate the cache automatically.
+func (gs *GoogleSheetsClient) InvalidateCaches() {
+	gs.headerCache.clear()
+	gs.sheetIDCache.clear()
+	gs.localeCache.clear()
+}
+
+// ColumnByHeader finds the column of the current set sheet whose header, in the first row, is equal to a given
+// value. The header row is cached (see WithHeaderCacheTTL) and read again once if the header is not found in it.
+//
+// Parameters:
+//   - header: The header to search for (e.g., "Email").
+//
+// Returns:
+//   - The column letter of the header (e.g., "C").
+//   - ErrHeaderNotFound if no cell of the header row is equal to the header, or an error if there was a problem
+//     reading the header row.
+func (gs *GoogleSheetsClient) ColumnByHeader(header string) (string, error) {
+	var column string
+	err := gs.withHeaders(func(headers []string) error {
+		for i, h := range headers {
+			if h == header {
+				column = columnLetter(i)
+				return nil
+			}
+		}
+		return errStaleHeaders
+	})
+	if errors.Is(err, errStaleHeaders) {
+		return "", fmt.Errorf("%w: %q", ErrHeaderNotFound, header)
+	}
+	if err != nil {
+		return "", err
+	}
+	return column, nil
+}
+
+// MoveColumn moves the column of the current set sheet with a given header so it is right before the column with
+// another header, keeping the data and formatting of the cells. The headers are resolved from the header row as it is
+// in the sheet, not from the cache.
+//
+// Parameters:
+//   - header: The header of the column to move (e.g., "Email").
+//   - beforeHeader: The header of the column that must be right after the moved column (e.g., "Phone").
+//
+// Returns:
+//   - ErrHeaderNotFound if either header is not in the header row, or an error if both headers are the same or
+//     there was a problem moving the column, nil otherwise.
+func (gs *GoogleSheetsClient) MoveColumn(header string, beforeHeader string) error {
+	err := validateClientFields(gs)
+	if err != nil {
+		return err
+	}
+
+	err = gs.requireScope(writeScopes...)
+	if err != nil {
+		return err
+	}
+
+	if header == beforeHeader {
+		return fmt.Errorf("unable to move column %q before itself", header)
+	}
+
+	gs.invalidateHeaders()
+	headers, _, err := gs.headers()
+	if err != nil {
+		return err
+	}
+
+	from, to := slices.Index(headers, header), slices.Index(headers, beforeHeader)
+	if from == -1 {
+		return fmt.Errorf("%w: %q", ErrHeaderNotFound, header)
+	}
+	if to == -1 {
+		return fmt.Errorf("%w: %q", ErrHeaderNotFound, beforeHeader)
+	}
+	if from+1 == to {
+		return nil // Already in place
+	}
+
+	sheetID, err := gs.getSheetID()
+	if err != nil {
+		return err
+	}
+
+	request := &sheets.Request{
+		MoveDimension: &sheets.MoveDimensionRequest{
+			Source: &sheets.DimensionRange{
+				SheetId:         sheetID,
+				Dimension:       "COLUMNS",
+				StartIndex:      int64(from),
+				EndIndex:        int64(from + 1),
+				ForceSendFields: []string{"StartIndex"}, // Index 0 would be omitted otherwise
+			},
+			// The API interprets the destination relative to the columns before the move
+			DestinationIndex: int64(to),
+			ForceSendFields:  []string{"DestinationIndex"},
+		},
+	}
+
+	batchUpdateRequest := &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{request},
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	_, err = gs.service.Spreadsheets.BatchUpdate(gs.spreadsheetID, batchUpdateRequest).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("unable to move column: %w", gs.apiError(err))
+	}
+
+	gs.invalidateHeaders()
+	gs.audit("MoveColumn", gs.sheetName, columnLetter(from), fmt.Sprintf("moved %q before %q", header, beforeHeader))
+	return nil
+}
+
+// RenameHeader changes a header of the current set sheet, writing only its cell of the header row. The headers are
+// resolved from the header row as it is in the sheet, not from the cache.
+//
+// Parameters:
+//   - oldHeader: The current header of the column (e.g., "Qty").
+//   - newHeader: The new header of the column (e.g., "Quantity").
+//
+// Returns:
+//   - ErrHeaderNotFound if the old header is not in the header row, ErrDuplicateHeader if another column already has
+//     the new header, or an error if the new header is empty or there was a problem writing it, nil otherwise.
+func (gs *GoogleSheetsClient) RenameHeader(oldHeader, newHeader string) error {
+	err := validateClientFields(gs)
+	if err != nil {
+		return err
+	}
+
+	err = gs.requireScope(writeScopes...)
+	if err != nil {
+		return err
+	}
+
+	if newHeader == "" {
+		return errors.New("the new header cannot be empty")
+	}
+
+	gs.invalidateHeaders()
+	headers, _, err := gs.headers()
+	if err != nil {
+		return err
+	}
+
+	index := slices.Index(headers, oldHeader)
+	if index == -1 {
+		return fmt.Errorf("%w: %q", ErrHeaderNotFound, oldHeader)
+	}
+	if oldHeader == newHeader {
+		return nil
+	}
+	if slices.Contains(headers, newHeader) {
+		return fmt.Errorf("%w: %q", ErrDuplicateHeader, newHeader)
+	}
+
+	column := columnLetter(index)
+	valueRange := &sheets.ValueRange{
+		Values: [][]interface{}{{newHeader}},
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	_, err = gs.service.Spreadsheets.Values.Update(gs.spreadsheetID, fmt.Sprintf("%s!%s1", quoteSheetName(gs.sheetName), column), valueRange).
+		ValueInputOption(InputRaw).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("unable to rename header: %w", gs.apiError(err))
+	}
+
+	gs.invalidateHeaders()
+	gs.audit("RenameHeader", gs.sheetName, column+"1", fmt.Sprintf("renamed %q to %q", oldHeader, newHeader))
+	return nil
+}
+
+// ReadWithHeader reads data from the current set sheet in the GoogleSheetsClient struct like ReadData, and splits
+// the first row of the range off as the headers.
+//
+// Parameters:
+//   - readRange: The range of cells to read data from, starting at the header row (e.g., "A1:F100" or "A:F").
+//   - opts: Optional settings for the read (see ReadData). The headers are converted to strings in any case.
+//
+// Returns:
+//   - The headers, as strings. Empty if the range has no data.
+//   - The rows below the headers. Empty if the range has no data or only the header row.
+//   - An error if there was a problem reading the data, nil otherwise.
+func (gs *GoogleSheetsClient) ReadWithHeader(readRange string, opts ...ReadOption) ([]string, [][]interface{}, error) {
+	data, err := gs.ReadData(readRange, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(data) == 0 {
+		return []string{}, [][]interface{}{}, nil
+	}
+
+	headers := make([]string, len(data[0]))
+	for i, cell := range data[0] {
+		headers[i] = fmt.Sprintf("%v", cell)
+	}
+	return headers, data[1:], nil
+}
+
+// withHeaders calls fn with the header row of the current set sheet. If fn returns an error wrapping
+// errStaleHeaders and the header row came from the cache, the header row is read again and fn is called once more
+// with it.
+//
+// Parameters:
+//   - fn: The function that uses the header row.
+//
+// Returns:
+//   - The error returned by the last call of fn, or an error if there was a problem reading the header row.
+func (gs *GoogleSheetsClient) withHeaders(fn func(headers []string) error) error {
+	headers, cached, err := gs.headers()
+	if err != nil {
+		return err
+	}
+
+	err = fn(headers)
+	if !errors.Is(err, errStaleHeaders) || !cached {
+		return err
+	}
+
+	gs.invalidateHeaders()
+	headers, _, err = gs.headers()
+	if err != nil {
+		return err
+	}
+	return fn(headers)
+}
+
+// headers returns the header row of the current set sheet, from the cache if it holds a valid copy.
+//
+// Returns:
+//   - The string representation of the cells of the first row of the sheet.
+//   - Whether the header row came from the cache.
+//   - An error if there was a problem reading the header row.
+func (gs *GoogleSheetsClient) headers() ([]string, bool, error) {
+	err := gs.resolveSheetName()
+	if err != nil {
+		return nil, false, err
+	}
+
+	key := gs.headerCacheKey()
+	if headers, ok := gs.headerCache.get(key); ok {
+		return headers, true, nil
+	}
+
+	data, err := gs.ReadData("1:1")
+	if err != nil {
+		return nil, false, fmt.Errorf("unable to read the header row: %w", err)
+	}
+
+	headers := []string{}
+	if len(data) > 0 {
+		for _, cell := range data[0] {
+			headers = append(headers, fmt.Sprintf("%v", cell))
+		}
+	}
+
+	gs.headerCache.set(key, headers)
+	return headers, false, nil
+}
+
+// invalidateHeaders removes the header row of the current set sheet from the cache. The methods of the client that
+// change the header row or move it call it after a successful change.
+func (gs *GoogleSheetsClient) invalidateHeaders() {
+	gs.headerCache.invalidate(gs.headerCacheKey())
+}
+
+// headerCacheKey returns the key of the current set sheet in the header cache.
+func (gs *GoogleSheetsClient) headerCacheKey() headerCacheKey {
+	return headerCacheKey{spreadsheetID: gs.spreadsheetID, sheetName: gs.sheetName}
+}
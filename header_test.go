@@ -0,0 +1,353 @@
+package gosheets
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestColumnByHeader(t *testing.T) {
+	fake.reset()
+	t.Cleanup(fake.reset)
+	fake.seedSheet("People", [][]interface{}{{"Name", "Phone", "Email"}, {"Alice", "555", "alice@example.com"}})
+
+	// Test cases
+	tests := []struct {
+		name    string
+		header  string
+		want    string
+		wantErr error
+	}{
+		{
+			name:   "First column",
+			header: "Name",
+			want:   "A",
+		},
+		{
+			name:   "Last column",
+			header: "Email",
+			want:   "C",
+		},
+		{
+			name:    "Non-existent header",
+			header:  "Address",
+			wantErr: ErrHeaderNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetClient()
+			client.SetSheetName("People")
+
+			got, err := client.ColumnByHeader(tt.header)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ColumnByHeader() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ColumnByHeader() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHeaderCache(t *testing.T) {
+	fake.reset()
+	t.Cleanup(fake.reset)
+	resetClient()
+
+	if _, err := client.ColumnByHeader("Header1"); err != nil {
+		t.Fatalf("ColumnByHeader() error = %v", err)
+	}
+
+	calls := fake.callCount()
+	if _, err := client.ColumnByHeader("Header2"); err != nil {
+		t.Fatalf("ColumnByHeader() error = %v", err)
+	}
+	if fake.callCount() != calls {
+		t.Errorf("ColumnByHeader() made %d API calls with a cached header row, want 0", fake.callCount()-calls)
+	}
+
+	// Another process adds a column: the cached header row is stale and read again once.
+	fake.sheet("Sheet1").values[0] = append(fake.sheet("Sheet1").values[0], "Header3")
+	if got, err := client.ColumnByHeader("Header3"); err != nil || got != "C" {
+		t.Errorf("ColumnByHeader() = %v, %v after the header row changed, want C, nil", got, err)
+	}
+
+	// Another process renames a column: the stale header still resolves until the cache is invalidated.
+	fake.sheet("Sheet1").values[0][0] = "Renamed"
+	if _, err := client.ColumnByHeader("Header1"); err != nil {
+		t.Errorf("ColumnByHeader() error = %v with a cached header row, want nil", err)
+	}
+	client.InvalidateCaches()
+	if _, err := client.ColumnByHeader("Header1"); !errors.Is(err, ErrHeaderNotFound) {
+		t.Errorf("ColumnByHeader() error = %v after InvalidateCaches, want %v", err, ErrHeaderNotFound)
+	}
+
+	// A deletion of the header row by the client invalidates the cache.
+	data := [][]interface{}{{"Renamed", "Header2", "Header3"}, {"Value1", "Value2"}}
+	if err := client.DeleteRow(data, "A", "Renamed", Force()); err != nil {
+		t.Fatalf("DeleteRow() error = %v", err)
+	}
+	if got, err := client.ColumnByHeader("Value2"); err != nil || got != "B" {
+		t.Errorf("ColumnByHeader() = %v, %v after the header row was deleted, want B, nil", got, err)
+	}
+}
+
+func TestWithHeaderCacheTTL(t *testing.T) {
+	fake.reset()
+	t.Cleanup(fake.reset)
+
+	gs, err := NewGoogleSheetsClient(testCredentials, WithEndpoint(testEndpoint), WithHeaderCacheTTL(0))
+	if err != nil {
+		t.Fatalf("NewGoogleSheetsClient() error = %v", err)
+	}
+	gs.SetSpreadsheetID("SPREADSHEET_ID")
+	gs.SetSheetName("Sheet1")
+
+	for i := 0; i < 2; i++ {
+		calls := fake.callCount()
+		if _, err := gs.ColumnByHeader("Header1"); err != nil {
+			t.Fatalf("ColumnByHeader() error = %v", err)
+		}
+		if fake.callCount() == calls {
+			t.Errorf("ColumnByHeader() made no API call with the cache disabled")
+		}
+	}
+
+	cache := &headerCache{ttl: time.Millisecond}
+	key := headerCacheKey{spreadsheetID: "SPREADSHEET_ID", sheetName: "Sheet1"}
+	cache.set(key, []string{"Header1"})
+	time.Sleep(2 * time.Millisecond)
+	if _, ok := cache.get(key); ok {
+		t.Errorf("get() returned an expired header row")
+	}
+}
+
+func TestReadWithHeader(t *testing.T) {
+	fake.reset()
+	t.Cleanup(fake.reset)
+	fake.seedSheet("People", [][]interface{}{{"Name", "Phone"}, {"Alice", "555"}, {"Bob", "556"}})
+	fake.seedSheet("Empty", nil)
+
+	// Test cases
+	tests := []struct {
+		name        string
+		sheetName   string
+		readRange   string
+		wantHeaders []string
+		wantRows    [][]interface{}
+		wantErr     bool
+	}{
+		{
+			name:        "Header and rows",
+			sheetName:   "People",
+			readRange:   "A:B",
+			wantHeaders: []string{"Name", "Phone"},
+			wantRows:    [][]interface{}{{"Alice", "555"}, {"Bob", "556"}},
+		},
+		{
+			name:        "Header only",
+			sheetName:   "People",
+			readRange:   "A1:B1",
+			wantHeaders: []string{"Name", "Phone"},
+			wantRows:    [][]interface{}{},
+		},
+		{
+			name:        "Empty sheet",
+			sheetName:   "Empty",
+			readRange:   "A:B",
+			wantHeaders: []string{},
+			wantRows:    [][]interface{}{},
+		},
+		{
+			name:      "Non-existent sheet",
+			sheetName: "Missing",
+			readRange: "A:B",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetClient()
+			client.SetSheetName(tt.sheetName)
+
+			headers, rows, err := client.ReadWithHeader(tt.readRange)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadWithHeader() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(headers, tt.wantHeaders) {
+				t.Errorf("ReadWithHeader() headers = %v, want %v", headers, tt.wantHeaders)
+			}
+			if !reflect.DeepEqual(rows, tt.wantRows) {
+				t.Errorf("ReadWithHeader() rows = %v, want %v", rows, tt.wantRows)
+			}
+		})
+	}
+}
+
+func TestMoveColumn(t *testing.T) {
+	t.Cleanup(fake.reset)
+	seed := func() [][]interface{} {
+		return [][]interface{}{{"Name", "Phone", "Email"}, {"Alice", "555", "alice@example.com"}, {"Bob"}}
+	}
+
+	// Test cases
+	tests := []struct {
+		name         string
+		header       string
+		beforeHeader string
+		want         [][]interface{}
+		wantErr      bool
+		wantErrIs    error
+	}{
+		{
+			name:         "Move left",
+			header:       "Email",
+			beforeHeader: "Phone",
+			want:         [][]interface{}{{"Name", "Email", "Phone"}, {"Alice", "alice@example.com", "555"}, {"Bob"}},
+		},
+		{
+			name:         "Move right",
+			header:       "Name",
+			beforeHeader: "Email",
+			want:         [][]interface{}{{"Phone", "Name", "Email"}, {"555", "Alice", "alice@example.com"}, {nil, "Bob"}},
+		},
+		{
+			name:         "Already in place",
+			header:       "Name",
+			beforeHeader: "Phone",
+			want:         seed(),
+		},
+		{
+			name:         "Missing header",
+			header:       "Address",
+			beforeHeader: "Phone",
+			wantErr:      true,
+			wantErrIs:    ErrHeaderNotFound,
+		},
+		{
+			name:         "Missing before header",
+			header:       "Email",
+			beforeHeader: "Address",
+			wantErr:      true,
+			wantErrIs:    ErrHeaderNotFound,
+		},
+		{
+			name:         "Same header",
+			header:       "Email",
+			beforeHeader: "Email",
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.reset()
+			fake.seedSheet("People", seed())
+			resetClient()
+			client.SetSheetName("People")
+
+			// Cache the header row, which the move must invalidate
+			if _, err := client.ColumnByHeader("Name"); err != nil {
+				t.Fatalf("ColumnByHeader() error = %v", err)
+			}
+
+			err := client.MoveColumn(tt.header, tt.beforeHeader)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MoveColumn() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Fatalf("MoveColumn() error = %v, want %v", err, tt.wantErrIs)
+			}
+			if err != nil {
+				return
+			}
+
+			if got := fake.sheet("People").values; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MoveColumn() values = %v, want %v", got, tt.want)
+			}
+			column, err := client.ColumnByHeader("Name")
+			if err != nil {
+				t.Fatalf("ColumnByHeader() error = %v", err)
+			}
+			if want := columnLetter(slices.Index(tt.want[0], interface{}("Name"))); column != want {
+				t.Errorf("ColumnByHeader() after MoveColumn() = %v, want %v", column, want)
+			}
+		})
+	}
+}
+
+func TestRenameHeader(t *testing.T) {
+	t.Cleanup(fake.reset)
+
+	// Test cases
+	tests := []struct {
+		name      string
+		oldHeader string
+		newHeader string
+		want      []interface{}
+		wantErr   error
+	}{
+		{
+			name:      "Rename",
+			oldHeader: "Qty",
+			newHeader: "Quantity",
+			want:      []interface{}{"Item", "Quantity", "Price"},
+		},
+		{
+			name:      "Same header",
+			oldHeader: "Qty",
+			newHeader: "Qty",
+			want:      []interface{}{"Item", "Qty", "Price"},
+		},
+		{
+			name:      "Missing header",
+			oldHeader: "Amount",
+			newHeader: "Quantity",
+			wantErr:   ErrHeaderNotFound,
+		},
+		{
+			name:      "Duplicate header",
+			oldHeader: "Qty",
+			newHeader: "Price",
+			wantErr:   ErrDuplicateHeader,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.reset()
+			// A sheet name that needs quotes in A1 notation
+			fake.seedSheet("Q2 Orders", [][]interface{}{{"Item", "Qty", "Price"}, {"Pen", "2", "1.5"}})
+			resetClient()
+			client.SetSheetName("Q2 Orders")
+
+			// Cache the header row, which the rename must invalidate
+			if _, err := client.ColumnByHeader("Qty"); err != nil {
+				t.Fatalf("ColumnByHeader() error = %v", err)
+			}
+
+			err := client.RenameHeader(tt.oldHeader, tt.newHeader)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RenameHeader() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if got := fake.sheet("Q2 Orders").values[0]; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RenameHeader() header row = %v, want %v", got, tt.want)
+			}
+			if _, err := client.ColumnByHeader(tt.newHeader); err != nil {
+				t.Errorf("ColumnByHeader() after RenameHeader() error = %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package gosheets
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// HTMLOption configures the HTML table produced by DataToHTML.
+type HTMLOption func(*htmlOptions)
+
+// htmlOptions holds the settings applied by the HTMLOption values passed to DataToHTML.
+//
+//   - The tableClass, headerClass and cellClass fields are used to store the CSS classes of the <table>, <th> and
+//     <td> elements.
+//   - The alignNumbers field is used to right-align the cells that hold numbers (see AlignNumbers).
+type htmlOptions struct {
+	tableClass   string
+	headerClass  string
+	cellClass    string
+	alignNumbers bool
+}
+
+// WithTableClass sets the class attribute of the <table> element, so a style sheet can style the table.
+func WithTableClass(class string) HTMLOption {
+	return func(o *htmlOptions) {
+		o.tableClass = class
+	}
+}
+
+// WithHeaderClass sets the class attribute of the <th> elements of the header row.
+func WithHeaderClass(class string) HTMLOption {
+	return func(o *htmlOptions) {
+		o.headerClass = class
+	}
+}
+
+// WithCellClass sets the class attribute of the <td> elements of the body rows.
+func WithCellClass(class string) HTMLOption {
+	return func(o *htmlOptions) {
+		o.cellClass = class
+	}
+}
+
+// AlignNumbers right-aligns the body cells that hold numbers, either numeric values or text that parses as a
+// number (e.g., "1234.5"), with an inline style, which email clients apply even when they drop style sheets.
+func AlignNumbers() HTMLOption {
+	return func(o *htmlOptions) {
+		o.alignNumbers = true
+	}
+}
+
+// DataToHTML converts a 2D slice of interface{} values to an HTML table, e.g., to embed the data read from a sheet
+// in an email report. The cell contents are HTML-escaped, nil cells are written as empty cells, and the rows
+// shorter than the widest one are padded with empty cells so the table stays rectangular.
+//
+// Parameters:
+//   - data: The 2D slice of interface{} values to convert.
+//   - firstRowIsHeader: Whether the first row is written as the <thead> of the table, with <th> cells.
+//   - opts: Optional settings for the table (e.g., WithTableClass, AlignNumbers).
+//
+// Returns:
+//   - A string with the <table> element of the data.
+func DataToHTML(data [][]interface{}, firstRowIsHeader bool, opts ...HTMLOption) string {
+	options := &htmlOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	width := 0
+	for _, row := range data {
+		width = max(width, len(row))
+	}
+
+	var result strings.Builder
+	result.WriteString("<table" + classAttribute(options.tableClass) + ">\n")
+
+	body := data
+	if firstRowIsHeader && len(data) > 0 {
+		result.WriteString("<thead>\n")
+		writeHTMLRow(&result, data[0], width, "th", classAttribute(options.headerClass), false)
+		result.WriteString("</thead>\n")
+		body = data[1:]
+	}
+
+	if len(body) > 0 {
+		result.WriteString("<tbody>\n")
+		for _, row := range body {
+			writeHTMLRow(&result, row, width, "td", classAttribute(options.cellClass), options.alignNumbers)
+		}
+		result.WriteString("</tbody>\n")
+	}
+
+	result.WriteString("</table>")
+	return result.String()
+}
+
+// writeHTMLRow writes a <tr> element with a cell per column, padding the row with empty cells up to the width.
+//
+// Parameters:
+//   - result: The builder to write the row to.
+//   - row: The cells of the row.
+//   - width: The number of cells of the row.
+//   - tag: The tag of the cells ("th" or "td").
+//   - attributes: The attributes of every cell (e.g., a class attribute), with a leading space.
+//   - alignNumbers: Whether to right-align the cells that hold numbers.
+func writeHTMLRow(result *strings.Builder, row []interface{}, width int, tag, attributes string, alignNumbers bool) {
+	result.WriteString("<tr>")
+	for i := 0; i < width; i++ {
+		var cell interface{}
+		if i < len(row) {
+			cell = row[i]
+		}
+
+		text := ""
+		if cell != nil {
+			text = fmt.Sprintf("%v", cell)
+		}
+
+		cellAttributes := attributes
+		if alignNumbers && isNumericCell(cell, text) {
+			cellAttributes += ` style="text-align: right"`
+		}
+		result.WriteString("<" + tag + cellAttributes + ">" + html.EscapeString(text) + "</" + tag + ">")
+	}
+	result.WriteString("</tr>\n")
+}
+
+// isNumericCell reports whether a cell holds a number, either a numeric value or text that parses as one.
+func isNumericCell(cell interface{}, text string) bool {
+	switch cell.(type) {
+	case nil, bool:
+		return false
+	case float64, float32, int, int64, int32, int16, int8, uint, uint64, uint32, uint16, uint8:
+		return true
+	}
+
+	// Words such as "NaN" or "Inf" parse as numbers, but are text in a report
+	number, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+	return err == nil && !math.IsNaN(number) && !math.IsInf(number, 0)
+}
+
+// classAttribute returns the class attribute for a CSS class, with a leading space, or an empty string if the
+// class is empty.
+func classAttribute(class string) string {
+	if class == "" {
+		return ""
+	}
+	return ` class="` + html.EscapeString(class) + `"`
+}
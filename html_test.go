@@ -0,0 +1,64 @@
+package gosheets
+
+import "testing"
+
+func TestDataToHTML(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name             string
+		data             [][]interface{}
+		firstRowIsHeader bool
+		opts             []HTMLOption
+		want             string
+	}{
+		{
+			name:             "Header and body",
+			data:             [][]interface{}{{"Name", "Total"}, {"Alice", 10}},
+			firstRowIsHeader: true,
+			want: "<table>\n" +
+				"<thead>\n<tr><th>Name</th><th>Total</th></tr>\n</thead>\n" +
+				"<tbody>\n<tr><td>Alice</td><td>10</td></tr>\n</tbody>\n" +
+				"</table>",
+		},
+		{
+			name: "Escaped contents and ragged rows padded",
+			data: [][]interface{}{{"<b>Tom & Jerry</b>", `"quoted"`, nil}, {"Short"}},
+			want: "<table>\n<tbody>\n" +
+				"<tr><td>&lt;b&gt;Tom &amp; Jerry&lt;/b&gt;</td><td>&#34;quoted&#34;</td><td></td></tr>\n" +
+				"<tr><td>Short</td><td></td><td></td></tr>\n" +
+				"</tbody>\n</table>",
+		},
+		{
+			name:             "Classes and numbers aligned",
+			data:             [][]interface{}{{"Item", "Price"}, {"Pen", "1.50"}, {"NaN", 3}},
+			firstRowIsHeader: true,
+			opts:             []HTMLOption{WithTableClass("report"), WithHeaderClass("head"), WithCellClass("cell"), AlignNumbers()},
+			want: "<table class=\"report\">\n" +
+				"<thead>\n<tr><th class=\"head\">Item</th><th class=\"head\">Price</th></tr>\n</thead>\n" +
+				"<tbody>\n" +
+				"<tr><td class=\"cell\">Pen</td><td class=\"cell\" style=\"text-align: right\">1.50</td></tr>\n" +
+				"<tr><td class=\"cell\">NaN</td><td class=\"cell\" style=\"text-align: right\">3</td></tr>\n" +
+				"</tbody>\n</table>",
+		},
+		{
+			name:             "Only the header row",
+			data:             [][]interface{}{{"Name"}},
+			firstRowIsHeader: true,
+			want:             "<table>\n<thead>\n<tr><th>Name</th></tr>\n</thead>\n</table>",
+		},
+		{
+			name: "Empty data",
+			data: nil,
+			want: "<table>\n</table>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DataToHTML(tt.data, tt.firstRowIsHeader, tt.opts...)
+			if got != tt.want {
+				t.Errorf("DataToHTML() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
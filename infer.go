@@ -0,0 +1,244 @@
+package gosheets
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// numberPattern matches the decimal numbers converted by InferTypes, with an optional sign and exponent.
+var numberPattern = regexp.MustCompile(`^[+-]?([0-9]+\.?[0-9]*|\.[0-9]+)([eE][+-]?[0-9]+)?$`)
+
+// maxExactDigits is the number of digits of the longest integers a float64 (and so a spreadsheet cell) always stores
+// exactly. Longer integers (e.g., IDs) are kept as text.
+const maxExactDigits = 15
+
+// AppendDataInferTypes appends data to the end of the current set sheet in the GoogleSheetsClient struct like
+// AppendData, but converts the strings that hold a number or a boolean to numbers and booleans first (see InferTypes).
+//
+// Parameters:
+//   - data: A 2D slice representing the data to be added, usually built from strings.
+//   - range_: The cell used to search for existing data and find a "table" within that range where the data will be
+//     appended (e.g., "A1").
+//   - opts: Other settings for the write (see AppendData).
+//
+// Returns:
+//   - An error if there was a problem appending the data, nil otherwise.
+func (gs *GoogleSheetsClient) AppendDataInferTypes(data [][]interface{}, range_ string, opts ...WriteOption) error {
+	return gs.AppendData(data, range_, append(opts, InferTypes())...)
+}
+
+// inferTypes returns a copy of data with the strings that hold a number or a boolean converted to float64 and bool.
+// The rows without such strings are not copied, and the data of the caller is never modified.
+func inferTypes(data [][]interface{}) [][]interface{} {
+	inferred := make([][]interface{}, len(data))
+	for i, row := range data {
+		inferred[i] = row
+		copied := false
+		for j, cell := range row {
+			text, ok := cell.(string)
+			if !ok {
+				continue
+			}
+			value, ok := inferValue(text)
+			if !ok {
+				continue
+			}
+			if !copied {
+				inferred[i] = append([]interface{}(nil), row...) // Do not modify the data of the caller
+				copied = true
+			}
+			inferred[i][j] = value
+		}
+	}
+	return inferred
+}
+
+// inferValue converts a string holding a number or a boolean to a float64 or a bool.
+//
+// Parameters:
+//   - text: The string to convert.
+//
+// Returns:
+//   - The converted value, and true if the string holds a number or a boolean, false otherwise.
+func inferValue(text string) (interface{}, bool) {
+	switch strings.ToLower(text) {
+	case "true":
+		return true, true
+	case "false":
+		return false, true
+	}
+
+	number, ok := numericValue(text)
+	if !ok {
+		return nil, false
+	}
+	return number, true
+}
+
+// numericValue converts a string holding a number to a float64, with the rules of InferTypes.
+//
+// Parameters:
+//   - text: The string to convert.
+//
+// Returns:
+//   - The number, and true if the string holds a number, false otherwise.
+func numericValue(text string) (float64, bool) {
+	if !numberPattern.MatchString(text) {
+		return 0, false
+	}
+
+	digits := strings.TrimLeft(text, "+-")
+	integer, _, _ := strings.Cut(strings.ToLower(digits), "e")
+	integer, _, isDecimal := strings.Cut(integer, ".")
+	if len(integer) > 1 && integer[0] == '0' {
+		return 0, false // Leading zeros are part of codes (e.g., "007" or ZIP codes), not numbers
+	}
+	if !isDecimal && !strings.ContainsAny(digits, "eE") && len(integer) > maxExactDigits {
+		return 0, false
+	}
+
+	number, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return 0, false // Out of the range of float64
+	}
+	return number, true
+}
+
+// coerceNumbers returns a copy of data with the strings that hold a number converted to float64, for the appends
+// made with WithNumericCoercion. The rows without such strings are not copied, and the data of the caller is never
+// modified.
+//
+// Parameters:
+//   - sheetName: The name of the sheet the data is appended to.
+//   - range_: The range the data is appended to, without the sheet name. Its first column is the column of the
+//     first cell of each row.
+//   - data: The data to convert.
+//   - exclude: The columns whose strings are kept as text, by header or by column letter.
+//
+// Returns:
+//   - The converted data, or an error wrapping ErrHeaderNotFound if an excluded column is neither a header nor a
+//     column letter, or an error if there was a problem reading the header row.
+func (gs *GoogleSheetsClient) coerceNumbers(sheetName, range_ string, data [][]interface{}, exclude []string) ([][]interface{}, error) {
+	start := appendStartColumn(range_)
+
+	excluded := make(map[int]bool, len(exclude))
+	if len(exclude) > 0 {
+		headers, err := gs.headerRowOf(sheetName)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range exclude {
+			column := slices.Index(headers, name)
+			if column == -1 {
+				index, row, err := parseA1Cell(name)
+				if err != nil || index == -1 || row != -1 {
+					return nil, fmt.Errorf("%w: %q is neither a header of sheet %s nor a column letter", ErrHeaderNotFound, name, sheetName)
+				}
+				column = int(index)
+			}
+			excluded[column-start] = true
+		}
+	}
+
+	coerced := make([][]interface{}, len(data))
+	for i, row := range data {
+		coerced[i] = row
+		copied := false
+		for j, cell := range row {
+			text, ok := cell.(string)
+			if !ok || excluded[j] {
+				continue
+			}
+			number, ok := numericValue(text)
+			if !ok {
+				continue
+			}
+			if !copied {
+				coerced[i] = append([]interface{}(nil), row...) // Do not modify the data of the caller
+				copied = true
+			}
+			coerced[i][j] = number
+		}
+	}
+	return coerced, nil
+}
+
+// warnNumericText reports to the warning hook the strings holding a number in data appended with InputRaw, which
+// the sheet stores as text that the formulas summing the cells skip (see WithNumericCoercion).
+//
+// Parameters:
+//   - sheetName: The name of the sheet the data is appended to.
+//   - range_: The range the data is appended to, without the sheet name.
+//   - data: The data appended.
+func (gs *GoogleSheetsClient) warnNumericText(sheetName, range_ string, data [][]interface{}) {
+	if gs.warningHook == nil {
+		return // Nobody to warn, avoid scanning the data
+	}
+
+	count := 0
+	example, exampleColumn := "", 0
+	for _, row := range data {
+		for j, cell := range row {
+			text, ok := cell.(string)
+			if !ok {
+				continue
+			}
+			if _, ok := numericValue(text); ok {
+				if count == 0 {
+					example, exampleColumn = text, j
+				}
+				count++
+			}
+		}
+	}
+
+	if count > 0 {
+		gs.warn(fmt.Errorf("%d cells appended to sheet %s hold numbers as text (e.g., %q in column %s): pass WithNumericCoercion to write them as numbers",
+			count, sheetName, example, columnLetter(appendStartColumn(range_)+exampleColumn)))
+	}
+}
+
+// appendStartColumn returns the 0-based index of the first column of the range an append is made to, the column
+// the first cell of each row is written to.
+func appendStartColumn(range_ string) int {
+	parsedRange, err := parseA1Range(range_)
+	if err != nil || parsedRange.StartColumn == -1 {
+		return 0
+	}
+	return int(parsedRange.StartColumn)
+}
+
+// headerRowOf returns the header row of a sheet, from the cache for the current set sheet.
+//
+// Parameters:
+//   - sheetName: The name of the sheet.
+//
+// Returns:
+//   - The string representation of the cells of the first row of the sheet, or an error if there was a problem
+//     reading it.
+func (gs *GoogleSheetsClient) headerRowOf(sheetName string) ([]string, error) {
+	if sheetName == gs.sheetName {
+		headers, _, err := gs.headers()
+		return headers, err
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	resp, err := gs.service.Spreadsheets.Values.Get(gs.spreadsheetID, quoteSheetName(sheetName)+"!1:1").Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the header row: %w", gs.apiError(err))
+	}
+
+	headers := []string{}
+	if len(resp.Values) > 0 {
+		for _, cell := range resp.Values[0] {
+			headers = append(headers, fmt.Sprintf("%v", cell))
+		}
+	}
+	return headers, nil
+}
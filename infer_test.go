@@ -0,0 +1,142 @@
+package gosheets
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInferValue(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name   string
+		text   string
+		want   interface{}
+		wantOk bool
+	}{
+		{name: "Integer", text: "42", want: 42.0, wantOk: true},
+		{name: "Negative decimal", text: "-3.5", want: -3.5, wantOk: true},
+		{name: "Exponent", text: "1e3", want: 1000.0, wantOk: true},
+		{name: "Zero", text: "0", want: 0.0, wantOk: true},
+		{name: "Decimal below one", text: "0.25", want: 0.25, wantOk: true},
+		{name: "Boolean in upper case", text: "TRUE", want: true, wantOk: true},
+		{name: "Leading zeros", text: "007"},
+		{name: "Too many digits", text: "1234567890123456"},
+		{name: "Text", text: "12 apples"},
+		{name: "Not a number", text: "NaN"},
+		{name: "Empty", text: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := inferValue(tt.text)
+			if ok != tt.wantOk || got != tt.want {
+				t.Errorf("inferValue(%q) = %v, %v, want %v, %v", tt.text, got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestAppendDataInferTypes(t *testing.T) {
+	fake.reset()
+	t.Cleanup(fake.reset)
+	fake.seedSheet("Imports", nil)
+
+	resetClient()
+	client.SetSheetName("Imports")
+
+	data := [][]interface{}{{"SKU-1", "12", "4.5", "true", "02134"}}
+	err := client.AppendDataInferTypes(data, "A1")
+	if err != nil {
+		t.Fatalf("AppendDataInferTypes() error = %v", err)
+	}
+
+	want := [][]interface{}{{"SKU-1", 12.0, 4.5, true, "02134"}}
+	if got := fake.sheet("Imports").values; !reflect.DeepEqual(got, want) {
+		t.Errorf("values = %v, want %v", got, want)
+	}
+	if data[0][1] != "12" {
+		t.Errorf("AppendDataInferTypes() modified the data of the caller: %v", data)
+	}
+}
+
+func TestWithNumericCoercion(t *testing.T) {
+	t.Cleanup(fake.reset)
+
+	var warnings []error
+	gs, err := NewGoogleSheetsClient(testCredentials, WithEndpoint(testEndpoint),
+		WithWarningHook(func(err error) { warnings = append(warnings, err) }))
+	if err != nil {
+		t.Fatalf("NewGoogleSheetsClient() error = %v", err)
+	}
+	gs.SetSpreadsheetID("SPREADSHEET_ID")
+	gs.SetSheetName("Orders")
+
+	// Test cases
+	tests := []struct {
+		name         string
+		opts         []WriteOption
+		want         []interface{}
+		wantWarnings int
+		wantErr      bool
+	}{
+		{
+			name: "Numbers coerced, booleans kept",
+			opts: []WriteOption{WithNumericCoercion()},
+			want: []interface{}{12.0, 4.5, 10001.0, "true"},
+		},
+		{
+			name: "Column excluded by header",
+			opts: []WriteOption{WithNumericCoercion("Zip")},
+			want: []interface{}{12.0, 4.5, "10001", "true"},
+		},
+		{
+			name: "Column excluded by letter",
+			opts: []WriteOption{WithNumericCoercion("A")},
+			want: []interface{}{"12", 4.5, 10001.0, "true"},
+		},
+		{
+			name:    "Unknown excluded column",
+			opts:    []WriteOption{WithNumericCoercion("Order ID")},
+			wantErr: true,
+		},
+		{
+			name:         "Numbers as text are reported without coercion",
+			want:         []interface{}{"12", "4.5", "10001", "true"},
+			wantWarnings: 1,
+		},
+		{
+			name: "Nothing reported when the values are parsed",
+			opts: []WriteOption{ValueInput(InputUserEntered)},
+			want: []interface{}{"12", "4.5", "10001", "true"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.reset()
+			fake.seedSheet("Orders", [][]interface{}{{"Qty", "Price", "Zip", "Gift"}})
+			gs.InvalidateCaches()
+			warnings = nil
+
+			data := [][]interface{}{{"12", "4.5", "10001", "true"}}
+			err := gs.AppendData(data, "A1", tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AppendData() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(warnings) != tt.wantWarnings {
+				t.Errorf("warnings = %v, want %d", warnings, tt.wantWarnings)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			values := fake.sheet("Orders").values
+			if got := values[len(values)-1]; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("appended row = %v, want %v", got, tt.want)
+			}
+			if data[0][0] != "12" {
+				t.Errorf("AppendData() modified the data of the caller: %v", data)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package gosheets
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// commaDecimalLanguages are the languages whose locales write numbers with a decimal comma (e.g., "1.234,56" in
+// de_DE), unless commaDecimalExceptions says otherwise.
+var commaDecimalLanguages = []string{
+	"bg", "ca", "cs", "da", "de", "el", "es", "et", "fi", "fr", "hr", "hu", "id", "it", "lt", "lv", "nb", "nl", "no",
+	"pl", "pt", "ro", "ru", "sk", "sl", "sr", "sv", "tr", "uk", "vi",
+}
+
+// commaDecimalExceptions are the locales that do not use the separators of their language.
+var commaDecimalExceptions = map[string]bool{
+	"de_CH": false, // 1'234.56
+	"es_MX": false, // 1,234.56
+	"es_US": false,
+	"it_CH": false,
+}
+
+// numberSeparators returns the separators of the numbers formatted for a locale.
+//
+// Parameters:
+//   - locale: The locale, as reported by the spreadsheet properties (e.g., "en_US" or "de_DE"), or an empty string
+//     for en_US.
+//
+// Returns:
+//   - The decimal separator.
+//   - The characters accepted as thousands separators.
+func numberSeparators(locale string) (string, string) {
+	locale = strings.ReplaceAll(locale, "-", "_")
+	language, _, _ := strings.Cut(locale, "_")
+
+	commaDecimal, ok := commaDecimalExceptions[locale]
+	if !ok {
+		commaDecimal = slices.Contains(commaDecimalLanguages, strings.ToLower(language))
+	}
+
+	if commaDecimal {
+		return ",", ". \u00a0\u202f" // Spaces in French and Nordic locales
+	}
+	if strings.HasSuffix(locale, "_CH") {
+		return ".", "'’"
+	}
+	return ".", ","
+}
+
+// parseLocalizedNumber parses a number formatted for a locale (e.g., "1.234,56" in de_DE or "1,234.56" in en_US).
+// The thousands separators are optional, but when present they must split the integer part in groups of three
+// digits, so "1,5" is not read as 15 in en_US nor "1.5" as 15 in de_DE.
+//
+// Parameters:
+//   - text: The text to parse.
+//   - locale: The locale of the text (see numberSeparators).
+//
+// Returns:
+//   - The number, or an error if the text is not a number in the locale.
+func parseLocalizedNumber(text, locale string) (float64, error) {
+	decimal, groups := numberSeparators(locale)
+	text = strings.TrimSpace(text)
+
+	integer, fraction, hasFraction := strings.Cut(text, decimal)
+	if strings.ContainsAny(fraction, groups) {
+		return 0, fmt.Errorf("invalid number %q for locale %q: thousands separator after the decimal separator", text, locale)
+	}
+
+	if strings.ContainsAny(integer, groups) {
+		sign := ""
+		if strings.HasPrefix(integer, "-") || strings.HasPrefix(integer, "+") {
+			sign, integer = integer[:1], integer[1:]
+		}
+
+		parts := []string{""}
+		for _, r := range integer {
+			if strings.ContainsRune(groups, r) {
+				parts = append(parts, "")
+				continue
+			}
+			parts[len(parts)-1] += string(r)
+		}
+		for i, part := range parts {
+			if (i == 0 && (part == "" || len(part) > 3)) || (i > 0 && len(part) != 3) {
+				return 0, fmt.Errorf("invalid number %q for locale %q: misplaced thousands separator", text, locale)
+			}
+		}
+		integer = sign + strings.Join(parts, "")
+	}
+
+	if hasFraction {
+		integer += "." + fraction
+	}
+	return strconv.ParseFloat(integer, 64)
+}
+
+// localeCache caches the locales of the spreadsheets by spreadsheet ID.
+type localeCache struct {
+	mu      sync.Mutex
+	locales map[string]string
+}
+
+// get returns the cached locale of a spreadsheet, and whether it was cached.
+func (c *localeCache) get(spreadsheetID string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	locale, ok := c.locales[spreadsheetID]
+	return locale, ok
+}
+
+// set caches the locale of a spreadsheet.
+func (c *localeCache) set(spreadsheetID, locale string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.locales == nil {
+		c.locales = map[string]string{}
+	}
+	c.locales[spreadsheetID] = locale
+}
+
+// clear discards every cached locale.
+func (c *localeCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.locales = nil
+}
+
+// Locale returns the locale the client reads formatted numbers with: the locale set with WithLocale, or else the
+// locale of the spreadsheet set in the GoogleSheetsClient struct (e.g., "de_DE"), read from its properties once
+// and cached (see InvalidateCaches).
+//
+// Returns:
+//   - The locale, or an error if there was a problem reading the spreadsheet properties.
+func (gs *GoogleSheetsClient) Locale() (string, error) {
+	if gs.locale != "" {
+		return gs.locale, nil
+	}
+	if locale, ok := gs.localeCache.get(gs.spreadsheetID); ok {
+		return locale, nil
+	}
+
+	err := validateClientFields(gs)
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	spreadsheet, err := gs.service.Spreadsheets.Get(gs.spreadsheetID).Fields("properties.locale").Context(ctx).Do()
+	if err != nil {
+		return "", fmt.Errorf("unable to retrieve the spreadsheet locale: %w", gs.apiError(err))
+	}
+
+	gs.localeCache.set(gs.spreadsheetID, spreadsheet.Properties.Locale)
+	return spreadsheet.Properties.Locale, nil
+}
+
+// CellFloat converts a cell read with ReadData to a number. The numbers are read as they are shown in the sheet
+// (e.g., "1.234,56" in a de_DE spreadsheet), with the separators of the locale of the client (see Locale).
+//
+// Parameters:
+//   - cell: The cell value (e.g., "1,234.56", a float64 or an int).
+//
+// Returns:
+//   - The number, or an error if the cell is not a number in the locale or there was a problem reading the locale.
+func (gs *GoogleSheetsClient) CellFloat(cell interface{}) (float64, error) {
+	switch value := cell.(type) {
+	case float64:
+		return value, nil
+	case int:
+		return float64(value), nil
+	case int64:
+		return float64(value), nil
+	}
+
+	locale, err := gs.Locale()
+	if err != nil {
+		return 0, err
+	}
+	return parseLocalizedNumber(fmt.Sprintf("%v", cell), locale)
+}
+
+// newMatcher builds the function that compares cells with a searched value, like the newMatcher function, reading
+// the numbers compared with NumericEqual with the separators of the locale of the client unless the options set
+// one with NumberLocale.
+//
+// Parameters:
+//   - value: The value to search for.
+//   - opts: The comparison options.
+//
+// Returns:
+//   - The matcher, or an error if there was a problem reading the locale.
+func (gs *GoogleSheetsClient) newMatcher(value string, opts []MatchOption) (valueMatcher, error) {
+	if options := newMatchOptions(opts); options.numeric && options.locale == "" {
+		locale, err := gs.Locale()
+		if err != nil {
+			return nil, err
+		}
+		opts = append([]MatchOption{NumberLocale(locale)}, opts...)
+	}
+	return newMatcher(value, opts), nil
+}
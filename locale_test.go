@@ -0,0 +1,106 @@
+package gosheets
+
+import (
+	"testing"
+)
+
+func TestParseLocalizedNumber(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name    string
+		text    string
+		locale  string
+		want    float64
+		wantErr bool
+	}{
+		{name: "en_US grouped decimal", text: "1,234.56", locale: "en_US", want: 1234.56},
+		{name: "en_US negative", text: "-12,345,678", locale: "en_US", want: -12345678},
+		{name: "en_US plain", text: " 42.5 ", locale: "en_US", want: 42.5},
+		{name: "en_US misplaced separator", text: "1,5", locale: "en_US", wantErr: true},
+		{name: "de_DE grouped decimal", text: "1.234,56", locale: "de_DE", want: 1234.56},
+		{name: "de_DE decimal only", text: "0,5", locale: "de_DE", want: 0.5},
+		{name: "de_DE grouping only", text: "1.234.567", locale: "de_DE", want: 1234567},
+		{name: "de_DE misplaced separator", text: "1.5", locale: "de_DE", wantErr: true},
+		{name: "de_DE separator after the decimal", text: "1,234.5", locale: "de_DE", wantErr: true},
+		{name: "fr_FR narrow no-break space", text: "1 234,5", locale: "fr_FR", want: 1234.5},
+		{name: "de_CH apostrophe", text: "1'234.5", locale: "de_CH", want: 1234.5},
+		{name: "Empty locale reads en_US", text: "1,234.5", locale: "", want: 1234.5},
+		{name: "Not a number", text: "abc", locale: "de_DE", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseLocalizedNumber(tt.text, tt.locale)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseLocalizedNumber(%q, %q) error = %v, wantErr %v", tt.text, tt.locale, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseLocalizedNumber(%q, %q) = %v, want %v", tt.text, tt.locale, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCellFloatLocale(t *testing.T) {
+	t.Cleanup(fake.reset)
+	t.Cleanup(resetClient)
+
+	// Test cases
+	tests := []struct {
+		name              string
+		spreadsheetLocale string
+		override          string
+		cell              interface{}
+		want              float64
+		wantErr           bool
+	}{
+		{name: "en_US spreadsheet", spreadsheetLocale: "en_US", cell: "1,234.56", want: 1234.56},
+		{name: "de_DE spreadsheet", spreadsheetLocale: "de_DE", cell: "1.234,56", want: 1234.56},
+		{name: "de_DE spreadsheet with en_US text", spreadsheetLocale: "de_DE", cell: "1,234.56", wantErr: true},
+		{name: "Override of the spreadsheet locale", spreadsheetLocale: "de_DE", override: "en_US", cell: "1,234.56", want: 1234.56},
+		{name: "Number cell", spreadsheetLocale: "de_DE", cell: 2.5, want: 2.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.reset()
+			fake.spreadsheets["SPREADSHEET_ID"].locale = tt.spreadsheetLocale
+			resetClient()
+			client.locale = tt.override
+			defer func() { client.locale = "" }()
+
+			got, err := client.CellFloat(tt.cell)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CellFloat(%v) error = %v, wantErr %v", tt.cell, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("CellFloat(%v) = %v, want %v", tt.cell, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNumericEqualLocale(t *testing.T) {
+	t.Cleanup(fake.reset)
+
+	fake.reset()
+	fake.spreadsheets["SPREADSHEET_ID"].locale = "de_DE"
+	fake.seedSheet("Prices", [][]interface{}{{"Item", "Price"}, {"Pen", "1.234,50"}, {"Ink", "2,5"}})
+	resetClient()
+	client.SetSheetName("Prices")
+
+	if got := FindRowNumber(fake.sheet("Prices").values, "B", "2.5", NumericEqual(), NumberLocale("de_DE")); got != -1 {
+		t.Errorf("FindRowNumber() of an en_US value with NumberLocale(de_DE) = %d, want -1", got)
+	}
+	if got := FindRowNumber(fake.sheet("Prices").values, "B", "2,5", NumericEqual(), NumberLocale("de_DE")); got != 3 {
+		t.Errorf("FindRowNumber() with NumberLocale(de_DE) = %d, want 3", got)
+	}
+
+	// The client methods read the numbers with the locale of the spreadsheet
+	if err := client.DeleteRowByValue("B", "1234,5", WithMatch(NumericEqual())); err != nil {
+		t.Fatalf("DeleteRowByValue() error = %v", err)
+	}
+	if got := len(fake.sheet("Prices").values); got != 2 {
+		t.Errorf("rows = %d, want 2", got)
+	}
+}
@@ -0,0 +1,161 @@
+package gosheets
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// CellLocation is the position of a cell in a spreadsheet, in the 1-based row numbers and column letters shown to
+// users. Use its methods to convert it to the 0-based indexes used by the API instead of adding or subtracting 1.
+//
+//   - The Sheet field is the name of the sheet of the cell, empty when it is not known.
+//   - The Row field is the 1-based row number of the cell (e.g., 7 for "B7").
+//   - The Column field is the column letter of the cell (e.g., "B" for "B7").
+type CellLocation struct {
+	Sheet  string
+	Row    int64
+	Column string
+}
+
+// plainSheetNamePattern matches the sheet names that do not need quotes in A1 notation, unless they also match
+// cellLikeSheetNamePattern.
+var plainSheetNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// cellLikeSheetNamePattern matches the sheet names that could be read as a cell reference (e.g., "Q1").
+var cellLikeSheetNamePattern = regexp.MustCompile(`^[A-Za-z]{1,3}[0-9]+$`)
+
+// CellLocationFromGrid creates a CellLocation from the 0-based indexes used by the API (e.g., in a
+// sheets.GridCoordinate or a sheets.GridRange).
+//
+// Parameters:
+//   - sheet: The name of the sheet of the cell, or an empty string if it is not known.
+//   - rowIndex: The 0-based index of the row of the cell.
+//   - columnIndex: The 0-based index of the column of the cell.
+//
+// Returns:
+//   - The location of the cell.
+func CellLocationFromGrid(sheet string, rowIndex, columnIndex int64) CellLocation {
+	return CellLocation{Sheet: sheet, Row: rowIndex + 1, Column: columnLetter(int(columnIndex))}
+}
+
+// ParseCellLocation creates a CellLocation from a cell reference in A1 notation, with or without the sheet name.
+//
+// Parameters:
+//   - a1: The cell reference (e.g., "B7", "Sheet1!B7" or "'My sheet'!$B$7").
+//
+// Returns:
+//   - The location of the cell, or an error if the reference is not a single cell in A1 notation.
+func ParseCellLocation(a1 string) (CellLocation, error) {
+	sheet, cell := "", a1
+	if i := strings.LastIndex(a1, "!"); i != -1 {
+		sheet, cell = unquoteSheetName(a1[:i]), a1[i+1:]
+	}
+
+	column, row, err := parseA1Cell(cell)
+	if err != nil {
+		return CellLocation{}, err
+	}
+	if column == -1 || row == -1 {
+		return CellLocation{}, fmt.Errorf("invalid cell reference %q: a cell needs both a column and a row", a1)
+	}
+
+	return CellLocationFromGrid(sheet, row, column), nil
+}
+
+// A1 returns the location in A1 notation, prefixed by the sheet name when it is known (e.g., "Sheet1!B7" or
+// "'My sheet'!B7").
+func (l CellLocation) A1() string {
+	cell := fmt.Sprintf("%s%d", l.Column, l.Row)
+	if l.Sheet == "" {
+		return cell
+	}
+	return quoteSheetName(l.Sheet) + "!" + cell
+}
+
+// RowIndex returns the 0-based index of the row, as used by the API.
+func (l CellLocation) RowIndex() int64 {
+	return l.Row - 1
+}
+
+// ColumnIndex returns the 0-based index of the column, as used by the API.
+func (l CellLocation) ColumnIndex() int64 {
+	return int64(columnIndex(l.Column))
+}
+
+// GridCoordinate returns the location as the coordinate used by the requests of a batch update.
+//
+// Parameters:
+//   - sheetID: The ID of the sheet of the cell (see getSheetID), which the location does not hold.
+//
+// Returns:
+//   - The 0-based coordinate of the cell.
+func (l CellLocation) GridCoordinate(sheetID int64) *sheets.GridCoordinate {
+	return &sheets.GridCoordinate{
+		SheetId:         sheetID,
+		RowIndex:        l.RowIndex(),
+		ColumnIndex:     l.ColumnIndex(),
+		ForceSendFields: []string{"SheetId", "RowIndex", "ColumnIndex"}, // 0 is a valid value for all of them
+	}
+}
+
+// FindCell finds the first cell of a range of the current set sheet in the GoogleSheetsClient struct whose value
+// matches a given value. The range is searched row by row, from left to right.
+//
+// Parameters:
+//   - readRange: The range of cells to search (e.g., "A:F" or "B2:D10").
+//   - value: The value to search for.
+//   - opts: Optional settings for the comparison of the cells with the value (see FindRowNumber).
+//
+// Returns:
+//   - The location of the first matching cell, and true if one was found.
+//   - An error if the range is not valid or there was a problem reading it.
+func (gs *GoogleSheetsClient) FindCell(readRange, value string, opts ...MatchOption) (CellLocation, bool, error) {
+	readRange, err := gs.rangeOrTable(gs.sheetName, readRange)
+	if err != nil {
+		return CellLocation{}, false, err
+	}
+
+	parsedRange, err := parseA1Range(readRange)
+	if err != nil {
+		return CellLocation{}, false, err
+	}
+
+	matcher, err := gs.newMatcher(value, opts)
+	if err != nil {
+		return CellLocation{}, false, err
+	}
+
+	data, err := gs.ReadData(readRange)
+	if err != nil {
+		return CellLocation{}, false, err
+	}
+
+	for i, row := range data {
+		for j, cell := range row {
+			if matcher(cell) {
+				return CellLocationFromGrid(gs.sheetName, parsedRange.StartRow+int64(i), parsedRange.StartColumn+int64(j)), true, nil
+			}
+		}
+	}
+	return CellLocation{}, false, nil
+}
+
+// quoteSheetName quotes a sheet name for A1 notation when it contains characters other than letters, digits and
+// underscores or looks like a cell reference, doubling the single quotes it contains.
+func quoteSheetName(name string) string {
+	if plainSheetNamePattern.MatchString(name) && !cellLikeSheetNamePattern.MatchString(name) {
+		return name
+	}
+	return "'" + strings.ReplaceAll(name, "'", "''") + "'"
+}
+
+// unquoteSheetName removes the quotes added by quoteSheetName.
+func unquoteSheetName(name string) string {
+	if len(name) >= 2 && strings.HasPrefix(name, "'") && strings.HasSuffix(name, "'") {
+		return strings.ReplaceAll(name[1:len(name)-1], "''", "'")
+	}
+	return name
+}
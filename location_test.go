@@ -0,0 +1,179 @@
+package gosheets
+
+import (
+	"testing"
+)
+
+func TestCellLocation(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name        string
+		a1          string
+		want        CellLocation
+		wantA1      string
+		rowIndex    int64
+		columnIndex int64
+		wantErr     bool
+	}{
+		{
+			name:        "First cell",
+			a1:          "A1",
+			want:        CellLocation{Row: 1, Column: "A"},
+			wantA1:      "A1",
+			rowIndex:    0,
+			columnIndex: 0,
+		},
+		{
+			name:        "Last single-letter column",
+			a1:          "Z9",
+			want:        CellLocation{Row: 9, Column: "Z"},
+			wantA1:      "Z9",
+			rowIndex:    8,
+			columnIndex: 25,
+		},
+		{
+			name:        "First two-letter column",
+			a1:          "AA10",
+			want:        CellLocation{Row: 10, Column: "AA"},
+			wantA1:      "AA10",
+			rowIndex:    9,
+			columnIndex: 26,
+		},
+		{
+			name:        "Sheet name",
+			a1:          "Sheet1!B7",
+			want:        CellLocation{Sheet: "Sheet1", Row: 7, Column: "B"},
+			wantA1:      "Sheet1!B7",
+			rowIndex:    6,
+			columnIndex: 1,
+		},
+		{
+			name:        "Quoted sheet name with an apostrophe",
+			a1:          "'Bob''s data'!$C$3",
+			want:        CellLocation{Sheet: "Bob's data", Row: 3, Column: "C"},
+			wantA1:      "'Bob''s data'!C3",
+			rowIndex:    2,
+			columnIndex: 2,
+		},
+		{
+			name:        "Sheet name that looks like a cell",
+			a1:          "'Q1'!A2",
+			want:        CellLocation{Sheet: "Q1", Row: 2, Column: "A"},
+			wantA1:      "'Q1'!A2",
+			rowIndex:    1,
+			columnIndex: 0,
+		},
+		{
+			name:        "Lowercase column",
+			a1:          "zz100",
+			want:        CellLocation{Row: 100, Column: "ZZ"},
+			wantA1:      "ZZ100",
+			rowIndex:    99,
+			columnIndex: 701,
+		},
+		{
+			name:    "Column without a row",
+			a1:      "B",
+			wantErr: true,
+		},
+		{
+			name:    "Range instead of a cell",
+			a1:      "A1:B2",
+			wantErr: true,
+		},
+		{
+			name:    "Row 0",
+			a1:      "A0",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCellLocation(tt.a1)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCellLocation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("ParseCellLocation() = %+v, want %+v", got, tt.want)
+			}
+			if got.A1() != tt.wantA1 {
+				t.Errorf("A1() = %v, want %v", got.A1(), tt.wantA1)
+			}
+			if got.RowIndex() != tt.rowIndex || got.ColumnIndex() != tt.columnIndex {
+				t.Errorf("RowIndex(), ColumnIndex() = %v, %v, want %v, %v", got.RowIndex(), got.ColumnIndex(), tt.rowIndex, tt.columnIndex)
+			}
+
+			coordinate := got.GridCoordinate(42)
+			if coordinate.SheetId != 42 || coordinate.RowIndex != tt.rowIndex || coordinate.ColumnIndex != tt.columnIndex {
+				t.Errorf("GridCoordinate() = %+v, want row %v and column %v of sheet 42", coordinate, tt.rowIndex, tt.columnIndex)
+			}
+
+			if back := CellLocationFromGrid(tt.want.Sheet, tt.rowIndex, tt.columnIndex); back != tt.want {
+				t.Errorf("CellLocationFromGrid() = %+v, want %+v", back, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindCell(t *testing.T) {
+	fake.reset()
+	t.Cleanup(fake.reset)
+
+	// Test cases
+	tests := []struct {
+		name      string
+		readRange string
+		value     string
+		opts      []MatchOption
+		want      CellLocation
+		wantFound bool
+		wantErr   bool
+	}{
+		{
+			name:      "Value in the whole columns",
+			readRange: "A:B",
+			value:     "Value2",
+			want:      CellLocation{Sheet: "Sheet1", Row: 2, Column: "B"},
+			wantFound: true,
+		},
+		{
+			name:      "Value in a range with an offset",
+			readRange: "B2:B2",
+			value:     "value2",
+			opts:      []MatchOption{IgnoreCase()},
+			want:      CellLocation{Sheet: "Sheet1", Row: 2, Column: "B"},
+			wantFound: true,
+		},
+		{
+			name:      "Value outside the range",
+			readRange: "A1:A2",
+			value:     "Value2",
+			wantFound: false,
+		},
+		{
+			name:      "Invalid range",
+			readRange: "A0",
+			value:     "Value2",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetClient()
+
+			got, found, err := client.FindCell(tt.readRange, tt.value, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FindCell() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if found != tt.wantFound || got != tt.want {
+				t.Errorf("FindCell() = %+v, %v, want %+v, %v", got, found, tt.want, tt.wantFound)
+			}
+		})
+	}
+}
@@ -0,0 +1,569 @@
+package gosheets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// Manifest describes the structure of a spreadsheet: its sheets with their size, frozen panes, header row, data
+// validation rules and protected ranges, and its named ranges. It is serialized to portable JSON with encoding/json,
+// so the structure of a spreadsheet can be versioned and recreated on another spreadsheet (see ExportManifest and
+// ApplyManifest).
+//
+// The ranges of a manifest are in A1 notation without the sheet name (e.g., "C2:C100"), an empty range meaning the
+// whole sheet.
+type Manifest struct {
+	Sheets      []SheetManifest      `json:"sheets"`
+	NamedRanges []NamedRangeManifest `json:"namedRanges,omitempty"`
+}
+
+// SheetManifest describes a sheet of a Manifest. The sheets of a manifest are in tab order.
+//
+//   - The RowCount and ColumnCount fields are the size of the grid, 0 to keep the size of the sheet.
+//   - The Headers and HeaderFormats fields are the values and the formats of the first row, trailing empty cells omitted.
+//     A nil format leaves the cell with the default format.
+//   - The Values field holds every value of the sheet, with formulas as their text (starting with "="), only if the
+//     values of the sheet were exported. The first row of the values is the header row.
+type SheetManifest struct {
+	Title           string                   `json:"title"`
+	RowCount        int64                    `json:"rowCount,omitempty"`
+	ColumnCount     int64                    `json:"columnCount,omitempty"`
+	FrozenRows      int64                    `json:"frozenRows,omitempty"`
+	FrozenColumns   int64                    `json:"frozenColumns,omitempty"`
+	Headers         []string                 `json:"headers,omitempty"`
+	HeaderFormats   []*sheets.CellFormat     `json:"headerFormats,omitempty"`
+	Validations     []ValidationManifest     `json:"validations,omitempty"`
+	ProtectedRanges []ProtectedRangeManifest `json:"protectedRanges,omitempty"`
+	Values          [][]interface{}          `json:"values,omitempty"`
+}
+
+// ValidationManifest is a data validation rule applied to a range of a sheet of a Manifest.
+type ValidationManifest struct {
+	Range string                     `json:"range"`
+	Rule  *sheets.DataValidationRule `json:"rule"`
+}
+
+// ProtectedRangeManifest is a protected range of a sheet of a Manifest. The editors of the range are not part of
+// the manifest, since they are rarely the same on another spreadsheet: the protected ranges are recreated editable
+// only by the owner of the spreadsheet, and by the service account that created them.
+type ProtectedRangeManifest struct {
+	Range       string `json:"range,omitempty"`
+	Description string `json:"description,omitempty"`
+	WarningOnly bool   `json:"warningOnly,omitempty"`
+}
+
+// NamedRangeManifest is a named range of a Manifest.
+type NamedRangeManifest struct {
+	Name  string `json:"name"`
+	Sheet string `json:"sheet"`
+	Range string `json:"range,omitempty"`
+}
+
+// manifestFields is the field mask of the spreadsheet data an exported manifest is built from.
+const manifestFields = "namedRanges,sheets(properties(sheetId,title,gridProperties),protectedRanges," +
+	"data.rowData.values(formattedValue,userEnteredValue,userEnteredFormat,dataValidation))"
+
+// ExportManifest exports the structure of the spreadsheet set in the GoogleSheetsClient struct as a Manifest, to be
+// serialized to JSON or recreated on another spreadsheet with ApplyManifest. The data validation rules can be
+// anywhere in a sheet, so the grid data of the whole spreadsheet is read: exporting a large spreadsheet is slow.
+//
+// Parameters:
+//   - valueSheets: The names of the sheets whose values are included in the manifest (e.g., a sheet of lookup values
+//     the validation rules refer to). Without names, the manifest has no values.
+//
+// Returns:
+//   - The manifest of the spreadsheet.
+//   - An error if a sheet of valueSheets does not exist or there was a problem reading the spreadsheet, nil otherwise.
+func (gs *GoogleSheetsClient) ExportManifest(valueSheets ...string) (*Manifest, error) {
+	if gs.spreadsheetID == "" {
+		return nil, fmt.Errorf("spreadsheet ID not set")
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	spreadsheet, err := gs.service.Spreadsheets.Get(gs.spreadsheetID).IncludeGridData(true).Fields(manifestFields).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve spreadsheet: %w", gs.apiError(err))
+	}
+
+	titles := make(map[int64]string, len(spreadsheet.Sheets))
+	for _, sheet := range spreadsheet.Sheets {
+		titles[sheet.Properties.SheetId] = sheet.Properties.Title
+	}
+	for _, name := range valueSheets {
+		if !slices.ContainsFunc(spreadsheet.Sheets, func(sheet *sheets.Sheet) bool { return sheet.Properties.Title == name }) {
+			return nil, fmt.Errorf("sheet with name %s not found", name)
+		}
+	}
+
+	namedRanges := make(map[string]*sheets.GridRange, len(spreadsheet.NamedRanges))
+	manifest := &Manifest{}
+	for _, namedRange := range spreadsheet.NamedRanges {
+		if namedRange.Range == nil {
+			continue
+		}
+		namedRanges[namedRange.NamedRangeId] = namedRange.Range
+		manifest.NamedRanges = append(manifest.NamedRanges, NamedRangeManifest{
+			Name:  namedRange.Name,
+			Sheet: titles[namedRange.Range.SheetId],
+			Range: gridRangeA1(namedRange.Range, gridPropertiesOf(spreadsheet, namedRange.Range.SheetId)),
+		})
+	}
+
+	for _, sheet := range spreadsheet.Sheets {
+		manifest.Sheets = append(manifest.Sheets, sheetManifest(sheet, namedRanges, slices.Contains(valueSheets, sheet.Properties.Title)))
+	}
+	return manifest, nil
+}
+
+// sheetManifest builds the manifest of a sheet from its properties, protected ranges and grid data.
+//
+// Parameters:
+//   - sheet: The sheet, with the fields of manifestFields.
+//   - namedRanges: The ranges of the named ranges of the spreadsheet by ID, for the protected named ranges.
+//   - withValues: Whether to include the values of the sheet.
+//
+// Returns:
+//   - The manifest of the sheet.
+func sheetManifest(sheet *sheets.Sheet, namedRanges map[string]*sheets.GridRange, withValues bool) SheetManifest {
+	grid := sheet.Properties.GridProperties
+	if grid == nil {
+		grid = &sheets.GridProperties{}
+	}
+	manifest := SheetManifest{
+		Title:         sheet.Properties.Title,
+		RowCount:      grid.RowCount,
+		ColumnCount:   grid.ColumnCount,
+		FrozenRows:    grid.FrozenRowCount,
+		FrozenColumns: grid.FrozenColumnCount,
+	}
+
+	for _, protectedRange := range sheet.ProtectedRanges {
+		gridRange := protectedRange.Range
+		if gridRange == nil {
+			gridRange = namedRanges[protectedRange.NamedRangeId]
+		}
+		if gridRange == nil {
+			continue // A protected named range whose named range was deleted protects nothing
+		}
+		manifest.ProtectedRanges = append(manifest.ProtectedRanges, ProtectedRangeManifest{
+			Range:       gridRangeA1(gridRange, grid),
+			Description: protectedRange.Description,
+			WarningOnly: protectedRange.WarningOnly,
+		})
+	}
+
+	var validations validationBlocks
+	for _, data := range sheet.Data {
+		for i, rowData := range data.RowData {
+			row := data.StartRow + int64(i)
+			validations.addRow(row, data.StartColumn, rowData.Values)
+
+			if row == 0 {
+				manifest.Headers, manifest.HeaderFormats = headerManifest(data.StartColumn, rowData.Values)
+			}
+			if withValues {
+				manifest.Values = append(manifest.Values, rowValues(data.StartColumn, rowData.Values))
+			}
+		}
+	}
+	for _, block := range validations.blocks {
+		manifest.Validations = append(manifest.Validations, ValidationManifest{
+			Range: fmt.Sprintf("%s%d:%s%d", columnLetter(int(block.startColumn)), block.startRow+1,
+				columnLetter(int(block.endColumn-1)), block.endRow),
+			Rule: block.rule,
+		})
+	}
+
+	for len(manifest.Values) > 0 && len(manifest.Values[len(manifest.Values)-1]) == 0 {
+		manifest.Values = manifest.Values[:len(manifest.Values)-1]
+	}
+	return manifest
+}
+
+// headerManifest returns the values and the formats of the cells of the header row, trailing empty cells omitted.
+//
+// Parameters:
+//   - startColumn: The 0-based column index of the first cell.
+//   - cells: The cells of the header row.
+//
+// Returns:
+//   - The formatted values of the cells, or nil if the row has no values.
+//   - The user-entered formats of the cells, or nil if no cell has one.
+func headerManifest(startColumn int64, cells []*sheets.CellData) ([]string, []*sheets.CellFormat) {
+	headers := make([]string, startColumn, startColumn+int64(len(cells)))
+	formats := make([]*sheets.CellFormat, startColumn, startColumn+int64(len(cells)))
+	for _, cell := range cells {
+		headers = append(headers, cell.FormattedValue)
+		formats = append(formats, cell.UserEnteredFormat)
+	}
+
+	for len(headers) > 0 && headers[len(headers)-1] == "" {
+		headers = headers[:len(headers)-1]
+	}
+	for len(formats) > 0 && formats[len(formats)-1] == nil {
+		formats = formats[:len(formats)-1]
+	}
+	if len(headers) == 0 {
+		headers = nil
+	}
+	if len(formats) == 0 {
+		formats = nil
+	}
+	return headers, formats
+}
+
+// rowValues returns the user-entered values of the cells of a row, trailing empty cells omitted.
+//
+// Parameters:
+//   - startColumn: The 0-based column index of the first cell.
+//   - cells: The cells of the row.
+//
+// Returns:
+//   - The values of the row, with formulas as their text and nil for the empty cells.
+func rowValues(startColumn int64, cells []*sheets.CellData) []interface{} {
+	values := make([]interface{}, startColumn, startColumn+int64(len(cells)))
+	for _, cell := range cells {
+		values = append(values, extendedValueToInterface(cell.UserEnteredValue))
+	}
+	for len(values) > 0 && values[len(values)-1] == nil {
+		values = values[:len(values)-1]
+	}
+	return values
+}
+
+// validationBlock is a rectangular block of cells with the same data validation rule. The indexes are 0-based and
+// the end indexes are exclusive.
+type validationBlock struct {
+	startRow, endRow, startColumn, endColumn int64
+	key                                      string
+	rule                                     *sheets.DataValidationRule
+}
+
+// validationBlocks coalesces the data validation rules of the cells of a sheet, row by row, into blocks: the cells
+// of a row with the same rule form runs, and a run extends the block of the previous row with the same columns and
+// rule. A rule applied to a whole column becomes a single block instead of one per cell.
+type validationBlocks struct {
+	blocks []*validationBlock
+	open   map[string]*validationBlock // The blocks that end at the last row added, by columns and rule
+}
+
+// addRow adds the rules of the cells of a row. The rows must be added in ascending order.
+//
+// Parameters:
+//   - row: The 0-based row index.
+//   - startColumn: The 0-based column index of the first cell.
+//   - cells: The cells of the row.
+func (v *validationBlocks) addRow(row, startColumn int64, cells []*sheets.CellData) {
+	open := map[string]*validationBlock{}
+	for j := 0; j < len(cells); {
+		if cells[j].DataValidation == nil {
+			j++
+			continue
+		}
+
+		key := ruleKey(cells[j].DataValidation)
+		end := j + 1
+		for end < len(cells) && cells[end].DataValidation != nil && ruleKey(cells[end].DataValidation) == key {
+			end++
+		}
+
+		start, stop := startColumn+int64(j), startColumn+int64(end)
+		openKey := fmt.Sprintf("%d:%d:%s", start, stop, key)
+		block := v.open[openKey]
+		if block != nil && block.endRow == row {
+			block.endRow = row + 1
+		} else {
+			block = &validationBlock{startRow: row, endRow: row + 1, startColumn: start, endColumn: stop, key: key, rule: cells[j].DataValidation}
+			v.blocks = append(v.blocks, block)
+		}
+		open[openKey] = block
+		j = end
+	}
+	v.open = open
+}
+
+// ruleKey returns a key that is equal for equal data validation rules.
+func ruleKey(rule *sheets.DataValidationRule) string {
+	encoded, _ := json.Marshal(rule) // A rule decoded from a response always encodes
+	return string(encoded)
+}
+
+// gridPropertiesOf returns the grid properties of the sheet of a spreadsheet with a given ID, or nil if there is none.
+func gridPropertiesOf(spreadsheet *sheets.Spreadsheet, sheetID int64) *sheets.GridProperties {
+	for _, sheet := range spreadsheet.Sheets {
+		if sheet.Properties.SheetId == sheetID {
+			return sheet.Properties.GridProperties
+		}
+	}
+	return nil
+}
+
+// gridRangeA1 converts a grid range to A1 notation without the sheet name, the inverse of a1Range.gridRange.
+//
+// Parameters:
+//   - grid: The grid range. The end indexes are 0 when the range is unbounded on that side.
+//   - properties: The grid properties of the sheet of the range, which bound a range unbounded on both sides that
+//     does not start at the first cell (A1 notation cannot express it). It may be nil otherwise.
+//
+// Returns:
+//   - The range in A1 notation (e.g., "A1:B2", "A:B" or "2:5"), or an empty string for the whole sheet.
+func gridRangeA1(grid *sheets.GridRange, properties *sheets.GridProperties) string {
+	endRow, endColumn := grid.EndRowIndex, grid.EndColumnIndex
+	if endRow == 0 && endColumn == 0 {
+		if grid.StartRowIndex == 0 && grid.StartColumnIndex == 0 {
+			return ""
+		}
+		if properties != nil {
+			endRow, endColumn = properties.RowCount, properties.ColumnCount
+		}
+	}
+
+	var start, end string
+	if endColumn != 0 || grid.StartColumnIndex != 0 {
+		start = columnLetter(int(grid.StartColumnIndex))
+	}
+	if endColumn != 0 {
+		end = columnLetter(int(endColumn - 1))
+	}
+	if endRow != 0 || grid.StartRowIndex != 0 {
+		start += fmt.Sprint(grid.StartRowIndex + 1)
+	}
+	if endRow != 0 {
+		end += fmt.Sprint(endRow)
+	}
+	return start + ":" + end
+}
+
+// ApplyManifest recreates the structure described by a Manifest on the spreadsheet set in the GoogleSheetsClient
+// struct, usually a fresh one, in a single batch update: either the whole structure is applied or nothing is.
+//
+//   - The sheets of the manifest missing from the spreadsheet are added; the ones with the same name are resized and
+//     moved to the position of the manifest. Other sheets of the spreadsheet are left as they are.
+//   - The header rows, the values, the data validation rules, the protected ranges and the named ranges are written
+//     over what the spreadsheet has. A named range with a name already in use makes the whole update fail.
+//
+// Parameters:
+//   - m: The manifest to apply, usually from ExportManifest or decoded from JSON.
+//
+// Returns:
+//   - An error if the manifest is not valid (e.g., two sheets with the same name or a range that is not valid A1
+//     notation) or there was a problem applying it, nil otherwise.
+func (gs *GoogleSheetsClient) ApplyManifest(m *Manifest) error {
+	if gs.spreadsheetID == "" {
+		return fmt.Errorf("spreadsheet ID not set")
+	}
+
+	if m == nil {
+		return fmt.Errorf("the manifest must not be nil")
+	}
+
+	err := gs.requireScope(writeScopes...)
+	if err != nil {
+		return err
+	}
+
+	sheetProperties, err := gs.listSheetProperties()
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]int64, len(sheetProperties))
+	usedIDs := make(map[int64]bool, len(sheetProperties))
+	for _, properties := range sheetProperties {
+		existing[properties.Title] = properties.SheetId
+		usedIDs[properties.SheetId] = true
+	}
+
+	sheetIDs := make(map[string]int64, len(m.Sheets))
+	var requests []*sheets.Request
+	for i, sheet := range m.Sheets {
+		if sheet.Title == "" {
+			return fmt.Errorf("the sheet at position %d of the manifest has no name", i)
+		}
+		if _, ok := sheetIDs[sheet.Title]; ok {
+			return fmt.Errorf("the manifest has more than one sheet with name %s", sheet.Title)
+		}
+
+		sheetID, ok := existing[sheet.Title]
+		if !ok {
+			for sheetID == 0 || usedIDs[sheetID] {
+				sheetID = rand.Int64N(1<<31-1) + 1 // Chosen by the client, so the next requests can refer to the sheet
+			}
+			usedIDs[sheetID] = true
+		}
+		sheetIDs[sheet.Title] = sheetID
+
+		sheetRequests, err := manifestSheetRequests(sheet, sheetID, int64(i), !ok)
+		if err != nil {
+			return err
+		}
+		requests = append(requests, sheetRequests...)
+	}
+
+	for _, namedRange := range m.NamedRanges {
+		sheetID, ok := sheetIDs[namedRange.Sheet]
+		if !ok {
+			sheetID, ok = existing[namedRange.Sheet]
+		}
+		if !ok {
+			return fmt.Errorf("sheet with name %s of named range %s not found", namedRange.Sheet, namedRange.Name)
+		}
+
+		gridRange, err := manifestGridRange(namedRange.Range, sheetID)
+		if err != nil {
+			return err
+		}
+		requests = append(requests, &sheets.Request{
+			AddNamedRange: &sheets.AddNamedRangeRequest{
+				NamedRange: &sheets.NamedRange{Name: namedRange.Name, Range: gridRange},
+			},
+		})
+	}
+
+	if len(requests) == 0 {
+		return nil // An empty manifest, avoid spending an API call
+	}
+
+	batchUpdateRequest := &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: requests,
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	_, err = gs.service.Spreadsheets.BatchUpdate(gs.spreadsheetID, batchUpdateRequest).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("unable to apply manifest: %w", gs.apiError(err))
+	}
+
+	gs.InvalidateCaches() // The header rows of the sheets were overwritten
+	gs.audit("ApplyManifest", "", "", fmt.Sprintf("%d sheets, %d named ranges", len(m.Sheets), len(m.NamedRanges)))
+	return nil
+}
+
+// manifestSheetRequests returns the requests that recreate a sheet of a manifest.
+//
+// Parameters:
+//   - sheet: The manifest of the sheet.
+//   - sheetID: The ID of the sheet, existing or chosen for the new sheet.
+//   - index: The position of the sheet in the tab order.
+//   - add: Whether the sheet must be added.
+//
+// Returns:
+//   - The requests, or an error if a range of the manifest is not valid A1 notation.
+func manifestSheetRequests(sheet SheetManifest, sheetID, index int64, add bool) ([]*sheets.Request, error) {
+	properties := &sheets.SheetProperties{
+		SheetId: sheetID,
+		Index:   index,
+		Title:   sheet.Title,
+		GridProperties: &sheets.GridProperties{
+			RowCount:          sheet.RowCount,
+			ColumnCount:       sheet.ColumnCount,
+			FrozenRowCount:    sheet.FrozenRows,
+			FrozenColumnCount: sheet.FrozenColumns,
+			ForceSendFields:   []string{"FrozenRowCount", "FrozenColumnCount"}, // 0 unfreezes the panes of an existing sheet
+		},
+		ForceSendFields: []string{"Index"}, // 0 is the first position
+	}
+
+	var requests []*sheets.Request
+	if add {
+		requests = append(requests, &sheets.Request{AddSheet: &sheets.AddSheetRequest{Properties: properties}})
+	} else {
+		fields := "index,gridProperties.frozenRowCount,gridProperties.frozenColumnCount"
+		if sheet.RowCount > 0 {
+			fields += ",gridProperties.rowCount"
+		}
+		if sheet.ColumnCount > 0 {
+			fields += ",gridProperties.columnCount"
+		}
+		requests = append(requests, &sheets.Request{
+			UpdateSheetProperties: &sheets.UpdateSheetPropertiesRequest{Properties: properties, Fields: fields},
+		})
+	}
+
+	start := &sheets.GridCoordinate{SheetId: sheetID, ForceSendFields: []string{"SheetId"}}
+	if len(sheet.Values) > 0 {
+		rows := make([]*sheets.RowData, len(sheet.Values))
+		for i, values := range sheet.Values {
+			rows[i] = &sheets.RowData{}
+			for _, value := range values {
+				rows[i].Values = append(rows[i].Values, &sheets.CellData{UserEnteredValue: interfaceToExtendedValue(value)})
+			}
+		}
+		requests = append(requests, &sheets.Request{
+			UpdateCells: &sheets.UpdateCellsRequest{Start: start, Rows: rows, Fields: "userEnteredValue"},
+		})
+	}
+
+	if len(sheet.Headers) > 0 || len(sheet.HeaderFormats) > 0 {
+		row := &sheets.RowData{}
+		for j := 0; j < max(len(sheet.Headers), len(sheet.HeaderFormats)); j++ {
+			cell := &sheets.CellData{}
+			if j < len(sheet.Headers) {
+				cell.UserEnteredValue = interfaceToExtendedValue(sheet.Headers[j])
+			}
+			if j < len(sheet.HeaderFormats) {
+				cell.UserEnteredFormat = sheet.HeaderFormats[j]
+			}
+			row.Values = append(row.Values, cell)
+		}
+		requests = append(requests, &sheets.Request{
+			UpdateCells: &sheets.UpdateCellsRequest{Start: start, Rows: []*sheets.RowData{row}, Fields: "userEnteredValue,userEnteredFormat"},
+		})
+	}
+
+	for _, validation := range sheet.Validations {
+		gridRange, err := manifestGridRange(validation.Range, sheetID)
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, &sheets.Request{
+			SetDataValidation: &sheets.SetDataValidationRequest{Range: gridRange, Rule: validation.Rule},
+		})
+	}
+
+	for _, protectedRange := range sheet.ProtectedRanges {
+		gridRange, err := manifestGridRange(protectedRange.Range, sheetID)
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, &sheets.Request{
+			AddProtectedRange: &sheets.AddProtectedRangeRequest{
+				ProtectedRange: &sheets.ProtectedRange{
+					Range:       gridRange,
+					Description: protectedRange.Description,
+					WarningOnly: protectedRange.WarningOnly,
+				},
+			},
+		})
+	}
+	return requests, nil
+}
+
+// manifestGridRange converts a range of a manifest to the grid range of a sheet.
+//
+// Parameters:
+//   - a1: The range in A1 notation without the sheet name, or an empty string for the whole sheet.
+//   - sheetID: The ID of the sheet of the range.
+//
+// Returns:
+//   - The grid range, or an error if the range is not valid A1 notation.
+func manifestGridRange(a1 string, sheetID int64) (*sheets.GridRange, error) {
+	if a1 == "" {
+		return &sheets.GridRange{SheetId: sheetID, ForceSendFields: []string{"SheetId"}}, nil
+	}
+
+	parsedRange, err := parseA1Range(a1)
+	if err != nil {
+		return nil, err
+	}
+	return parsedRange.gridRange(sheetID), nil
+}
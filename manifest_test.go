@@ -0,0 +1,159 @@
+package gosheets
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+func TestManifestRoundTrip(t *testing.T) {
+	t.Cleanup(fake.reset)
+	t.Cleanup(resetClient)
+
+	fake.reset()
+	fake.seedSheet("Orders", [][]interface{}{{"Item", "Quantity"}, {"Pen", 2.0}, {"Total", "=SUM(B2)"}})
+	fake.seedNamedRange("Quantities", "Orders", "B2:B100")
+	resetClient()
+
+	client.SetSheetName("Orders")
+	if err := client.SetNumberValidation("B2:B100", 0, 10, true); err != nil {
+		t.Fatalf("SetNumberValidation() error = %v", err)
+	}
+	if err := client.SetAlignment("A1:B1", "CENTER", ""); err != nil {
+		t.Fatalf("SetAlignment() error = %v", err)
+	}
+	orders := fake.sheet("Orders")
+	orders.properties.GridProperties.FrozenRowCount = 1
+	orders.protectedRanges = []*sheets.ProtectedRange{{
+		ProtectedRangeId: 1,
+		Range:            &sheets.GridRange{SheetId: orders.properties.SheetId, EndRowIndex: 1},
+		Description:      "Header",
+		WarningOnly:      true,
+	}}
+	fake.sheet("Sheet1").protectedRanges = []*sheets.ProtectedRange{{
+		ProtectedRangeId: 2,
+		Range:            &sheets.GridRange{SheetId: fake.sheet("Sheet1").properties.SheetId},
+	}}
+
+	exported, err := client.ExportManifest("Orders")
+	if err != nil {
+		t.Fatalf("ExportManifest() error = %v", err)
+	}
+
+	if got := len(exported.Sheets); got != 2 {
+		t.Fatalf("len(Sheets) = %d, want 2", got)
+	}
+	sheet1, orderSheet := exported.Sheets[0], exported.Sheets[1]
+	if sheet1.Values != nil {
+		t.Errorf("Sheet1 values = %v, want none", sheet1.Values)
+	}
+	if want := []ProtectedRangeManifest{{Range: ""}}; !reflect.DeepEqual(sheet1.ProtectedRanges, want) {
+		t.Errorf("Sheet1 protected ranges = %+v, want %+v", sheet1.ProtectedRanges, want)
+	}
+	if want := []string{"Item", "Quantity"}; !reflect.DeepEqual(orderSheet.Headers, want) {
+		t.Errorf("headers = %v, want %v", orderSheet.Headers, want)
+	}
+	if len(orderSheet.HeaderFormats) != 2 || orderSheet.HeaderFormats[0].HorizontalAlignment != "CENTER" {
+		t.Errorf("header formats = %+v, want 2 centered cells", orderSheet.HeaderFormats)
+	}
+	if len(orderSheet.Validations) != 1 || orderSheet.Validations[0].Range != "B2:B100" {
+		t.Errorf("validations = %+v, want a single rule over B2:B100", orderSheet.Validations)
+	}
+	if want := []ProtectedRangeManifest{{Range: "1:1", Description: "Header", WarningOnly: true}}; !reflect.DeepEqual(orderSheet.ProtectedRanges, want) {
+		t.Errorf("protected ranges = %+v, want %+v", orderSheet.ProtectedRanges, want)
+	}
+	if orderSheet.FrozenRows != 1 {
+		t.Errorf("frozen rows = %d, want 1", orderSheet.FrozenRows)
+	}
+	if want := []NamedRangeManifest{{Name: "Quantities", Sheet: "Orders", Range: "B2:B100"}}; !reflect.DeepEqual(exported.NamedRanges, want) {
+		t.Errorf("named ranges = %+v, want %+v", exported.NamedRanges, want)
+	}
+
+	encoded, err := json.Marshal(exported)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var decoded Manifest
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	fake.addSpreadsheet("COPY_ID")
+	client.SetSpreadsheetID("COPY_ID")
+	if err := client.ApplyManifest(&decoded); err != nil {
+		t.Fatalf("ApplyManifest() error = %v", err)
+	}
+
+	copied, err := client.ExportManifest("Orders")
+	if err != nil {
+		t.Fatalf("ExportManifest() of the copy error = %v", err)
+	}
+	copiedEncoded, err := json.Marshal(copied)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if string(copiedEncoded) != string(encoded) {
+		t.Errorf("manifest of the copy = %s, want %s", copiedEncoded, encoded)
+	}
+}
+
+func TestApplyManifestInvalid(t *testing.T) {
+	t.Cleanup(fake.reset)
+
+	// Test cases
+	tests := []struct {
+		name     string
+		manifest *Manifest
+		wantErr  bool
+	}{
+		{
+			name:     "Nil manifest",
+			manifest: nil,
+			wantErr:  true,
+		},
+		{
+			name:     "Sheet without name",
+			manifest: &Manifest{Sheets: []SheetManifest{{Title: ""}}},
+			wantErr:  true,
+		},
+		{
+			name:     "Two sheets with the same name",
+			manifest: &Manifest{Sheets: []SheetManifest{{Title: "Data"}, {Title: "Data"}}},
+			wantErr:  true,
+		},
+		{
+			name: "Invalid validation range",
+			manifest: &Manifest{Sheets: []SheetManifest{{
+				Title:       "Data",
+				Validations: []ValidationManifest{{Range: "B2:A1", Rule: &sheets.DataValidationRule{}}},
+			}}},
+			wantErr: true,
+		},
+		{
+			name:     "Named range of a missing sheet",
+			manifest: &Manifest{NamedRanges: []NamedRangeManifest{{Name: "Totals", Sheet: "Missing", Range: "A1"}}},
+			wantErr:  true,
+		},
+		{
+			name:     "Named range of an existing sheet",
+			manifest: &Manifest{NamedRanges: []NamedRangeManifest{{Name: "Totals", Sheet: "Sheet1", Range: "A1"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.reset()
+			resetClient()
+
+			err := client.ApplyManifest(tt.manifest)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ApplyManifest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && len(fake.requests) != 0 {
+				t.Errorf("requests = %d, want none for an invalid manifest", len(fake.requests))
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package gosheets
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// AppendMapped appends records whose fields are named after the headers of another source (e.g., the header row
+// of a CSV file) to the end of the current set sheet in the GoogleSheetsClient struct. Each field is moved to the
+// column of the sheet header it is mapped to, so the rows follow the column order of the sheet whatever the order
+// of the source. The header row is assumed to be the first row of the sheet, starting at column A.
+//
+// Parameters:
+//   - rows: The records to append, as source header to value maps. The fields whose source header is not mapped
+//     are dropped.
+//   - columnMapping: The sheet header of each source header (e.g., {"e-mail": "Email"}). The sheet columns no source
+//     header is mapped to are left blank.
+//   - range_: The cell used to search for existing data and find a "table" within that range where the data will be
+//     appended (e.g., "A1"), or an empty string to use the table range of the sheet (see SetTableRange).
+//
+// Returns:
+//   - An error wrapping ErrHeaderNotFound and listing every mapped sheet header that is not in the header row.
+//   - An error if two source headers are mapped to the same sheet header or there was a problem appending the rows,
+//     nil otherwise. Empty rows are a no-op that returns nil without calling the API.
+func (gs *GoogleSheetsClient) AppendMapped(rows []map[string]string, columnMapping map[string]string, range_ string) error {
+	if len(rows) == 0 {
+		return nil // Nothing to append, avoid spending an API call
+	}
+	if len(columnMapping) == 0 {
+		return fmt.Errorf("invalid column mapping: it must map at least one source header")
+	}
+
+	// Sorted, so the errors do not depend on the iteration order of the map
+	sources := make([]string, 0, len(columnMapping))
+	for source := range columnMapping {
+		sources = append(sources, source)
+	}
+	slices.Sort(sources)
+
+	mappedBy := make(map[string]string, len(columnMapping))
+	for _, source := range sources {
+		target := columnMapping[source]
+		if other, ok := mappedBy[target]; ok {
+			return fmt.Errorf("invalid column mapping: %q and %q are both mapped to %q", other, source, target)
+		}
+		mappedBy[target] = source
+	}
+
+	err := gs.resolveSheetName()
+	if err != nil {
+		return err
+	}
+
+	// The column of each source header, and the sheet headers missing from the header row
+	columns := make(map[string]int, len(columnMapping))
+	var missing []string
+	width := 0
+	err = gs.withHeaders(func(headers []string) error {
+		missing = nil
+		width = len(headers)
+		for _, source := range sources {
+			columns[source] = slices.Index(headers, columnMapping[source])
+			if columns[source] == -1 {
+				missing = append(missing, fmt.Sprintf("%q", columnMapping[source]))
+			}
+		}
+		if len(missing) > 0 {
+			return errStaleHeaders
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errStaleHeaders) {
+		return err
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s", ErrHeaderNotFound, strings.Join(missing, ", "))
+	}
+
+	data := make([][]interface{}, len(rows))
+	for i, record := range rows {
+		row := make([]interface{}, width)
+		for j := range row {
+			row[j] = "" // Blank for the sheet columns without a mapped field
+		}
+		for source, value := range record {
+			if column, ok := columns[source]; ok {
+				row[column] = value
+			}
+		}
+		data[i] = row
+	}
+
+	return gs.AppendData(data, range_)
+}
@@ -0,0 +1,84 @@
+package gosheets
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAppendMapped(t *testing.T) {
+	t.Cleanup(fake.reset)
+
+	rows := []map[string]string{
+		{"e-mail": "ana@example.com", "full name": "Ana", "source": "csv"},
+		{"full name": "Bob", "e-mail": "bob@example.com"},
+	}
+
+	// Test cases
+	tests := []struct {
+		name      string
+		rows      []map[string]string
+		mapping   map[string]string
+		wantRows  [][]interface{}
+		wantErr   bool
+		wantErrIs error
+		wantInErr []string
+	}{
+		{
+			name:    "Fields follow the order of the sheet",
+			rows:    rows,
+			mapping: map[string]string{"e-mail": "Email", "full name": "Name"},
+			wantRows: [][]interface{}{
+				{"Name", "Phone", "Email"},
+				{"Ana", "", "ana@example.com"},
+				{"Bob", "", "bob@example.com"},
+			},
+		},
+		{
+			name:      "Unknown sheet headers are reported together",
+			rows:      rows,
+			mapping:   map[string]string{"e-mail": "Mail", "full name": "Full name", "source": "Phone"},
+			wantErr:   true,
+			wantErrIs: ErrHeaderNotFound,
+			wantInErr: []string{`"Mail"`, `"Full name"`},
+		},
+		{
+			name:    "Two source headers mapped to the same sheet header",
+			rows:    rows,
+			mapping: map[string]string{"e-mail": "Email", "source": "Email"},
+			wantErr: true,
+		},
+		{
+			name:     "No rows",
+			mapping:  map[string]string{"e-mail": "Email"},
+			wantRows: [][]interface{}{{"Name", "Phone", "Email"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.reset()
+			fake.seedSheet("Contacts", [][]interface{}{{"Name", "Phone", "Email"}})
+			resetClient()
+			client.SetSheetName("Contacts")
+
+			err := client.AppendMapped(tt.rows, tt.mapping, "A1")
+			if (err != nil) != tt.wantErr || (tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs)) {
+				t.Fatalf("AppendMapped() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, want := range tt.wantInErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("AppendMapped() error = %v, want it to mention %s", err, want)
+				}
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if got := fake.sheet("Contacts").values; !reflect.DeepEqual(got, tt.wantRows) {
+				t.Errorf("sheet values = %v, want %v", got, tt.wantRows)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package gosheets
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// MatchOption configures how the methods that search for a value (e.g., FindRowNumber, CountInColumn, DeleteRow
+// and MatchColumn) compare the cells with it. Without options the string representation of the cell must be
+// exactly equal to the value.
+type MatchOption func(*matchOptions)
+
+// matchOptions holds the settings applied by the MatchOption values.
+//
+//   - The ignoreCase field is used to compare the text case-insensitively.
+//   - The trimSpace field is used to ignore leading and trailing whitespace.
+//   - The numeric field is used to compare numbers by value instead of by text.
+//   - The regexp field is used to match the cells with a regular expression instead of the searched value.
+//   - The locale field is used to read the numbers compared by NumericEqual (see NumberLocale).
+type matchOptions struct {
+	ignoreCase bool
+	trimSpace  bool
+	numeric    bool
+	regexp     *regexp.Regexp
+	locale     string
+}
+
+// valueMatcher reports whether a cell matches a searched value.
+type valueMatcher func(cell interface{}) bool
+
+// IgnoreCase compares the cells with the value case-insensitively, so "TRUE" matches "true".
+func IgnoreCase() MatchOption {
+	return func(o *matchOptions) {
+		o.ignoreCase = true
+	}
+}
+
+// TrimSpace ignores the leading and trailing whitespace of the cells and of the value.
+func TrimSpace() MatchOption {
+	return func(o *matchOptions) {
+		o.trimSpace = true
+	}
+}
+
+// NumericEqual compares the cells with the value as numbers when both parse as numbers, so "100", "100.0" and
+// "1e2" all match 100. Cells or values that are not numbers are compared as text.
+func NumericEqual() MatchOption {
+	return func(o *matchOptions) {
+		o.numeric = true
+	}
+}
+
+// NumberLocale reads the numbers compared by NumericEqual with the separators of a locale, so "1.234,56" matches
+// 1234.56 with "de_DE". Without it the numbers are read as in en_US by the functions that take the data (e.g.,
+// FindRowNumber), and with the locale of the client (see Locale) by the client methods (e.g., DeleteRowByValue).
+//
+// Parameters:
+//   - locale: The locale of the cells and the value (e.g., "en_US" or "de_DE").
+func NumberLocale(locale string) MatchOption {
+	return func(o *matchOptions) {
+		o.locale = locale
+	}
+}
+
+// Regexp matches the cells with a regular expression instead of the searched value, which is then ignored. The
+// expression is compiled by the caller (e.g., with regexp.MustCompile), so an invalid one is reported there instead
+// of matching no cell. Combined with IgnoreCase the expression is matched case-insensitively.
+//
+// Parameters:
+//   - re: The regular expression the cells must match. It must not be nil.
+func Regexp(re *regexp.Regexp) MatchOption {
+	return func(o *matchOptions) {
+		o.regexp = re
+	}
+}
+
+// MatchColumn returns a predicate for DeleteRowsWhere that selects the rows whose cell in a given column matches
+// a value, with the same comparison options as FindRowNumber.
+//
+// Parameters:
+//   - column: The column letter in which to search for the value.
+//   - value: The value to search for.
+//   - opts: Optional settings for the comparison of the cells with the value.
+//
+// Returns:
+//   - A function that reports whether a row matches.
+func MatchColumn(column, value string, opts ...MatchOption) func(row []interface{}) bool {
+	matcher := newMatcher(value, opts)
+	return func(row []interface{}) bool {
+		return cellMatches(row, column, matcher)
+	}
+}
+
+// newMatcher builds the function that compares cells with a searched value.
+//
+// Parameters:
+//   - value: The value to search for.
+//   - opts: The comparison options.
+//
+// Returns:
+//   - The matcher.
+func newMatcher(value string, opts []MatchOption) valueMatcher {
+	options := newMatchOptions(opts)
+
+	normalize := func(text string) string {
+		if options.trimSpace {
+			text = strings.TrimSpace(text)
+		}
+		return text
+	}
+
+	if options.regexp != nil {
+		re := options.regexp
+		if options.ignoreCase {
+			re = regexp.MustCompile("(?i)" + re.String()) // Valid, since re compiled
+		}
+		return func(cell interface{}) bool {
+			return re.MatchString(normalize(fmt.Sprintf("%v", cell)))
+		}
+	}
+
+	want := normalize(value)
+	wantNumber, wantErr := parseMatchNumber(want, options.locale)
+
+	return func(cell interface{}) bool {
+		text := normalize(fmt.Sprintf("%v", cell))
+
+		if options.numeric && wantErr == nil {
+			if number, err := parseMatchNumber(text, options.locale); err == nil {
+				return number == wantNumber
+			}
+		}
+
+		if options.ignoreCase {
+			return strings.EqualFold(text, want)
+		}
+		return text == want
+	}
+}
+
+// newMatchOptions applies the given options to the default comparison settings.
+func newMatchOptions(opts []MatchOption) *matchOptions {
+	options := &matchOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options
+}
+
+// parseMatchNumber parses a number compared by NumericEqual.
+//
+// Parameters:
+//   - text: The text to parse.
+//   - locale: The locale of the text, or an empty string to accept any number strconv.ParseFloat accepts.
+//
+// Returns:
+//   - The number, or an error if the text is not a number.
+func parseMatchNumber(text, locale string) (float64, error) {
+	if locale == "" {
+		return strconv.ParseFloat(strings.TrimSpace(text), 64)
+	}
+	return parseLocalizedNumber(text, locale)
+}
+
+// cellMatches reports whether the cell of a row in a given column satisfies a matcher.
+//
+// Parameters:
+//   - row: The row to check.
+//   - column: The column letter of the cell.
+//   - matcher: The function that reports whether the cell matches.
+//
+// Returns:
+//   - true if the cell exists and matches, false otherwise.
+func cellMatches(row []interface{}, column string, matcher valueMatcher) bool {
+	if len(row) < columnIndex(column)+1 {
+		return false // Avoid index out of range error if the row does not have enough columns
+	}
+	return matcher(row[columnIndex(column)])
+}
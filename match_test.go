@@ -0,0 +1,118 @@
+package gosheets
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchOptions(t *testing.T) {
+	data := [][]interface{}{
+		{"Name", "Amount"},
+		{"  Alice ", "100.0"},
+		{"BOB", 42.5},
+		{"carol-01", "n/a"},
+	}
+
+	// Test cases
+	tests := []struct {
+		name   string
+		column string
+		value  string
+		opts   []MatchOption
+		want   int
+	}{
+		{
+			name:   "Exact match by default",
+			column: "A",
+			value:  "alice",
+			want:   -1,
+		},
+		{
+			name:   "Trim whitespace",
+			column: "A",
+			value:  "Alice",
+			opts:   []MatchOption{TrimSpace()},
+			want:   2,
+		},
+		{
+			name:   "Case-insensitive",
+			column: "A",
+			value:  "bob",
+			opts:   []MatchOption{IgnoreCase()},
+			want:   3,
+		},
+		{
+			name:   "Numeric equality with a string cell",
+			column: "B",
+			value:  "1e2",
+			opts:   []MatchOption{NumericEqual()},
+			want:   2,
+		},
+		{
+			name:   "Numeric equality with a number cell",
+			column: "B",
+			value:  "42.50",
+			opts:   []MatchOption{NumericEqual()},
+			want:   3,
+		},
+		{
+			name:   "Numeric equality falls back to text",
+			column: "B",
+			value:  "n/a",
+			opts:   []MatchOption{NumericEqual()},
+			want:   4,
+		},
+		{
+			name:   "Regular expression",
+			column: "A",
+			opts:   []MatchOption{Regexp(regexp.MustCompile(`^CAROL-\d+$`)), IgnoreCase()},
+			want:   4,
+		},
+		{
+			name:   "Regular expression ignores the value",
+			column: "A",
+			value:  "BOB",
+			opts:   []MatchOption{Regexp(regexp.MustCompile(`^x`))},
+			want:   -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FindRowNumber(data, tt.column, tt.value, tt.opts...); got != tt.want {
+				t.Errorf("FindRowNumber() = %v, want %v", got, tt.want)
+			}
+
+			matched := -1
+			for i, row := range data {
+				if MatchColumn(tt.column, tt.value, tt.opts...)(row) {
+					matched = i + 1
+					break
+				}
+			}
+			if matched != tt.want {
+				t.Errorf("MatchColumn() matched row %v, want %v", matched, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeleteRowWithMatch(t *testing.T) {
+	fake.reset()
+	t.Cleanup(fake.reset)
+	resetClient()
+
+	data := [][]interface{}{{"Header1", "Header2"}, {"Value1", "Value2"}}
+
+	if err := client.DeleteRow(data, "A", " value1 ", WithMatch(TrimSpace(), IgnoreCase())); err != nil {
+		t.Fatalf("DeleteRow() error = %v, want nil", err)
+	}
+
+	got, err := client.ReadData("A:B")
+	if err != nil {
+		t.Fatalf("ReadData() error = %v", err)
+	}
+	if len(got) != 1 {
+		t.Errorf("ReadData() = %v, want only the header row", got)
+	}
+}
@@ -0,0 +1,135 @@
+package gosheets
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// GetDeveloperMetadata reads the values of the developer metadata with a given key in the spreadsheet set in the
+// GoogleSheetsClient struct, e.g., a schema version stamped with SetDeveloperMetadata.
+//
+// Parameters:
+//   - key: The key of the metadata (e.g., "schema-version").
+//
+// Returns:
+//   - The values of the metadata with the key, empty if there is none.
+//   - An error if there was a problem searching the metadata, nil otherwise.
+func (gs *GoogleSheetsClient) GetDeveloperMetadata(key string) ([]string, error) {
+	matches, err := gs.searchDeveloperMetadata(developerMetadataLookup(key))
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]string, len(matches))
+	for i, metadata := range matches {
+		values[i] = metadata.MetadataValue
+	}
+	return values, nil
+}
+
+// SetDeveloperMetadata stores a value under a key in the developer metadata of the spreadsheet set in the
+// GoogleSheetsClient struct, replacing the value of the key if it already has one. The metadata is attached to
+// the spreadsheet itself and is visible to every application with access to it.
+//
+// Parameters:
+//   - key: The key of the metadata (e.g., "schema-version").
+//   - value: The value to store.
+//
+// Returns:
+//   - An error if the key is empty or there was a problem storing the metadata, nil otherwise.
+func (gs *GoogleSheetsClient) SetDeveloperMetadata(key, value string) error {
+	if key == "" {
+		return fmt.Errorf("the metadata key must not be empty")
+	}
+
+	err := gs.requireScope(writeScopes...)
+	if err != nil {
+		return err
+	}
+
+	matches, err := gs.searchDeveloperMetadata(developerMetadataLookup(key))
+	if err != nil {
+		return err
+	}
+
+	var request *sheets.Request
+	if len(matches) == 0 {
+		request = &sheets.Request{
+			CreateDeveloperMetadata: &sheets.CreateDeveloperMetadataRequest{
+				DeveloperMetadata: &sheets.DeveloperMetadata{
+					MetadataKey:   key,
+					MetadataValue: value,
+					Location:      &sheets.DeveloperMetadataLocation{Spreadsheet: true},
+					Visibility:    "DOCUMENT",
+				},
+			},
+		}
+	} else {
+		request = &sheets.Request{
+			UpdateDeveloperMetadata: &sheets.UpdateDeveloperMetadataRequest{
+				DataFilters: []*sheets.DataFilter{{DeveloperMetadataLookup: developerMetadataLookup(key)}},
+				DeveloperMetadata: &sheets.DeveloperMetadata{
+					MetadataValue: value,
+				},
+				Fields: "metadataValue",
+			},
+		}
+	}
+
+	batchUpdateRequest := &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{request},
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	_, err = gs.service.Spreadsheets.BatchUpdate(gs.spreadsheetID, batchUpdateRequest).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("unable to set developer metadata: %w", gs.apiError(err))
+	}
+
+	gs.audit("SetDeveloperMetadata", gs.sheetName, "spreadsheet", "key "+key)
+	return nil
+}
+
+// searchDeveloperMetadata finds the developer metadata of the spreadsheet set in the GoogleSheetsClient struct that
+// matches a lookup.
+//
+// Parameters:
+//   - lookup: The lookup of the metadata (e.g., developerMetadataLookup for the metadata of the spreadsheet with a key).
+//
+// Returns:
+//   - The matching metadata, or an error if there was a problem searching it.
+func (gs *GoogleSheetsClient) searchDeveloperMetadata(lookup *sheets.DeveloperMetadataLookup) ([]*sheets.DeveloperMetadata, error) {
+	if gs.spreadsheetID == "" {
+		return nil, fmt.Errorf("spreadsheet ID not set")
+	}
+
+	request := &sheets.SearchDeveloperMetadataRequest{
+		DataFilters: []*sheets.DataFilter{{DeveloperMetadataLookup: lookup}},
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	resp, err := gs.service.Spreadsheets.DeveloperMetadata.Search(gs.spreadsheetID, request).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("unable to search developer metadata: %w", gs.apiError(err))
+	}
+
+	matches := make([]*sheets.DeveloperMetadata, len(resp.MatchedDeveloperMetadata))
+	for i, match := range resp.MatchedDeveloperMetadata {
+		matches[i] = match.DeveloperMetadata
+	}
+	return matches, nil
+}
+
+// developerMetadataLookup returns the lookup of the developer metadata with a given key attached to the spreadsheet.
+func developerMetadataLookup(key string) *sheets.DeveloperMetadataLookup {
+	return &sheets.DeveloperMetadataLookup{
+		MetadataKey:  key,
+		LocationType: "SPREADSHEET",
+	}
+}
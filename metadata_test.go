@@ -0,0 +1,76 @@
+package gosheets
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDeveloperMetadata(t *testing.T) {
+	fake.reset()
+	t.Cleanup(fake.reset)
+
+	// Test cases, run in order against the same spreadsheet
+	tests := []struct {
+		name       string
+		key        string
+		value      string
+		wantValues []string
+		wantErr    bool
+	}{
+		{
+			name:       "Create metadata",
+			key:        "schema-version",
+			value:      "1",
+			wantValues: []string{"1"},
+		},
+		{
+			name:       "Replace the value of an existing key",
+			key:        "schema-version",
+			value:      "2",
+			wantValues: []string{"2"},
+		},
+		{
+			name:       "Another key",
+			key:        "owner",
+			value:      "billing",
+			wantValues: []string{"billing"},
+		},
+		{
+			name:    "Empty key",
+			key:     "",
+			value:   "1",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetClient()
+
+			err := client.SetDeveloperMetadata(tt.key, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SetDeveloperMetadata() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			got, err := client.GetDeveloperMetadata(tt.key)
+			if err != nil {
+				t.Fatalf("GetDeveloperMetadata() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.wantValues) {
+				t.Errorf("GetDeveloperMetadata() = %v, want %v", got, tt.wantValues)
+			}
+		})
+	}
+
+	t.Run("Missing key", func(t *testing.T) {
+		resetClient()
+
+		got, err := client.GetDeveloperMetadata("missing")
+		if err != nil || len(got) != 0 {
+			t.Errorf("GetDeveloperMetadata() = %v, %v, want no values", got, err)
+		}
+	})
+}
@@ -0,0 +1,116 @@
+package gosheets
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// WriteAtNamedRange writes a block of data starting at the top-left cell of a named range of the spreadsheet set in
+// the GoogleSheetsClient struct, so templates can mark where a block goes by name instead of by position. The named
+// range may be on any sheet of the spreadsheet, and the data is written from its first cell whatever its size: the
+// cells outside the block keep their values.
+//
+// Parameters:
+//   - name: The name of the named range (e.g., "SummaryStart").
+//   - data: A 2D slice representing the data to write. Each inner slice represents a row of data, with each element
+//     representing a cell value.
+//   - opts: Optional settings for the write (e.g., ValueInput to parse formulas and dates).
+//
+// Returns:
+//   - ErrNamedRangeNotFound if the spreadsheet has no named range with the name.
+//   - An error if there was a problem writing the data, nil otherwise. Empty or nil data is a no-op that returns nil
+//     without calling the API.
+func (gs *GoogleSheetsClient) WriteAtNamedRange(name string, data [][]interface{}, opts ...WriteOption) error {
+	options := newWriteOptions(opts)
+
+	if len(data) == 0 {
+		return nil // Nothing to write, avoid spending an API call
+	}
+
+	if gs.spreadsheetID == "" {
+		return fmt.Errorf("spreadsheet ID not set")
+	}
+
+	err := gs.requireScope(writeScopes...)
+	if err != nil {
+		return err
+	}
+
+	inputOption, err := gs.inputOption(options)
+	if err != nil {
+		return err
+	}
+
+	sheetName, row, column, err := gs.namedRangeStart(name)
+	if err != nil {
+		return err
+	}
+
+	if options.protectionCheck {
+		width := 0
+		for _, values := range data {
+			width = max(width, len(values))
+		}
+		written := a1Range{StartRow: row, EndRow: row + int64(len(data)), StartColumn: column, EndColumn: column + int64(max(width, 1))}
+		err = gs.checkProtection(sheetName, fmt.Sprintf("named range %s", name), written)
+		if err != nil {
+			return err
+		}
+	}
+
+	valueRange := &sheets.ValueRange{
+		Values: data,
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	target := fmt.Sprintf("%s!%s%d", quoteSheetName(sheetName), columnLetter(int(column)), row+1)
+	_, err = gs.service.Spreadsheets.Values.Update(gs.spreadsheetID, target, valueRange).ValueInputOption(inputOption).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("unable to write data to Google Sheets: %w", gs.apiError(err))
+	}
+
+	if row == 0 {
+		gs.headerCache.invalidate(headerCacheKey{spreadsheetID: gs.spreadsheetID, sheetName: sheetName}) // The header row was overwritten
+	}
+	gs.audit("WriteAtNamedRange", sheetName, name, fmt.Sprintf("%d rows", len(data)))
+	return nil
+}
+
+// namedRangeStart finds the top-left cell of a named range of the spreadsheet set in the GoogleSheetsClient struct.
+//
+// Parameters:
+//   - name: The name of the named range.
+//
+// Returns:
+//   - The name of the sheet of the named range.
+//   - The 0-based row and column indexes of the first cell of the named range.
+//   - ErrNamedRangeNotFound if there is no named range with the name, or an error if there was a problem retrieving
+//     the named ranges.
+func (gs *GoogleSheetsClient) namedRangeStart(name string) (string, int64, int64, error) {
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	spreadsheet, err := gs.service.Spreadsheets.Get(gs.spreadsheetID).Fields("namedRanges", "sheets.properties(sheetId,title)").Context(ctx).Do()
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("unable to retrieve named ranges: %w", gs.apiError(err))
+	}
+
+	for _, namedRange := range spreadsheet.NamedRanges {
+		if namedRange.Name != name || namedRange.Range == nil {
+			continue
+		}
+
+		for _, sheet := range spreadsheet.Sheets {
+			if sheet.Properties.SheetId == namedRange.Range.SheetId {
+				return sheet.Properties.Title, namedRange.Range.StartRowIndex, namedRange.Range.StartColumnIndex, nil
+			}
+		}
+		return "", 0, 0, fmt.Errorf("sheet of named range %q not found", name)
+	}
+
+	return "", 0, 0, fmt.Errorf("%w: %q", ErrNamedRangeNotFound, name)
+}
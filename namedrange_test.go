@@ -0,0 +1,70 @@
+package gosheets
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestWriteAtNamedRange(t *testing.T) {
+	t.Cleanup(fake.reset)
+
+	// Test cases
+	tests := []struct {
+		name       string
+		rangeName  string
+		data       [][]interface{}
+		wantSheet  string
+		wantValues [][]interface{}
+		wantErr    error
+	}{
+		{
+			name:      "Write from the first cell of the named range",
+			rangeName: "Totals",
+			data:      [][]interface{}{{"Total", "10"}, {"Average", "5"}},
+			wantSheet: "Monthly Report",
+			wantValues: [][]interface{}{
+				{"Title"},
+				nil,
+				{nil, "Total", "10"},
+				{nil, "Average", "5"},
+			},
+		},
+		{
+			name:       "Empty data is a no-op",
+			rangeName:  "Totals",
+			data:       nil,
+			wantSheet:  "Monthly Report",
+			wantValues: [][]interface{}{{"Title"}},
+		},
+		{
+			name:      "Missing named range",
+			rangeName: "Missing",
+			data:      [][]interface{}{{"Total", "10"}},
+			wantErr:   ErrNamedRangeNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.reset()
+			// A sheet name that needs quotes in A1 notation
+			fake.seedSheet("Monthly Report", [][]interface{}{{"Title"}})
+			fake.seedNamedRange("Totals", "Monthly Report", "B3:C4")
+			resetClient()
+
+			err := client.WriteAtNamedRange(tt.rangeName, tt.data)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("WriteAtNamedRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			got := fake.sheet(tt.wantSheet).values
+			if !reflect.DeepEqual(got, tt.wantValues) {
+				t.Errorf("values = %v, want %v", got, tt.wantValues)
+			}
+		})
+	}
+}
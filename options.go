@@ -0,0 +1,616 @@
+package gosheets
+
+import (
+	"net/http"
+	"time"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// OAuth2 scopes accepted by WithScopes.
+const (
+	// ScopeSheets allows reading and editing all the spreadsheets the credentials have access to. It is the default scope.
+	ScopeSheets = sheets.SpreadsheetsScope
+
+	// ScopeSheetsReadOnly allows only reading the spreadsheets the credentials have access to.
+	ScopeSheetsReadOnly = sheets.SpreadsheetsReadonlyScope
+
+	// ScopeDriveFile allows reading and editing only the Google Drive files created or opened by the application.
+	ScopeDriveFile = sheets.DriveFileScope
+
+	// ScopeDrive allows full access to the Google Drive files the credentials have access to, including spreadsheets.
+	ScopeDrive = sheets.DriveScope
+)
+
+// writeScopes are the scopes that allow editing spreadsheets.
+var writeScopes = []string{ScopeSheets, ScopeDrive, ScopeDriveFile}
+
+// ClientOption configures a GoogleSheetsClient when it is created with NewGoogleSheetsClient.
+type ClientOption func(*clientConfig)
+
+// clientConfig holds the settings applied by the ClientOption values passed to NewGoogleSheetsClient.
+//
+//   - The subject field is used to store the email of the user impersonated by the service account.
+//   - The scopes field is used to store the OAuth2 scopes requested for the tokens.
+//   - The endpoint field is used to store the base URL of the Google Sheets API.
+//   - The httpClient field is used to store the HTTP client the OAuth2 transport is built on.
+//   - The quotaProject field is used to store the Google Cloud project the API usage is billed to.
+//   - The userAgent field is used to store the product added to the User-Agent header of the API requests.
+//   - The defaultTimeout field is used to store the timeout of the API calls made without a deadline.
+//   - The maxRetries field is used to store the number of times a failed API call is retried.
+//   - The retryAttempts and retryElapsed fields are used to store the retry budget of the operations.
+//   - The headerCacheTTL field is used to store the time the header rows of the sheets are cached.
+//   - The auditSheet field is used to store the name of the sheet the writes are logged to.
+//   - The actor field is used to store the name of the caller recorded in the audit log.
+//   - The warningHook field is used to store the function called with the problems that do not fail an operation.
+//   - The locale field is used to store the locale the formatted numbers are read with.
+//   - The valueInputOption field is used to store how the written values are interpreted by default.
+type clientConfig struct {
+	subject          string
+	scopes           []string
+	endpoint         string
+	httpClient       *http.Client
+	quotaProject     string
+	userAgent        string
+	defaultTimeout   time.Duration
+	maxRetries       int
+	retryAttempts    int
+	retryElapsed     time.Duration
+	headerCacheTTL   time.Duration
+	auditSheet       string
+	actor            string
+	warningHook      func(error)
+	locale           string
+	valueInputOption string
+}
+
+// WithLocale sets the locale the client reads the formatted numbers with (e.g., in CellFloat and in the NumericEqual
+// comparisons of DeleteRowByValue), instead of the locale of the spreadsheet read from its properties. Use it when
+// the cells are formatted for another locale than the one of the spreadsheet.
+//
+// Parameters:
+//   - locale: The locale of the numbers (e.g., "en_US" or "de_DE").
+//
+// Returns:
+//   - A ClientOption to pass to NewGoogleSheetsClient.
+func WithLocale(locale string) ClientOption {
+	return func(c *clientConfig) {
+		c.locale = locale
+	}
+}
+
+// Value input options accepted by WithValueInputOption and ValueInput.
+const (
+	// InputRaw stores the values as they are: text starting with "=" stays text, and "1/2/2024" is not a date. It is
+	// the default.
+	InputRaw = "RAW"
+
+	// InputUserEntered parses the values as if a user typed them in the sheet: text starting with "=" is a formula,
+	// and numbers, dates and percentages are parsed with the locale of the spreadsheet.
+	InputUserEntered = "USER_ENTERED"
+)
+
+// WithValueInputOption sets how the values written by the append and update methods (e.g., AppendData, BulkUpsert,
+// UpdateColumns and WriteAtNamedRange) are interpreted by default. Without it the values are written with InputRaw,
+// as in previous versions. Pass ValueInput to a method to override it for a single write. The renames of headers
+// and the rows of the audit log are always written as they are, and the values written as dates with
+// WithSerialDates are not affected.
+//
+// Parameters:
+//   - option: InputRaw or InputUserEntered.
+//
+// Returns:
+//   - A ClientOption to pass to NewGoogleSheetsClient, which fails with any other option.
+func WithValueInputOption(option string) ClientOption {
+	return func(c *clientConfig) {
+		c.valueInputOption = option
+	}
+}
+
+// WithImpersonationSubject makes the service account impersonate the given user through Google Workspace
+// domain-wide delegation, so the client can access the spreadsheets that user can access. Domain-wide delegation
+// must be enabled for the service account and its scopes authorized in the Google Workspace admin console,
+// otherwise the requests fail with an error wrapping ErrDelegationNotAuthorized.
+//
+// Parameters:
+//   - email: The email of the user to impersonate.
+//
+// Returns:
+//   - A ClientOption to pass to NewGoogleSheetsClient.
+func WithImpersonationSubject(email string) ClientOption {
+	return func(c *clientConfig) {
+		c.subject = email
+	}
+}
+
+// WithScopes replaces the default OAuth2 scopes (ScopeSheets) requested by the client. Use ScopeSheetsReadOnly for
+// read-only clients, or add ScopeDrive or ScopeDriveFile for features that need access to Google Drive. Methods that
+// need a scope the client was not created with return an error wrapping ErrMissingScope instead of calling the API.
+//
+// Parameters:
+//   - scopes: The OAuth2 scopes to request (e.g., ScopeSheets, ScopeDriveFile).
+//
+// Returns:
+//   - A ClientOption to pass to NewGoogleSheetsClient.
+func WithScopes(scopes ...string) ClientOption {
+	return func(c *clientConfig) {
+		c.scopes = scopes
+	}
+}
+
+// WithEndpoint sends the requests to the given base URL instead of the public Google Sheets API endpoint, e.g., to
+// route them through a private Google endpoint or to a local fake in tests. The OAuth2 tokens are still fetched
+// from the token_uri of the service account credentials, so point it to a matching token server when the
+// endpoint is not a Google one.
+//
+// Parameters:
+//   - url: The base URL of the API (e.g., "https://sheets.example.com/").
+//
+// Returns:
+//   - A ClientOption to pass to NewGoogleSheetsClient.
+func WithEndpoint(url string) ClientOption {
+	return func(c *clientConfig) {
+		c.endpoint = url
+	}
+}
+
+// WithHTTPClient makes the client send its requests, both to the API and to fetch the OAuth2 tokens, through the
+// transport of the given HTTP client instead of http.DefaultTransport, e.g., to route them through an egress proxy
+// or to trust a custom CA. The client adds the OAuth2 authentication on top of the transport, so the HTTP client
+// must not authenticate the requests itself.
+//
+// Parameters:
+//   - client: The base HTTP client (e.g., &http.Client{Transport: &http.Transport{Proxy: http.ProxyURL(proxyURL)}}).
+//
+// Returns:
+//   - A ClientOption to pass to NewGoogleSheetsClient.
+func WithHTTPClient(client *http.Client) ClientOption {
+	return func(c *clientConfig) {
+		c.httpClient = client
+	}
+}
+
+// WithQuotaProject bills and attributes the API usage of the client to the given Google Cloud project instead of the
+// project that owns the credentials. The Google Sheets API must be enabled in that project and the credentials must
+// have the Service Usage Consumer role on it; otherwise the requests fail with an error wrapping ErrAPINotEnabled or
+// ErrQuotaProjectDenied that names the project.
+//
+// Parameters:
+//   - project: The ID of the quota project (e.g., "billing-project-123").
+//
+// Returns:
+//   - A ClientOption to pass to NewGoogleSheetsClient.
+func WithQuotaProject(project string) ClientOption {
+	return func(c *clientConfig) {
+		c.quotaProject = project
+	}
+}
+
+// WithUserAgent adds a product to the User-Agent header of the API requests of the client, after the one of the
+// Google API client library, so the requests can be told apart in the audit logs of the project.
+//
+// Parameters:
+//   - ua: The product and version to add (e.g., "billing-exporter/1.4").
+//
+// Returns:
+//   - A ClientOption to pass to NewGoogleSheetsClient.
+func WithUserAgent(ua string) ClientOption {
+	return func(c *clientConfig) {
+		c.userAgent = ua
+	}
+}
+
+// WithDefaultTimeout limits the duration of every API call made by the client. The timeout only applies when the
+// call has no deadline of its own (e.g., a context with a deadline passed to Ping keeps its deadline). A call that
+// times out returns an error for which errors.Is(err, context.DeadlineExceeded) reports true. The timeout covers the
+// retries of the call (see WithMaxRetries), which are not made when their backoff would end past it. A method that
+// makes several calls (e.g., AppendDataChunked, one call per chunk) runs for at most its number of calls times the
+// timeout, and stops at the first call that fails. Use WithRetryBudget to bound the whole method instead.
+//
+// Parameters:
+//   - d: The maximum duration of each API call, 0 to disable the timeout.
+//
+// Returns:
+//   - A ClientOption to pass to NewGoogleSheetsClient.
+func WithDefaultTimeout(d time.Duration) ClientOption {
+	return func(c *clientConfig) {
+		c.defaultTimeout = d
+	}
+}
+
+// WithMaxRetries sets the number of times an API call that fails with a transient error is retried, with
+// exponential backoff and jitter, or the time given by the Retry-After header of the response. The calls rejected for
+// exceeding the rate limits (429) are always retried. The calls failing with a server error (500, 502, 503 or 504) are
+// only retried when they read data, since a write that failed that way may have been applied. The default is
+// DefaultMaxRetries.
+//
+// Parameters:
+//   - n: The maximum number of retries of each API call, 0 to disable the retries.
+//
+// Returns:
+//   - A ClientOption to pass to NewGoogleSheetsClient.
+func WithMaxRetries(n int) ClientOption {
+	return func(c *clientConfig) {
+		c.maxRetries = n
+	}
+}
+
+// WithRetryBudget bounds the API calls of a single call of the methods that make several API calls in a row:
+// AppendDataChunked, ReadDataChunked, ArchiveRowsWhere, DeleteRowsWhere and BulkUpsert. The budget is shared by all
+// the API calls of the method, including the ones of the methods it calls, so a large AppendDataChunked stops after
+// maxElapsed instead of running for its number of chunks times the timeout of WithDefaultTimeout. Every attempt of
+// every API call counts against the budget, retries included (see WithMaxRetries), and no retry waits past the end
+// of the budget. A method that runs out of budget stops and returns
+// an error wrapping ErrRetryBudgetExhausted with the work done, like for any other failure (e.g., a *ChunkError with
+// the first row not appended, or the number of rows deleted).
+//
+// Parameters:
+//   - maxTotalAttempts: The maximum number of attempts of the API calls of the method, 0 for no limit.
+//   - maxElapsed: The maximum duration of the method, 0 for no limit. Each API call still ends at its own timeout
+//     if that comes first.
+//
+// Returns:
+//   - A ClientOption to pass to NewGoogleSheetsClient.
+func WithRetryBudget(maxTotalAttempts int, maxElapsed time.Duration) ClientOption {
+	return func(c *clientConfig) {
+		c.retryAttempts = maxTotalAttempts
+		c.retryElapsed = maxElapsed
+	}
+}
+
+// WithHeaderCacheTTL sets the time the client caches the header row of a sheet (DefaultHeaderCacheTTL by default)
+// for the methods that resolve columns by header, such as ColumnByHeader. The client discards the cached header
+// row when it changes it itself; use InvalidateCaches after other processes change the columns of a sheet.
+//
+// Parameters:
+//   - d: The time a header row is reused after it is read, 0 to read it on every call.
+//
+// Returns:
+//   - A ClientOption to pass to NewGoogleSheetsClient.
+func WithHeaderCacheTTL(d time.Duration) ClientOption {
+	return func(c *clientConfig) {
+		c.headerCacheTTL = d
+	}
+}
+
+// WriteOption configures a single call of a method that modifies the spreadsheet.
+type WriteOption func(*writeOptions)
+
+// writeOptions holds the settings applied by the WriteOption values passed to a method.
+//
+//   - The force field is used to allow modifying the protected header rows (see ProtectHeaderRows).
+//   - The match field is used to store the options that control how cells are compared with searched values.
+//   - The timeLayout and timeLocation fields are used to format the time.Time values as text (see WithTimeFormat).
+//   - The serialDates field is used to write the time.Time values as dates (see WithSerialDates).
+//   - The inferTypes field is used to convert the strings holding numbers or booleans (see InferTypes).
+//   - The coerceNumbers and coercionExclude fields are used to convert the strings holding numbers, except in the
+//     excluded columns (see WithNumericCoercion).
+//   - The failOnMissing field is used to fail instead of skipping the items that do not exist (see FailOnMissing).
+//   - The referenceCheck field is used to refuse deleting cells referenced by formulas (see WithReferenceCheck).
+//   - The protectionCheck field is used to refuse writing to protected cells (see WithProtectionCheck).
+//   - The valueInputOption field is used to set how the written values are interpreted (see ValueInput).
+//   - The createMissing field is used to add the columns that do not exist (see CreateMissingColumns).
+//   - The inheritRowFormat field is used to copy the format of the row above the appended rows (see
+//     WithInheritRowFormat).
+//   - The insertRows field is used to insert new rows for the appended data instead of overwriting the empty rows
+//     after the table (see InsertRows).
+type writeOptions struct {
+	force            bool
+	match            []MatchOption
+	timeLayout       string
+	timeLocation     *time.Location
+	serialDates      bool
+	inferTypes       bool
+	coerceNumbers    bool
+	coercionExclude  []string
+	failOnMissing    bool
+	referenceCheck   bool
+	protectionCheck  bool
+	valueInputOption string
+	createMissing    bool
+	inheritRowFormat bool
+	insertRows       bool
+}
+
+// newWriteOptions applies the given options to the default write settings.
+func newWriteOptions(opts []WriteOption) *writeOptions {
+	options := &writeOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options
+}
+
+// Force allows inserting rows above or between the protected header rows, and deleting them (see ProtectHeaderRows).
+//
+// Returns:
+//   - A WriteOption to pass to the row insertion and deletion methods.
+func Force() WriteOption {
+	return func(o *writeOptions) {
+		o.force = true
+	}
+}
+
+// FailOnMissing makes the methods that skip the items that do not exist (e.g., the sheet names passed to
+// DeleteSheets) fail without changing anything instead.
+//
+// Returns:
+//   - A WriteOption to pass to the methods that act on several named items.
+func FailOnMissing() WriteOption {
+	return func(o *writeOptions) {
+		o.failOnMissing = true
+	}
+}
+
+// CreateMissingColumns makes ApplyColumnSchema add the columns whose header is not in the header row after the last
+// header, in the order of the schema, instead of failing.
+//
+// Returns:
+//   - A WriteOption to pass to ApplyColumnSchema.
+func CreateMissingColumns() WriteOption {
+	return func(o *writeOptions) {
+		o.createMissing = true
+	}
+}
+
+// WithReferenceCheck makes the row deletion methods (e.g., DeleteRow) refuse to delete a row referenced by the
+// formulas of any sheet of the spreadsheet, so the deletion does not turn them into #REF! errors. The references
+// are found with CheckReferences, which costs an extra request reading the formulas of the whole spreadsheet.
+//
+// Returns:
+//   - A WriteOption to pass to the row deletion methods. The methods return a *ReferenceError, matching
+//     ErrReferenced, with the referencing cells when the row is referenced.
+func WithReferenceCheck() WriteOption {
+	return func(o *writeOptions) {
+		o.referenceCheck = true
+	}
+}
+
+// WithProtectionCheck makes a write check the protected ranges of its sheet first, and refuse to change cells the
+// credentials of the client cannot edit (see ListProtectedRanges) instead of failing partway through. It costs an
+// extra request, and it is honored by the writes whose target is known before writing: UpdateColumns,
+// WriteAtNamedRange, the row deletion methods (e.g., DeleteRow) and the rows updated by BulkUpsert. Appends are not
+// checked, since the API picks their rows.
+//
+// Returns:
+//   - A WriteOption to pass to the write methods. The methods return a *ProtectedRangeError, matching ErrProtected,
+//     naming the protected ranges and their descriptions when the write overlaps them.
+func WithProtectionCheck() WriteOption {
+	return func(o *writeOptions) {
+		o.protectionCheck = true
+	}
+}
+
+// ValueInput sets how the values of a single write are interpreted, overriding the default of the client (see
+// WithValueInputOption).
+//
+// Parameters:
+//   - option: InputRaw or InputUserEntered.
+//
+// Returns:
+//   - A WriteOption to pass to the append and update methods, which fail with any other option.
+func ValueInput(option string) WriteOption {
+	return func(o *writeOptions) {
+		o.valueInputOption = option
+	}
+}
+
+// UserEntered parses the values of a single write as if a user typed them in the sheet. It is a shorthand for
+// ValueInput(InputUserEntered).
+//
+// Returns:
+//   - A WriteOption to pass to the append and update methods.
+func UserEntered() WriteOption {
+	return ValueInput(InputUserEntered)
+}
+
+// InsertRows makes an append insert new rows for the data below the table, shifting down the rows after it,
+// instead of writing the data over the empty rows after the table (which may hold formats or notes). It is not
+// honored by the appends written as dates with WithSerialDates.
+//
+// Returns:
+//   - A WriteOption to pass to the append methods.
+func InsertRows() WriteOption {
+	return func(o *writeOptions) {
+		o.insertRows = true
+	}
+}
+
+// WithMatch sets how the methods that search for a value (e.g., DeleteRow) compare the cells with it.
+//
+// Parameters:
+//   - opts: The comparison options (e.g., IgnoreCase, TrimSpace, NumericEqual or Regexp).
+//
+// Returns:
+//   - A WriteOption to pass to the methods that search for a value.
+func WithMatch(opts ...MatchOption) WriteOption {
+	return func(o *writeOptions) {
+		o.match = append(o.match, opts...)
+	}
+}
+
+// WithTimeFormat writes the time.Time values as text formatted with a layout, instead of the RFC 3339 text they
+// are written as by default. The cells hold the text even when the values are parsed (InputUserEntered), since the
+// text is then written with a leading apostrophe, which the spreadsheet does not store; use WithSerialDates to write
+// real dates.
+//
+// Parameters:
+//   - layout: The layout of the text, as accepted by time.Time.Format (e.g., "02/01/2006 15:04").
+//   - loc: The time zone the values are converted to before formatting, or nil to keep the time zone of each value.
+//
+// Returns:
+//   - A WriteOption to pass to the append methods.
+func WithTimeFormat(layout string, loc *time.Location) WriteOption {
+	return func(o *writeOptions) {
+		o.timeLayout = layout
+		o.timeLocation = loc
+	}
+}
+
+// WithSerialDates writes the time.Time values as dates: the cell holds the date serial number, in the time zone of
+// the spreadsheet, with a date number format, so it is a real date whatever the locale of the spreadsheet is.
+// Values at midnight get a date format and the others a date and time format. The values and the formats are
+// written together in a single batch update, which appends the rows after the last row with data of the sheet. The
+// other values follow the value input option of the write: with InputRaw, strings starting with "=" are written as
+// text, not as formulas.
+//
+// Returns:
+//   - A WriteOption to pass to the append methods.
+func WithSerialDates() WriteOption {
+	return func(o *writeOptions) {
+		o.serialDates = true
+	}
+}
+
+// InferTypes converts the strings that hold a number (e.g., "42", "-3.5" or "1e3") or a boolean ("true" or "false",
+// in any case) to numbers and booleans before writing them, so they are not stored as text in the sheet. Numbers
+// with leading zeros (e.g., "007") and integers too long to be stored exactly are kept as text.
+//
+// Returns:
+//   - A WriteOption to pass to the append methods.
+func InferTypes() WriteOption {
+	return func(o *writeOptions) {
+		o.inferTypes = true
+	}
+}
+
+// WithInheritRowFormat makes an append copy the formats and the data validation rules of the last row of the table
+// (e.g., its dropdowns, checkboxes and number formats) onto the appended rows, which the API appends without them.
+// The rows the data landed on are taken from the response of the append, and the copy costs a second request made
+// after it: if it fails, the rows are appended without the format and the error is returned. The first row of the
+// table is taken as its header row, so nothing is copied when the table has no data row yet. It is not honored by
+// the appends written as dates with WithSerialDates.
+//
+// Returns:
+//   - A WriteOption to pass to the append methods (e.g., AppendData, AppendDataToSheet or BulkUpsert for the new
+//     rows).
+func WithInheritRowFormat() WriteOption {
+	return func(o *writeOptions) {
+		o.inheritRowFormat = true
+	}
+}
+
+// WithNumericCoercion converts the strings that hold a number (e.g., "42" or "-3.5") to numbers before writing
+// them, like InferTypes but leaving the booleans as they are, so the formulas summing the written cells (e.g., SUM)
+// do not silently skip them. The columns holding codes that look like numbers (e.g., ZIP codes or IDs) can be
+// excluded. Without this option or InferTypes, an append with InputRaw that writes such strings reports them to the
+// hook set with WithWarningHook.
+//
+// Parameters:
+//   - exclude: The columns whose strings are kept as text, by header or by column letter (e.g., "Zip" or "C"). A
+//     name is looked up in the header row first, which costs an extra request unless it is cached.
+//
+// Returns:
+//   - A WriteOption to pass to the append methods, which fail with an error wrapping ErrHeaderNotFound when an
+//     excluded column is neither a header nor a column letter.
+func WithNumericCoercion(exclude ...string) WriteOption {
+	return func(o *writeOptions) {
+		o.coerceNumbers = true
+		o.coercionExclude = append(o.coercionExclude, exclude...)
+	}
+}
+
+// ReadOption configures a single call of a method that reads values. The methods that take read options are
+// ReadData, ReadDataChunked, ReadDataPadded, ReadUsedRange, ReadLastRows, ReadCells and ReadWithHeader; they all
+// take the same options, so a set of options can be shared between calls. The other read methods (e.g., ReadGrid or
+// ReadTyped) read the cells with the settings they need and do not take them.
+type ReadOption func(*readOptions)
+
+// readOptions holds the settings applied by the ReadOption values passed to a method.
+//
+//   - The valueRender field is used to set how the values are rendered (see Unformatted and Formulas).
+//   - The padWidth field is used to pad the rows to a number of cells (see PadRows).
+type readOptions struct {
+	valueRender string
+	padWidth    int
+}
+
+// newReadOptions applies the given options to the default read settings.
+func newReadOptions(opts []ReadOption) *readOptions {
+	options := &readOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options
+}
+
+// Unformatted reads the values as they are stored, without the format of the cells: numbers are float64 values
+// instead of text like "$1,234.50", and booleans are bool values.
+//
+// Returns:
+//   - A ReadOption to pass to the read methods.
+func Unformatted() ReadOption {
+	return func(o *readOptions) {
+		o.valueRender = "UNFORMATTED_VALUE"
+	}
+}
+
+// Formulas reads the formulas of the cells that hold one (e.g., "=SUM(A1:A3)") instead of their results.
+//
+// Returns:
+//   - A ReadOption to pass to the read methods.
+func Formulas() ReadOption {
+	return func(o *readOptions) {
+		o.valueRender = "FORMULA"
+	}
+}
+
+// PadRows pads every row read with nil values up to a number of cells, since the API omits the trailing empty
+// cells of each row. The rows longer than that are kept as they are. See ReadDataPadded to pad to the width of the
+// range.
+//
+// Parameters:
+//   - width: The number of cells of every row.
+//
+// Returns:
+//   - A ReadOption to pass to the read methods.
+func PadRows(width int) ReadOption {
+	return func(o *readOptions) {
+		o.padWidth = width
+	}
+}
+
+// WithAuditLog makes the client log every successful write to a sheet of the spreadsheet: after each write, a row
+// with the time, the actor (see WithActor), the operation, the sheet, the target range or row and a summary of
+// the change (e.g., the number of rows, not their contents) is appended to the sheet. The sheet must exist in the
+// spreadsheets the client writes to. Writes to the audit sheet itself are not logged, and a failure to log a write
+// does not fail the write: it is reported to the hook set with WithWarningHook.
+//
+// Parameters:
+//   - sheetName: The name of the sheet to append the log rows to (e.g., "Audit").
+//
+// Returns:
+//   - A ClientOption to pass to NewGoogleSheetsClient.
+func WithAuditLog(sheetName string) ClientOption {
+	return func(c *clientConfig) {
+		c.auditSheet = sheetName
+	}
+}
+
+// WithActor sets the name recorded as the author of the writes in the audit log (see WithAuditLog).
+//
+// Parameters:
+//   - name: The name of the caller (e.g., the name of the service or of the user it acts for).
+//
+// Returns:
+//   - A ClientOption to pass to NewGoogleSheetsClient.
+func WithActor(name string) ClientOption {
+	return func(c *clientConfig) {
+		c.actor = name
+	}
+}
+
+// WithWarningHook sets a function the client calls with the problems that do not fail an operation, such as a
+// write that succeeded but could not be logged to the audit sheet. Without a hook, these problems are ignored.
+//
+// Parameters:
+//   - hook: The function to call with each problem. It must be safe to call from several goroutines if the
+//     client is shared between them.
+//
+// Returns:
+//   - A ClientOption to pass to NewGoogleSheetsClient.
+func WithWarningHook(hook func(err error)) ClientOption {
+	return func(c *clientConfig) {
+		c.warningHook = hook
+	}
+}
@@ -0,0 +1,202 @@
+package gosheets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/sheets/v4"
+)
+
+// ProtectedRangeInfo describes a protected range of the spreadsheet, as listed by ListProtectedRanges.
+//
+//   - The ID field is the ID of the protected range.
+//   - The Sheet field is the name of the sheet of the protected range.
+//   - The Range field is the protected range in A1 notation without the sheet name (e.g., "A1:D1" or "C:C"), empty
+//     when the whole sheet is protected.
+//   - The NamedRange field is the name of the named range protected, empty when the range is not a named range.
+//   - The Description field is the description of the protected range, usually who owns it and why.
+//   - The WarningOnly field is true when editing the range only shows a warning, which does not stop the writes made
+//     through the API.
+//   - The CanEdit field is true when the credentials of the client are allowed to edit the range.
+//   - The UnprotectedRanges field holds the ranges inside a protected sheet that are left editable, in A1 notation
+//     without the sheet name.
+type ProtectedRangeInfo struct {
+	ID                int64
+	Sheet             string
+	Range             string
+	NamedRange        string
+	Description       string
+	WarningOnly       bool
+	CanEdit           bool
+	UnprotectedRanges []string
+}
+
+// A1 returns the protected range in A1 notation with the sheet name (e.g., "Sheet1!A1:D1" or "Sheet1" for a whole
+// sheet).
+func (p ProtectedRangeInfo) A1() string {
+	if p.Range == "" {
+		return quoteSheetName(p.Sheet)
+	}
+	return quoteSheetName(p.Sheet) + "!" + p.Range
+}
+
+// protectedRange is a protected range with its grid indexes, for the checks of WithProtectionCheck.
+type protectedRange struct {
+	info        ProtectedRangeInfo
+	grid        a1Range
+	unprotected []a1Range
+}
+
+// ListProtectedRanges lists the protected ranges of every sheet of the spreadsheet set in the GoogleSheetsClient
+// struct, including whether the credentials of the client can edit each of them.
+//
+// Returns:
+//   - The protected ranges, by sheet in tab order.
+//   - An error if there was a problem retrieving the protected ranges, nil otherwise.
+func (gs *GoogleSheetsClient) ListProtectedRanges() ([]ProtectedRangeInfo, error) {
+	ranges, err := gs.protectedRanges()
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]ProtectedRangeInfo, len(ranges))
+	for i, r := range ranges {
+		infos[i] = r.info
+	}
+	return infos, nil
+}
+
+// protectedRanges retrieves the protected ranges of every sheet of the spreadsheet set in the GoogleSheetsClient
+// struct, in a single request.
+//
+// Returns:
+//   - The protected ranges, by sheet in tab order. The protected named ranges whose named range was deleted are
+//     left out, since they protect nothing.
+//   - An error if there was a problem retrieving the protected ranges.
+func (gs *GoogleSheetsClient) protectedRanges() ([]protectedRange, error) {
+	err := validateClientFields(gs)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	spreadsheet, err := gs.service.Spreadsheets.Get(gs.spreadsheetID).
+		Fields(googleapi.Field("namedRanges,sheets(properties(title,gridProperties),protectedRanges)")).
+		Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve protected ranges: %w", gs.apiError(err))
+	}
+
+	namedRanges := make(map[string]*sheets.NamedRange, len(spreadsheet.NamedRanges))
+	for _, namedRange := range spreadsheet.NamedRanges {
+		namedRanges[namedRange.NamedRangeId] = namedRange
+	}
+
+	var ranges []protectedRange
+	for _, sheet := range spreadsheet.Sheets {
+		for _, p := range sheet.ProtectedRanges {
+			info := ProtectedRangeInfo{
+				ID:          p.ProtectedRangeId,
+				Sheet:       sheet.Properties.Title,
+				Description: p.Description,
+				WarningOnly: p.WarningOnly,
+				CanEdit:     p.RequestingUserCanEdit,
+			}
+
+			gridRange := p.Range
+			if gridRange == nil {
+				namedRange := namedRanges[p.NamedRangeId]
+				if namedRange == nil {
+					continue // A protected named range whose named range was deleted protects nothing
+				}
+				gridRange, info.NamedRange = namedRange.Range, namedRange.Name
+			}
+			info.Range = gridRangeA1(gridRange, sheet.Properties.GridProperties)
+
+			r := protectedRange{grid: a1RangeFromGrid(gridRange)}
+			for _, unprotected := range p.UnprotectedRanges {
+				info.UnprotectedRanges = append(info.UnprotectedRanges, gridRangeA1(unprotected, sheet.Properties.GridProperties))
+				r.unprotected = append(r.unprotected, a1RangeFromGrid(unprotected))
+			}
+			r.info = info
+			ranges = append(ranges, r)
+		}
+	}
+	return ranges, nil
+}
+
+// checkProtection fails when a write would change cells of a sheet that the credentials of the client cannot edit,
+// for the writes made with WithProtectionCheck. A range that only shows a warning does not stop the write, and the
+// cells inside one of the unprotected ranges of a protected range are editable.
+//
+// Parameters:
+//   - sheetName: The name of the sheet written to.
+//   - target: The description of the write target used in the error (e.g., "Sheet1!A2:C10" or "Sheet1!7:7").
+//   - ranges: The ranges the write changes.
+//
+// Returns:
+//   - A *ProtectedRangeError if the write changes cells of protected ranges, an error if there was a problem
+//     reading them, nil otherwise.
+func (gs *GoogleSheetsClient) checkProtection(sheetName, target string, ranges ...a1Range) error {
+	protected, err := gs.protectedRanges()
+	if err != nil {
+		return err
+	}
+
+	var blocking []ProtectedRangeInfo
+	for _, p := range protected {
+		if p.info.CanEdit || p.info.WarningOnly || !strings.EqualFold(p.info.Sheet, sheetName) {
+			continue
+		}
+		for _, r := range ranges {
+			if !p.grid.intersects(r) {
+				continue
+			}
+			// The write is editable when a single unprotected range holds its protected cells; a write spanning
+			// several unprotected ranges still counts as protected
+			written := p.grid.intersection(r)
+			editable := false
+			for _, unprotected := range p.unprotected {
+				if unprotected.covers(written) {
+					editable = true
+					break
+				}
+			}
+			if !editable {
+				blocking = append(blocking, p.info)
+				break
+			}
+		}
+	}
+
+	if len(blocking) > 0 {
+		return &ProtectedRangeError{Target: target, Ranges: blocking}
+	}
+	return nil
+}
+
+// checkRowProtection fails when rows of the current set sheet to be deleted overlap protected ranges, for the
+// deletions made with WithProtectionCheck.
+//
+// Parameters:
+//   - rowNumbers: The 1-based numbers of the rows to delete.
+//
+// Returns:
+//   - A *ProtectedRangeError if the rows overlap protected ranges, an error if there was a problem reading them,
+//     nil otherwise.
+func (gs *GoogleSheetsClient) checkRowProtection(rowNumbers ...int64) error {
+	target := fmt.Sprintf("rows %v of %s", rowNumbers, quoteSheetName(gs.sheetName))
+	if len(rowNumbers) == 1 {
+		target = fmt.Sprintf("%s!%d:%d", quoteSheetName(gs.sheetName), rowNumbers[0], rowNumbers[0])
+	}
+
+	ranges := make([]a1Range, len(rowNumbers))
+	for i, row := range rowNumbers {
+		ranges[i] = a1Range{StartRow: row - 1, EndRow: row, StartColumn: 0, EndColumn: -1}
+	}
+	return gs.checkProtection(gs.sheetName, target, ranges...)
+}
@@ -0,0 +1,112 @@
+package gosheets
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// seedProtectedOrders seeds an Orders sheet whose Total column is protected by another team, with the first rows
+// left editable, and whose header row only shows a warning.
+func seedProtectedOrders() {
+	fake.reset()
+	fake.seedSheet("Orders", [][]interface{}{{"Item", "Qty", "Total"}, {"Pen", "2", "5"}, {"Ink", "1", "7"}, {"Pad", "3", "9"}})
+	fake.seedProtectedRange("Orders", "C:C", sheets.ProtectedRange{
+		Description:       "Finance only",
+		UnprotectedRanges: []*sheets.GridRange{{SheetId: fake.sheet("Orders").properties.SheetId, StartRowIndex: 1, EndRowIndex: 3, StartColumnIndex: 2, EndColumnIndex: 3}},
+	})
+	fake.seedProtectedRange("Orders", "1:1", sheets.ProtectedRange{Description: "Header", WarningOnly: true})
+	fake.seedProtectedRange("Orders", "A10:B20", sheets.ProtectedRange{Description: "Ours", RequestingUserCanEdit: true})
+	resetClient()
+	client.SetSheetName("Orders")
+}
+
+func TestListProtectedRanges(t *testing.T) {
+	t.Cleanup(fake.reset)
+	seedProtectedOrders()
+
+	got, err := client.ListProtectedRanges()
+	if err != nil {
+		t.Fatalf("ListProtectedRanges() error = %v", err)
+	}
+
+	want := []ProtectedRangeInfo{
+		{ID: 1, Sheet: "Orders", Range: "C:C", Description: "Finance only", UnprotectedRanges: []string{"C2:C3"}},
+		{ID: 2, Sheet: "Orders", Range: "1:1", Description: "Header", WarningOnly: true},
+		{ID: 3, Sheet: "Orders", Range: "A10:B20", Description: "Ours", CanEdit: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ListProtectedRanges() = %+v, want %+v", got, want)
+	}
+}
+
+func TestWithProtectionCheck(t *testing.T) {
+	t.Cleanup(fake.reset)
+
+	// Test cases
+	tests := []struct {
+		name    string
+		write   func() error
+		wantErr bool
+	}{
+		{
+			name: "Update of a protected column",
+			write: func() error {
+				return client.UpdateColumns("C4:C5", [][]interface{}{{"10", "11"}}, WithProtectionCheck())
+			},
+			wantErr: true,
+		},
+		{
+			name: "Update of the unprotected rows of the column",
+			write: func() error {
+				return client.UpdateColumns("C2:C3", [][]interface{}{{"10", "11"}}, WithProtectionCheck())
+			},
+		},
+		{
+			name: "Update of a warning-only range and a range the client can edit",
+			write: func() error {
+				return client.UpdateColumns("A1:B12", [][]interface{}{{"x"}, {"y"}}, WithProtectionCheck())
+			},
+		},
+		{
+			name:    "Deletion of a row crossing the protected column",
+			write:   func() error { return client.DeleteRowByValue("A", "Pad", WithProtectionCheck()) },
+			wantErr: true,
+		},
+		{
+			name:  "Deletion of a row inside the unprotected rows",
+			write: func() error { return client.DeleteRowByValue("A", "Ink", WithProtectionCheck()) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seedProtectedOrders()
+
+			err := tt.write()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("write error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				return
+			}
+
+			var protectedErr *ProtectedRangeError
+			if !errors.Is(err, ErrProtected) || !errors.As(err, &protectedErr) {
+				t.Fatalf("write error = %v, want a *ProtectedRangeError", err)
+			}
+			if len(protectedErr.Ranges) != 1 || protectedErr.Ranges[0].Range != "C:C" || !strings.Contains(err.Error(), `"Finance only"`) {
+				t.Errorf("write error = %v, want it to name the protected column C:C", err)
+			}
+			if len(fake.requests) != 0 {
+				t.Errorf("write sent %d batch update requests, want none", len(fake.requests))
+			}
+			if got := fake.sheet("Orders").values[3][0]; got != "Pad" {
+				t.Errorf("row 4 = %v, want it unchanged", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package gosheets
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// a1Range represents a range in A1 notation (e.g., "A1:C10", "A:C", "2:5" or "B3") converted to grid indexes.
+//
+//   - The indexes are 0-based and the end indexes are exclusive, the same convention used by sheets.GridRange.
+//   - The end indexes are -1 when the range is unbounded on that side (e.g., the rows of "A:C").
+type a1Range struct {
+	StartRow    int64
+	EndRow      int64
+	StartColumn int64
+	EndColumn   int64
+}
+
+// a1CellPattern matches one side of an A1 range: optional column letters followed by an optional row number,
+// each of them optionally marked as absolute with a "$".
+var a1CellPattern = regexp.MustCompile(`^\$?([A-Za-z]*)\$?([0-9]*)$`)
+
+// parseA1Range converts a range in A1 notation, without the sheet name, to grid indexes.
+//
+// Parameters:
+//   - a1: The range to convert (e.g., "A1:B2", "A:A", "3:3" or "C7").
+//
+// Returns:
+//   - The grid indexes of the range, or an error if the range is not valid A1 notation.
+func parseA1Range(a1 string) (a1Range, error) {
+	start, end, isRange := strings.Cut(a1, ":")
+
+	startCol, startRow, err := parseA1Cell(start)
+	if err != nil {
+		return a1Range{}, fmt.Errorf("invalid range %q: %w", a1, err)
+	}
+
+	if !isRange {
+		if startCol == -1 || startRow == -1 {
+			return a1Range{}, fmt.Errorf("invalid range %q: a single cell needs both a column and a row", a1)
+		}
+		return a1Range{StartRow: startRow, EndRow: startRow + 1, StartColumn: startCol, EndColumn: startCol + 1}, nil
+	}
+
+	endCol, endRow, err := parseA1Cell(end)
+	if err != nil {
+		return a1Range{}, fmt.Errorf("invalid range %q: %w", a1, err)
+	}
+
+	r := a1Range{StartRow: max(startRow, 0), EndRow: -1, StartColumn: max(startCol, 0), EndColumn: -1}
+	if endRow != -1 {
+		r.EndRow = endRow + 1
+	}
+	if endCol != -1 {
+		r.EndColumn = endCol + 1
+	}
+
+	if (r.EndRow != -1 && r.EndRow <= r.StartRow) || (r.EndColumn != -1 && r.EndColumn <= r.StartColumn) {
+		return a1Range{}, fmt.Errorf("invalid range %q: the end is before the start", a1)
+	}
+
+	return r, nil
+}
+
+// BuildRange builds a range in A1 notation, without the sheet name, from 1-based row and column numbers, the numbers
+// shown in the sheet (e.g., row 2 and column 2 for "B2"). Both ends are inclusive, like in A1 notation, and the ends
+// are swapped when they are given in reverse order.
+//
+// Parameters:
+//   - startRow: The 1-based number of the first row (e.g., 2).
+//   - startCol: The 1-based number of the first column (e.g., 2 for "B").
+//   - endRow: The 1-based number of the last row (e.g., 10).
+//   - endCol: The 1-based number of the last column (e.g., 4 for "D").
+//
+// Returns:
+//   - The range in A1 notation (e.g., "B2:D10"). It panics if a number is lower than 1, which is a bug in the caller.
+func BuildRange(startRow, startCol, endRow, endCol int) string {
+	startRow, endRow = min(startRow, endRow), max(startRow, endRow)
+	startCol, endCol = min(startCol, endCol), max(startCol, endCol)
+	return BuildCell(startRow, startCol) + ":" + BuildCell(endRow, endCol)
+}
+
+// BuildCell builds the A1 notation of a single cell, without the sheet name, from its 1-based row and column numbers.
+//
+// Parameters:
+//   - row: The 1-based number of the row (e.g., 7).
+//   - col: The 1-based number of the column (e.g., 2 for "B").
+//
+// Returns:
+//   - The cell in A1 notation (e.g., "B7"). It panics if a number is lower than 1, which is a bug in the caller.
+func BuildCell(row, col int) string {
+	if row < 1 || col < 1 {
+		panic(fmt.Sprintf("gosheets: invalid cell at row %d and column %d, the numbers are 1-based", row, col))
+	}
+	return fmt.Sprintf("%s%d", columnLetter(col-1), row)
+}
+
+// gridRange converts the range to the grid range of a given sheet, as used by the requests of a batch update.
+// The unbounded sides are omitted, which the API reads as the end of the sheet.
+//
+// Parameters:
+//   - sheetID: The ID of the sheet of the range (see getSheetID).
+//
+// Returns:
+//   - The grid range of the range.
+func (r a1Range) gridRange(sheetID int64) *sheets.GridRange {
+	grid := &sheets.GridRange{
+		SheetId:          sheetID,
+		StartRowIndex:    r.StartRow,
+		StartColumnIndex: r.StartColumn,
+		ForceSendFields:  []string{"SheetId", "StartRowIndex", "StartColumnIndex"}, // 0 is a valid value for all of them
+	}
+	if r.EndRow != -1 {
+		grid.EndRowIndex = r.EndRow
+	}
+	if r.EndColumn != -1 {
+		grid.EndColumnIndex = r.EndColumn
+	}
+	return grid
+}
+
+// a1RangeFromGrid converts a grid range to grid indexes, the inverse of a1Range.gridRange.
+//
+// Parameters:
+//   - grid: The grid range. The end indexes are 0 when the range is unbounded on that side.
+//
+// Returns:
+//   - The grid indexes of the range.
+func a1RangeFromGrid(grid *sheets.GridRange) a1Range {
+	r := a1Range{StartRow: grid.StartRowIndex, EndRow: -1, StartColumn: grid.StartColumnIndex, EndColumn: -1}
+	if grid.EndRowIndex != 0 {
+		r.EndRow = grid.EndRowIndex
+	}
+	if grid.EndColumnIndex != 0 {
+		r.EndColumn = grid.EndColumnIndex
+	}
+	return r
+}
+
+// contains reports whether a cell is inside the range.
+//
+// Parameters:
+//   - row: The 0-based index of the row of the cell.
+//   - column: The 0-based index of the column of the cell.
+//
+// Returns:
+//   - True if the cell is inside the range, false otherwise.
+func (r a1Range) contains(row, column int64) bool {
+	return row >= r.StartRow && (r.EndRow == -1 || row < r.EndRow) &&
+		column >= r.StartColumn && (r.EndColumn == -1 || column < r.EndColumn)
+}
+
+// intersects reports whether two ranges have at least one cell in common.
+//
+// Parameters:
+//   - other: The range to compare with.
+//
+// Returns:
+//   - True if the ranges overlap, false otherwise.
+func (r a1Range) intersects(other a1Range) bool {
+	overlaps := func(start1, end1, start2, end2 int64) bool {
+		return (end2 == -1 || start1 < end2) && (end1 == -1 || start2 < end1)
+	}
+	return overlaps(r.StartRow, r.EndRow, other.StartRow, other.EndRow) &&
+		overlaps(r.StartColumn, r.EndColumn, other.StartColumn, other.EndColumn)
+}
+
+// covers reports whether every cell of another range is inside the range.
+//
+// Parameters:
+//   - other: The range to compare with.
+//
+// Returns:
+//   - True if the other range is inside the range, false otherwise.
+func (r a1Range) covers(other a1Range) bool {
+	inside := func(start1, end1, start2, end2 int64) bool {
+		return start2 >= start1 && (end1 == -1 || (end2 != -1 && end2 <= end1))
+	}
+	return inside(r.StartRow, r.EndRow, other.StartRow, other.EndRow) &&
+		inside(r.StartColumn, r.EndColumn, other.StartColumn, other.EndColumn)
+}
+
+// intersection returns the cells two ranges have in common. Check that they intersect first.
+//
+// Parameters:
+//   - other: The range to intersect with.
+//
+// Returns:
+//   - The range of the common cells.
+func (r a1Range) intersection(other a1Range) a1Range {
+	end := func(end1, end2 int64) int64 {
+		switch {
+		case end1 == -1:
+			return end2
+		case end2 == -1:
+			return end1
+		}
+		return min(end1, end2)
+	}
+	return a1Range{
+		StartRow:    max(r.StartRow, other.StartRow),
+		EndRow:      end(r.EndRow, other.EndRow),
+		StartColumn: max(r.StartColumn, other.StartColumn),
+		EndColumn:   end(r.EndColumn, other.EndColumn),
+	}
+}
+
+// parseA1Cell converts one side of an A1 range (e.g., "B7", "B" or "7") to 0-based column and row indexes.
+//
+// Parameters:
+//   - cell: The cell reference to convert.
+//
+// Returns:
+//   - The column index, or -1 if the reference has no column.
+//   - The row index, or -1 if the reference has no row.
+//   - An error if the reference is not valid A1 notation.
+func parseA1Cell(cell string) (int64, int64, error) {
+	match := a1CellPattern.FindStringSubmatch(cell)
+	if match == nil || (match[1] == "" && match[2] == "") {
+		return -1, -1, fmt.Errorf("invalid cell reference %q", cell)
+	}
+
+	column, row := int64(-1), int64(-1)
+	if match[1] != "" {
+		column = int64(columnIndex(match[1]))
+	}
+	if match[2] != "" {
+		number, err := strconv.ParseInt(match[2], 10, 64)
+		if err != nil || number < 1 {
+			return -1, -1, fmt.Errorf("invalid row number in cell reference %q", cell)
+		}
+		row = number - 1
+	}
+
+	return column, row, nil
+}
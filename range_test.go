@@ -0,0 +1,216 @@
+package gosheets
+
+import (
+	"reflect"
+	"testing"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+func TestParseA1Range(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name    string
+		a1      string
+		want    a1Range
+		wantErr bool
+	}{
+		{
+			name: "Bounded range",
+			a1:   "A1:B4",
+			want: a1Range{StartRow: 0, EndRow: 4, StartColumn: 0, EndColumn: 2},
+		},
+		{
+			name: "Single cell",
+			a1:   "C7",
+			want: a1Range{StartRow: 6, EndRow: 7, StartColumn: 2, EndColumn: 3},
+		},
+		{
+			name: "Whole columns",
+			a1:   "A:C",
+			want: a1Range{StartRow: 0, EndRow: -1, StartColumn: 0, EndColumn: 3},
+		},
+		{
+			name: "Whole rows",
+			a1:   "2:5",
+			want: a1Range{StartRow: 1, EndRow: 5, StartColumn: 0, EndColumn: -1},
+		},
+		{
+			name: "Open ended rows",
+			a1:   "B3:D",
+			want: a1Range{StartRow: 2, EndRow: -1, StartColumn: 1, EndColumn: 4},
+		},
+		{
+			name: "Absolute references",
+			a1:   "$A$1:$B$2",
+			want: a1Range{StartRow: 0, EndRow: 2, StartColumn: 0, EndColumn: 2},
+		},
+		{
+			name:    "Empty range",
+			a1:      "",
+			wantErr: true,
+		},
+		{
+			name:    "Row zero",
+			a1:      "A0",
+			wantErr: true,
+		},
+		{
+			name:    "Column without row",
+			a1:      "A",
+			wantErr: true,
+		},
+		{
+			name:    "End before start",
+			a1:      "C1:A1",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid characters",
+			a1:      "A1:B-2",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseA1Range(tt.a1)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseA1Range() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseA1Range() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGridRange(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name string
+		a1   string
+		want sheets.GridRange
+	}{
+		{
+			name: "Bounded range",
+			a1:   "B2:C4",
+			want: sheets.GridRange{SheetId: 7, StartRowIndex: 1, EndRowIndex: 4, StartColumnIndex: 1, EndColumnIndex: 3},
+		},
+		{
+			name: "Whole columns",
+			a1:   "A:B",
+			want: sheets.GridRange{SheetId: 7, StartRowIndex: 0, EndRowIndex: 0, StartColumnIndex: 0, EndColumnIndex: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := parseA1Range(tt.a1)
+			if err != nil {
+				t.Fatalf("parseA1Range() error = %v", err)
+			}
+
+			got := parsed.gridRange(7)
+			got.ForceSendFields = nil
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("gridRange() = %+v, want %+v", *got, tt.want)
+			}
+			if back := a1RangeFromGrid(got); back != parsed {
+				t.Errorf("a1RangeFromGrid() = %+v, want %+v", back, parsed)
+			}
+		})
+	}
+}
+
+func TestRangeIntersection(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name       string
+		a, b       string
+		wantCommon string
+		wantCovers bool
+	}{
+		{
+			name:       "Overlapping ranges",
+			a:          "A1:C3",
+			b:          "B2:D4",
+			wantCommon: "B2:C3",
+		},
+		{
+			name:       "Whole column and a row",
+			a:          "C:C",
+			b:          "2:2",
+			wantCommon: "C2:C2",
+		},
+		{
+			name:       "Range inside a whole column",
+			a:          "A:B",
+			b:          "A5:B9",
+			wantCommon: "A5:B9",
+			wantCovers: true,
+		},
+		{
+			name: "Unbounded range is never covered by a bounded one",
+			a:    "A1:B100",
+			b:    "A2:A",
+			// The rows below 100 are only in b
+			wantCommon: "A2:A100",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, _ := parseA1Range(tt.a)
+			b, _ := parseA1Range(tt.b)
+			want, _ := parseA1Range(tt.wantCommon)
+
+			if !a.intersects(b) {
+				t.Fatalf("intersects() = false, want true")
+			}
+			if got := a.intersection(b); got != want {
+				t.Errorf("intersection() = %+v, want %+v", got, want)
+			}
+			if got := a.covers(b); got != tt.wantCovers {
+				t.Errorf("covers() = %v, want %v", got, tt.wantCovers)
+			}
+		})
+	}
+}
+
+func TestBuildRange(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name                               string
+		startRow, startCol, endRow, endCol int
+		want                               string
+	}{
+		{name: "Block", startRow: 2, startCol: 2, endRow: 10, endCol: 4, want: "B2:D10"},
+		{name: "Single cell as a range", startRow: 1, startCol: 1, endRow: 1, endCol: 1, want: "A1:A1"},
+		{name: "Columns past Z", startRow: 1, startCol: 26, endRow: 3, endCol: 28, want: "Z1:AB3"},
+		{name: "Reversed ends", startRow: 10, startCol: 4, endRow: 2, endCol: 2, want: "B2:D10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := BuildRange(tt.startRow, tt.startCol, tt.endRow, tt.endCol)
+			if got != tt.want {
+				t.Errorf("BuildRange() = %q, want %q", got, tt.want)
+			}
+			if _, err := parseA1Range(got); err != nil {
+				t.Errorf("parseA1Range(%q) error = %v", got, err)
+			}
+		})
+	}
+
+	if got := BuildCell(7, 2); got != "B7" {
+		t.Errorf("BuildCell() = %q, want %q", got, "B7")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("BuildCell() with a 0-based row did not panic")
+		}
+	}()
+	BuildCell(0, 1)
+}
@@ -0,0 +1,182 @@
+package gosheets
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"google.golang.org/api/googleapi"
+)
+
+// referencePattern matches a cell reference in a formula: an optional sheet name, quoted or not, followed by a cell
+// ("B7"), a range ("A1:A10", "A2:A") or a whole column or row range ("C:C", "3:5"), each side optionally absolute
+// ("$A$1"). The matches are checked by formulaReferences, since the pattern alone also matches words such as
+// function names.
+var referencePattern = regexp.MustCompile(
+	`(?:(?:'((?:[^']|'')+)'|([A-Za-z_][A-Za-z0-9_.]*))!)?` +
+		`(\$?[A-Za-z]{1,3}\$?[0-9]+|\$?[A-Za-z]{1,3}|\$?[0-9]+)` +
+		`(?::(\$?[A-Za-z]{1,3}\$?[0-9]+|\$?[A-Za-z]{1,3}|\$?[0-9]+))?`)
+
+// formulaReference is a cell reference found in a formula.
+//
+//   - The Sheet field is the name of the sheet of the reference, empty when the reference is not sheet-qualified.
+//   - The Range field is the referenced range.
+type formulaReference struct {
+	Sheet string
+	Range a1Range
+}
+
+// CheckReferences finds the formulas of the spreadsheet set in the GoogleSheetsClient struct that reference a range,
+// to check that the range can be deleted or moved without breaking them. The formulas of every sheet are read in a
+// single request, and their references are parsed from the formula text: references that only overlap the range
+// count, and references without a sheet name are resolved to the sheet of the formula. The formulas inside the range
+// are not reported, and references through named ranges or built from text (e.g., with INDIRECT) are not detected.
+//
+// Parameters:
+//   - target: The range to check in A1 notation (e.g., "C:C", "5:5", "B2:D10" or "'Other sheet'!A1"), in the current
+//     set sheet when it has no sheet name.
+//
+// Returns:
+//   - The locations of the formula cells that reference the range, sorted by sheet, row and column.
+//   - An error wrapping ErrInvalidRange if the target is not valid A1 notation, or an error if there was a problem
+//     reading the formulas, nil otherwise.
+func (gs *GoogleSheetsClient) CheckReferences(target string) ([]CellLocation, error) {
+	sheetName, rangeA1 := gs.sheetName, target
+	if i := strings.LastIndex(target, "!"); i != -1 {
+		sheetName, rangeA1 = unquoteSheetName(target[:i]), target[i+1:]
+	}
+
+	targetRange, err := parseA1Range(rangeA1)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRange, err)
+	}
+
+	err = validateClientFields(gs)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	spreadsheet, err := gs.service.Spreadsheets.Get(gs.spreadsheetID).
+		IncludeGridData(true).
+		Fields(googleapi.Field("sheets(properties.title,data(startRow,startColumn,rowData(values(userEnteredValue.formulaValue))))")).
+		Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve formulas from Google Sheets: %w", gs.apiError(err))
+	}
+
+	var locations []CellLocation
+	for _, sheet := range spreadsheet.Sheets {
+		title := sheet.Properties.Title
+		inTargetSheet := strings.EqualFold(title, sheetName)
+
+		for _, gridData := range sheet.Data {
+			for i, rowData := range gridData.RowData {
+				for j, cell := range rowData.Values {
+					if cell.UserEnteredValue == nil || cell.UserEnteredValue.FormulaValue == nil {
+						continue
+					}
+
+					row, column := gridData.StartRow+int64(i), gridData.StartColumn+int64(j)
+					if inTargetSheet && targetRange.contains(row, column) {
+						continue // The formula goes away with the range
+					}
+
+					for _, reference := range formulaReferences(*cell.UserEnteredValue.FormulaValue) {
+						referenceSheet := reference.Sheet
+						if referenceSheet == "" {
+							referenceSheet = title
+						}
+						if strings.EqualFold(referenceSheet, sheetName) && reference.Range.intersects(targetRange) {
+							locations = append(locations, CellLocationFromGrid(title, row, column))
+							break
+						}
+					}
+				}
+			}
+		}
+	}
+	return locations, nil
+}
+
+// formulaReferences parses the cell references of a formula. The text inside string literals is skipped.
+//
+// Parameters:
+//   - formula: The formula, as entered by the user (e.g., "=SUM('Other sheet'!$A$1:A10)").
+//
+// Returns:
+//   - The references of the formula, in the order they appear in it.
+func formulaReferences(formula string) []formulaReference {
+	// Blank out the string literals, keeping the length so the offsets do not change
+	masked := []byte(formula)
+	inString := false
+	for i, c := range masked {
+		if c == '"' {
+			inString = !inString // A doubled quote inside a literal toggles twice
+			continue
+		}
+		if inString {
+			masked[i] = ' '
+		}
+	}
+
+	var references []formulaReference
+	for _, match := range referencePattern.FindAllSubmatchIndex(masked, -1) {
+		start, end := match[0], match[1]
+		if start > 0 && isReferenceChar(masked[start-1]) {
+			continue // Part of a longer word (e.g., "D1" in "ABCD1")
+		}
+		if end < len(masked) && (isReferenceChar(masked[end]) || masked[end] == '(' || masked[end] == '!') {
+			continue // A function name (e.g., "LOG10(") or part of a longer word
+		}
+
+		var sheet string
+		switch {
+		case match[2] != -1:
+			sheet = strings.ReplaceAll(formula[match[2]:match[3]], "''", "'")
+		case match[4] != -1:
+			sheet = formula[match[4]:match[5]]
+		}
+
+		rangeA1 := formula[match[6]:match[7]]
+		if match[8] != -1 {
+			rangeA1 += ":" + formula[match[8]:match[9]]
+		}
+
+		r, err := parseA1Range(rangeA1)
+		if err != nil {
+			continue // Not a reference (e.g., a number or a word without a row)
+		}
+		references = append(references, formulaReference{Sheet: sheet, Range: r})
+	}
+	return references
+}
+
+// isReferenceChar reports whether a character can be part of a reference or a name next to it.
+func isReferenceChar(c byte) bool {
+	return c == '_' || c == '.' || c == '$' || c >= '0' && c <= '9' || c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z'
+}
+
+// checkRowReferences fails when formulas reference a row of the current set sheet, for the deletions made with
+// WithReferenceCheck.
+//
+// Parameters:
+//   - rowNumber: The 1-based number of the row to delete.
+//
+// Returns:
+//   - A *ReferenceError if formulas reference the row, an error if there was a problem reading them, nil otherwise.
+func (gs *GoogleSheetsClient) checkRowReferences(rowNumber int) error {
+	target := fmt.Sprintf("%s!%d:%d", quoteSheetName(gs.sheetName), rowNumber, rowNumber)
+
+	cells, err := gs.CheckReferences(target)
+	if err != nil {
+		return err
+	}
+	if len(cells) > 0 {
+		return &ReferenceError{Target: target, Cells: cells}
+	}
+	return nil
+}
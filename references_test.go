@@ -0,0 +1,164 @@
+package gosheets
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestFormulaReferences(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name    string
+		formula string
+		want    []formulaReference
+	}{
+		{
+			name:    "Relative cell",
+			formula: "=A1*2",
+			want:    []formulaReference{{Range: a1Range{StartRow: 0, EndRow: 1, StartColumn: 0, EndColumn: 1}}},
+		},
+		{
+			name:    "Absolute cell",
+			formula: "=$B$3+1",
+			want:    []formulaReference{{Range: a1Range{StartRow: 2, EndRow: 3, StartColumn: 1, EndColumn: 2}}},
+		},
+		{
+			name:    "Ranges in a function",
+			formula: "=SUM(A1:A10, C:C, 2:3)",
+			want: []formulaReference{
+				{Range: a1Range{StartRow: 0, EndRow: 10, StartColumn: 0, EndColumn: 1}},
+				{Range: a1Range{StartRow: 0, EndRow: -1, StartColumn: 2, EndColumn: 3}},
+				{Range: a1Range{StartRow: 1, EndRow: 3, StartColumn: 0, EndColumn: -1}},
+			},
+		},
+		{
+			name:    "Cross-sheet references",
+			formula: "='Other sheet'!A1+Data!$B$2:B+'It''s'!C3",
+			want: []formulaReference{
+				{Sheet: "Other sheet", Range: a1Range{StartRow: 0, EndRow: 1, StartColumn: 0, EndColumn: 1}},
+				{Sheet: "Data", Range: a1Range{StartRow: 1, EndRow: -1, StartColumn: 1, EndColumn: 2}},
+				{Sheet: "It's", Range: a1Range{StartRow: 2, EndRow: 3, StartColumn: 2, EndColumn: 3}},
+			},
+		},
+		{
+			name:    "Function names, numbers and strings are not references",
+			formula: `=IF(LOG10(D4)>1.5, "A1", TRUE)`,
+			want:    []formulaReference{{Range: a1Range{StartRow: 3, EndRow: 4, StartColumn: 3, EndColumn: 4}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := formulaReferences(tt.formula)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("formulaReferences(%q) = %+v, want %+v", tt.formula, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckReferences(t *testing.T) {
+	t.Cleanup(fake.reset)
+
+	fake.reset()
+	fake.seedSheet("Data", [][]interface{}{
+		{"Item", "Amount", "Double"},
+		{"Pen", 2, "=B2*2"},
+		{"Ink", 3, "=$B$3*2"},
+		{"Total", "=SUM(B2:B3)"},
+	})
+	fake.seedSheet("Report", [][]interface{}{
+		{"Total", "=Data!B4"},
+		{"Pens", "='Data'!A2"},
+	})
+
+	// Test cases
+	tests := []struct {
+		name    string
+		target  string
+		want    []CellLocation
+		wantErr bool
+	}{
+		{
+			name:   "Column of the current sheet",
+			target: "B:B",
+			want: []CellLocation{
+				{Sheet: "Data", Row: 2, Column: "C"},
+				{Sheet: "Data", Row: 3, Column: "C"},
+				{Sheet: "Report", Row: 1, Column: "B"},
+			},
+		},
+		{
+			name:   "Row referenced from another sheet",
+			target: "2:2",
+			want: []CellLocation{
+				{Sheet: "Data", Row: 4, Column: "B"},
+				{Sheet: "Report", Row: 2, Column: "B"},
+			},
+		},
+		{
+			name:   "Sheet-qualified target",
+			target: "'Report'!A1:B1",
+			want:   nil,
+		},
+		{
+			name:   "Range without references",
+			target: "A5:C10",
+			want:   nil,
+		},
+		{
+			name:    "Invalid target",
+			target:  "C1:A1",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetClient()
+			client.SetSheetName("Data")
+
+			got, err := client.CheckReferences(tt.target)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckReferences() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CheckReferences() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeleteRowByValueWithReferenceCheck(t *testing.T) {
+	t.Cleanup(fake.reset)
+
+	fake.reset()
+	fake.seedSheet("Data", [][]interface{}{
+		{"Item", "Amount"},
+		{"Pen", 2},
+		{"Ink", 3},
+		{"Total", "=B2"},
+	})
+	resetClient()
+	client.SetSheetName("Data")
+
+	err := client.DeleteRowByValue("A", "Pen", WithReferenceCheck())
+	var referenceErr *ReferenceError
+	if !errors.Is(err, ErrReferenced) || !errors.As(err, &referenceErr) {
+		t.Fatalf("DeleteRowByValue() error = %v, want a *ReferenceError", err)
+	}
+	if want := []CellLocation{{Sheet: "Data", Row: 4, Column: "B"}}; !reflect.DeepEqual(referenceErr.Cells, want) {
+		t.Errorf("referencing cells = %v, want %v", referenceErr.Cells, want)
+	}
+	if got := len(fake.sheet("Data").values); got != 4 {
+		t.Errorf("rows = %d, want 4 after a refused deletion", got)
+	}
+
+	if err := client.DeleteRowByValue("A", "Ink", WithReferenceCheck()); err != nil {
+		t.Fatalf("DeleteRowByValue() of an unreferenced row error = %v", err)
+	}
+	if got := len(fake.sheet("Data").values); got != 3 {
+		t.Errorf("rows = %d, want 3", got)
+	}
+}
@@ -0,0 +1,242 @@
+package gosheets
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// ReplaceOption configures how ReplaceInColumn and PreviewReplace search for the text to replace. Without options
+// the search is case-insensitive, matches the text anywhere in the cells and skips the formula cells, like the
+// find and replace dialog of Google Sheets.
+type ReplaceOption func(*replaceOptions)
+
+// replaceOptions holds the settings applied by the ReplaceOption values.
+//
+//   - The matchCase field is used to search case-sensitively.
+//   - The matchEntireCell field is used to replace only the cells whose whole value matches.
+//   - The regex field is used to treat the searched text as a regular expression.
+//   - The includeFormulas field is used to search the formulas too.
+type replaceOptions struct {
+	matchCase       bool
+	matchEntireCell bool
+	regex           bool
+	includeFormulas bool
+}
+
+// MatchCase searches the text case-sensitively, so "Paid" does not match "paid".
+func MatchCase() ReplaceOption {
+	return func(o *replaceOptions) {
+		o.matchCase = true
+	}
+}
+
+// MatchEntireCell only replaces the cells whose whole value matches the searched text.
+func MatchEntireCell() ReplaceOption {
+	return func(o *replaceOptions) {
+		o.matchEntireCell = true
+	}
+}
+
+// SearchByRegex treats the searched text as a regular expression, and the replacement may reference its capture
+// groups (e.g., "$1"). PreviewReplace evaluates the expression with the RE2 syntax, which covers the common
+// subset of the syntax used by Google Sheets.
+func SearchByRegex() ReplaceOption {
+	return func(o *replaceOptions) {
+		o.regex = true
+	}
+}
+
+// IncludeFormulas also searches the text of the formulas (e.g., "=SUM(A1:A3)"), which are skipped otherwise.
+func IncludeFormulas() ReplaceOption {
+	return func(o *replaceOptions) {
+		o.includeFormulas = true
+	}
+}
+
+// newReplaceOptions applies the given options to the default search settings.
+func newReplaceOptions(opts []ReplaceOption) *replaceOptions {
+	options := &replaceOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options
+}
+
+// ReplaceInColumn replaces a text in the cells of a column of the current set sheet in the GoogleSheetsClient
+// struct, with a find and replace request scoped to that column so the other columns are never changed. The
+// column is found by header (see ColumnByHeader) and the header row, assumed to be the first row of the sheet, is
+// not changed. Use PreviewReplace to check which cells would change first.
+//
+// Parameters:
+//   - header: The header of the column.
+//   - find: The text to search for.
+//   - replace: The text to replace it with.
+//   - opts: Optional settings for the search (e.g., MatchCase or SearchByRegex).
+//
+// Returns:
+//   - The number of cells changed.
+//   - An error wrapping ErrHeaderNotFound if no column has the header, or an error if the searched text is empty or
+//     there was a problem replacing it, nil otherwise.
+func (gs *GoogleSheetsClient) ReplaceInColumn(header, find, replace string, opts ...ReplaceOption) (int64, error) {
+	options := newReplaceOptions(opts)
+
+	if find == "" {
+		return 0, fmt.Errorf("invalid search: the text to find must not be empty")
+	}
+
+	err := validateClientFields(gs)
+	if err != nil {
+		return 0, err
+	}
+
+	err = gs.requireScope(writeScopes...)
+	if err != nil {
+		return 0, err
+	}
+
+	column, err := gs.ColumnByHeader(header)
+	if err != nil {
+		return 0, err
+	}
+	columnNumber := int64(columnIndex(column))
+
+	properties, err := gs.getSheetProperties()
+	if err != nil {
+		return 0, fmt.Errorf("unable to retrieve sheet ID: %w", err)
+	}
+
+	batchUpdateRequest := &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{{
+			FindReplace: &sheets.FindReplaceRequest{
+				Find:            find,
+				Replacement:     replace,
+				Range:           a1Range{StartRow: 1, EndRow: -1, StartColumn: columnNumber, EndColumn: columnNumber + 1}.gridRange(properties.SheetId),
+				MatchCase:       options.matchCase,
+				MatchEntireCell: options.matchEntireCell,
+				SearchByRegex:   options.regex,
+				IncludeFormulas: options.includeFormulas,
+			},
+		}},
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	resp, err := gs.service.Spreadsheets.BatchUpdate(gs.spreadsheetID, batchUpdateRequest).Context(ctx).Do()
+	if err != nil {
+		return 0, fmt.Errorf("unable to replace %q in column %q: %w", find, header, gs.apiError(err))
+	}
+
+	var changed int64
+	if len(resp.Replies) > 0 && resp.Replies[0].FindReplace != nil {
+		changed = resp.Replies[0].FindReplace.ValuesChanged
+	}
+
+	gs.audit("ReplaceInColumn", gs.sheetName, column+" column", fmt.Sprintf("replaced %q with %q in %d cells", find, replace, changed))
+	return changed, nil
+}
+
+// PreviewReplace reports the cells that ReplaceInColumn would change with the same arguments, without writing
+// anything. The column is read with the formulas of its cells and searched on the client side; the cells whose
+// value would not change (e.g., when the replacement equals the text found) are not reported.
+//
+// Parameters:
+//   - header: The header of the column.
+//   - find: The text to search for.
+//   - replace: The text to replace it with.
+//   - opts: Optional settings for the search (e.g., MatchCase or SearchByRegex).
+//
+// Returns:
+//   - The locations of the cells that would change, sorted by row.
+//   - An error wrapping ErrHeaderNotFound if no column has the header, or an error if the searched text is empty or
+//     not a valid regular expression or there was a problem reading the column, nil otherwise.
+func (gs *GoogleSheetsClient) PreviewReplace(header, find, replace string, opts ...ReplaceOption) ([]CellLocation, error) {
+	options := newReplaceOptions(opts)
+
+	if find == "" {
+		return nil, fmt.Errorf("invalid search: the text to find must not be empty")
+	}
+
+	replacer, err := newReplacer(find, replace, options)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateClientFields(gs)
+	if err != nil {
+		return nil, err
+	}
+
+	column, err := gs.ColumnByHeader(header)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	readRange := fmt.Sprintf("%s!%s2:%s", quoteSheetName(gs.sheetName), column, column)
+	resp, err := gs.service.Spreadsheets.Values.Get(gs.spreadsheetID, readRange).
+		ValueRenderOption("FORMULA").Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve data from sheet: %w", gs.apiError(err))
+	}
+
+	var locations []CellLocation
+	for i, row := range resp.Values {
+		if len(row) == 0 {
+			continue
+		}
+		text := fmt.Sprintf("%v", row[0])
+		if text == "" || (strings.HasPrefix(text, "=") && !options.includeFormulas) {
+			continue
+		}
+		if _, changed := replacer(text); changed {
+			locations = append(locations, CellLocation{Sheet: gs.sheetName, Row: int64(i) + 2, Column: column})
+		}
+	}
+	return locations, nil
+}
+
+// newReplacer builds the function that replaces the searched text in a cell value on the client side, with the
+// semantics of a find and replace request.
+//
+// Parameters:
+//   - find: The text to search for.
+//   - replace: The text to replace it with, which may reference the capture groups of a regular expression.
+//   - options: The search settings.
+//
+// Returns:
+//   - The replacer, which returns the new value and whether it differs from the old one.
+//   - An error if the SearchByRegex option is used with an invalid regular expression.
+func newReplacer(find, replace string, options *replaceOptions) (func(string) (string, bool), error) {
+	pattern := find
+	if !options.regex {
+		pattern = regexp.QuoteMeta(find)
+	}
+	if options.matchEntireCell {
+		pattern = "^(?:" + pattern + ")$"
+	}
+	if !options.matchCase {
+		pattern = "(?i)" + pattern
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regular expression %q: %w", find, err)
+	}
+
+	return func(text string) (string, bool) {
+		var replaced string
+		if options.regex {
+			replaced = re.ReplaceAllString(text, replace)
+		} else {
+			replaced = re.ReplaceAllLiteralString(text, replace)
+		}
+		return replaced, replaced != text
+	}, nil
+}
@@ -0,0 +1,138 @@
+package gosheets
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestReplaceInColumn(t *testing.T) {
+	t.Cleanup(fake.reset)
+
+	// The sheet is seeded with a fresh copy for each case, since the replacements change the values in place
+	newData := func() [][]interface{} {
+		return [][]interface{}{
+			{"Item", "Status", "Notes"},
+			{"Pen", "paid", "paid in cash"},
+			{"Ink", "Unpaid", "paid later"},
+			{"Pad", "PAID", "none"},
+			{"Cap", `=IF(A5="Cap","paid","")`, ""},
+			{"Box", "ref-2024-17", "paid"},
+		}
+	}
+	data := newData()
+
+	// Test cases
+	tests := []struct {
+		name       string
+		header     string
+		find       string
+		replace    string
+		opts       []ReplaceOption
+		wantCells  []string
+		wantStatus []interface{}
+		wantErr    bool
+		wantErrIs  error
+	}{
+		{
+			name:       "Case-insensitive by default",
+			header:     "Status",
+			find:       "paid",
+			replace:    "settled",
+			wantCells:  []string{"Orders!B2", "Orders!B3", "Orders!B4"},
+			wantStatus: []interface{}{"Status", "settled", "Unsettled", "settled", `=IF(A5="Cap","paid","")`, "ref-2024-17"},
+		},
+		{
+			name:       "Match case and entire cell",
+			header:     "Status",
+			find:       "paid",
+			replace:    "settled",
+			opts:       []ReplaceOption{MatchCase(), MatchEntireCell()},
+			wantCells:  []string{"Orders!B2"},
+			wantStatus: []interface{}{"Status", "settled", "Unpaid", "PAID", `=IF(A5="Cap","paid","")`, "ref-2024-17"},
+		},
+		{
+			name:       "Include formulas",
+			header:     "Status",
+			find:       `"paid"`,
+			replace:    `"done"`,
+			opts:       []ReplaceOption{IncludeFormulas()},
+			wantCells:  []string{"Orders!B5"},
+			wantStatus: []interface{}{"Status", "paid", "Unpaid", "PAID", `=IF(A5="Cap","done","")`, "ref-2024-17"},
+		},
+		{
+			name:       "Regular expression with capture groups",
+			header:     "Status",
+			find:       `ref-(\d+)-(\d+)`,
+			replace:    "$2/$1",
+			opts:       []ReplaceOption{SearchByRegex()},
+			wantCells:  []string{"Orders!B6"},
+			wantStatus: []interface{}{"Status", "paid", "Unpaid", "PAID", `=IF(A5="Cap","paid","")`, "17/2024"},
+		},
+		{
+			name:       "Replacement equal to the text found",
+			header:     "Status",
+			find:       "PAID",
+			replace:    "PAID",
+			opts:       []ReplaceOption{MatchCase()},
+			wantStatus: []interface{}{"Status", "paid", "Unpaid", "PAID", `=IF(A5="Cap","paid","")`, "ref-2024-17"},
+		},
+		{
+			name:      "Unknown header",
+			header:    "State",
+			find:      "paid",
+			wantErr:   true,
+			wantErrIs: ErrHeaderNotFound,
+		},
+		{
+			name:    "Empty search",
+			header:  "Status",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.reset()
+			fake.seedSheet("Orders", newData())
+			resetClient()
+			client.SetSheetName("Orders")
+
+			cells, err := client.PreviewReplace(tt.header, tt.find, tt.replace, tt.opts...)
+			if (err != nil) != tt.wantErr || (tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs)) {
+				t.Fatalf("PreviewReplace() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var got []string
+			for _, cell := range cells {
+				got = append(got, cell.A1())
+			}
+			if !reflect.DeepEqual(got, tt.wantCells) {
+				t.Errorf("PreviewReplace() = %v, want %v", got, tt.wantCells)
+			}
+			if len(fake.requests) != 0 {
+				t.Fatalf("PreviewReplace() sent %d batch update requests, want none", len(fake.requests))
+			}
+
+			changed, err := client.ReplaceInColumn(tt.header, tt.find, tt.replace, tt.opts...)
+			if (err != nil) != tt.wantErr || (tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs)) {
+				t.Fatalf("ReplaceInColumn() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if changed != int64(len(tt.wantCells)) {
+				t.Errorf("ReplaceInColumn() = %d, want %d", changed, len(tt.wantCells))
+			}
+
+			sheet := fake.sheet("Orders")
+			for i, want := range tt.wantStatus {
+				if sheet.values[i][1] != want {
+					t.Errorf("row %d status = %v, want %v", i+1, sheet.values[i][1], want)
+				}
+				if sheet.values[i][2] != data[i][2] {
+					t.Errorf("row %d notes = %v, want them unchanged", i+1, sheet.values[i][2])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package gosheets
+
+import (
+	"context"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// DefaultMaxRetries is the number of times a failed API call is retried when no number is set with WithMaxRetries.
+const DefaultMaxRetries = 3
+
+// Delays between the attempts of an API call, doubled after every attempt up to the maximum.
+var (
+	retryInitialDelay = 500 * time.Millisecond
+	retryMaxDelay     = 8 * time.Second
+)
+
+// retryTransport is an http.RoundTripper that retries the API requests that fail with a transient error, with
+// exponential backoff, and draws the attempts from the retry budget of the operation, if any (see WithRetryBudget).
+// The requests rejected for exceeding the rate limits (429) are retried whatever their method, since the API does
+// not process them. The server errors (5xx) are only retried for the idempotent methods (GET and PUT), since a
+// batch update or an append that failed that way may have been applied. The backoff never waits past the deadline
+// of the request: the last response is returned instead.
+//
+//   - The base field is used to send the requests.
+//   - The maxRetries field is the number of times a request is retried.
+type retryTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+}
+
+// RoundTrip sends the request, and sends it again while it fails with a transient error and retries are left.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	budget, _ := ctx.Value(operationBudgetKey{}).(*operationBudget)
+
+	for attempt := 0; ; attempt++ {
+		if budget != nil {
+			if err := budget.spend(); err != nil {
+				return nil, err
+			}
+		}
+
+		attemptReq := req
+		if attempt > 0 {
+			attemptReq = req.Clone(ctx)
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				attemptReq.Body = body
+			}
+		}
+
+		resp, err := t.base.RoundTrip(attemptReq)
+		if err != nil || attempt >= t.maxRetries || !isRetryable(req, resp) {
+			return resp, err
+		}
+
+		delay := retryDelay(attempt, resp)
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(delay).After(deadline) {
+			return resp, nil // No time left to retry, return the error of the API
+		}
+
+		// The body of the failed response is dropped, so the connection can be reused
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// isRetryable reports whether a request can be sent again after failing with the given response.
+func isRetryable(req *http.Request, resp *http.Response) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false // The body cannot be sent again
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return req.Method == http.MethodGet || req.Method == http.MethodPut
+	}
+	return false
+}
+
+// retryDelay returns the time to wait before the next attempt of a request: the Retry-After time of the response
+// if it sets one, or else the backoff delay of the attempt, with a random jitter so concurrent clients do not retry
+// in lockstep.
+//
+// Parameters:
+//   - attempt: The 0-based number of the attempt that failed.
+//   - resp: The response of the attempt.
+//
+// Returns:
+//   - The time to wait.
+func retryDelay(attempt int, resp *http.Response) time.Duration {
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	delay := min(retryInitialDelay<<attempt, retryMaxDelay)
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// operationBudgetKey is the context key of the retry budget of the operation an API request belongs to.
+type operationBudgetKey struct{}
+
+// withOperationBudget returns a copy of ctx that carries a retry budget, so the retry transport draws the attempts
+// of the requests made with it from the budget.
+func withOperationBudget(ctx context.Context, budget *operationBudget) context.Context {
+	return context.WithValue(ctx, operationBudgetKey{}, budget)
+}
@@ -0,0 +1,135 @@
+package gosheets
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryTransport(t *testing.T) {
+	rateLimited := &fakeError{http.StatusTooManyRequests, "Quota exceeded for quota metric 'Read requests'."}
+	unavailable := &fakeError{http.StatusServiceUnavailable, "The service is currently unavailable."}
+
+	// Test cases
+	tests := []struct {
+		name         string
+		opts         []ClientOption
+		errs         []*fakeError
+		write        bool
+		initialDelay time.Duration
+		wantCalls    int
+		wantErr      bool
+		wantBudget   bool
+	}{
+		{
+			name:      "Rate limited read",
+			errs:      []*fakeError{rateLimited, rateLimited},
+			wantCalls: 3,
+		},
+		{
+			name:      "Server error on read",
+			errs:      []*fakeError{unavailable},
+			wantCalls: 2,
+		},
+		{
+			name:      "Rate limited write",
+			errs:      []*fakeError{rateLimited},
+			write:     true,
+			wantCalls: 2,
+		},
+		{
+			name:      "Server error on write",
+			errs:      []*fakeError{unavailable},
+			write:     true,
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		{
+			name:      "Client error",
+			errs:      []*fakeError{{http.StatusBadRequest, "Unable to parse range: Sheet1!"}},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		{
+			name:      "Retries exhausted",
+			errs:      []*fakeError{rateLimited, rateLimited, rateLimited, rateLimited},
+			wantCalls: 1 + DefaultMaxRetries,
+			wantErr:   true,
+		},
+		{
+			name:      "Retries disabled",
+			opts:      []ClientOption{WithMaxRetries(0)},
+			errs:      []*fakeError{rateLimited},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		{
+			name:         "Backoff past the deadline",
+			opts:         []ClientOption{WithDefaultTimeout(100 * time.Millisecond)},
+			errs:         []*fakeError{rateLimited},
+			initialDelay: 10 * time.Second,
+			wantCalls:    1,
+			wantErr:      true,
+		},
+		{
+			name:       "Retries drawn from the budget",
+			opts:       []ClientOption{WithRetryBudget(2, 0)},
+			errs:       []*fakeError{rateLimited, rateLimited},
+			write:      true,
+			wantCalls:  2,
+			wantErr:    true,
+			wantBudget: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.reset()
+			t.Cleanup(fake.reset)
+
+			if tt.initialDelay > 0 {
+				initialDelay := retryInitialDelay
+				retryInitialDelay = tt.initialDelay
+				t.Cleanup(func() { retryInitialDelay = initialDelay })
+			}
+
+			opts := append([]ClientOption{WithEndpoint(testEndpoint)}, tt.opts...)
+			gs, err := NewGoogleSheetsClient(testCredentials, opts...)
+			if err != nil {
+				t.Fatalf("NewGoogleSheetsClient() error = %v", err)
+			}
+			gs.SetSpreadsheetID("SPREADSHEET_ID")
+			gs.SetSheetName("Sheet1")
+
+			fake.mu.Lock()
+			fake.transientErrs = tt.errs
+			fake.mu.Unlock()
+
+			start := time.Now()
+			if tt.write {
+				// AppendDataChunked makes the same single append call as AppendData, under the retry budget
+				err = gs.AppendDataChunked([][]interface{}{{"Value3", "Value4"}}, "A1", 1)
+			} else {
+				_, err = gs.ReadData("A1:B2")
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := errors.Is(err, ErrRetryBudgetExhausted); got != tt.wantBudget {
+				t.Errorf("errors.Is(err, ErrRetryBudgetExhausted) = %v, want %v (error = %v)", got, tt.wantBudget, err)
+			}
+			if got := fake.callCount(); got != tt.wantCalls {
+				t.Errorf("made %d API calls, want %d", got, tt.wantCalls)
+			}
+			if tt.initialDelay > 0 && time.Since(start) >= tt.initialDelay {
+				t.Errorf("waited %v, want the backoff to be skipped past the deadline", time.Since(start))
+			}
+			if tt.write && !tt.wantErr {
+				if got := len(fake.sheet("Sheet1").values); got != 3 {
+					t.Errorf("left %d rows, want 3", got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package gosheets
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// ValidateSchema checks that the header row of the current set sheet in the GoogleSheetsClient struct matches the
+// headers declared by a struct, so a job can fail fast when the columns of the sheet changed. The header row is
+// cached (see WithHeaderCacheTTL) and read again once if it does not match.
+//
+// The headers of a struct are taken from the `sheet` tag of its exported fields (e.g., `sheet:"Email"`), or from
+// the name of the field when it has no tag. Fields tagged `sheet:"-"` are skipped. Empty cells of the header row
+// are not reported as extra columns.
+//
+// Parameters:
+//   - sample: A struct, a pointer to a struct or a slice of them, whose type declares the headers.
+//   - checkOrder: Whether the columns must also be in the order of the fields of the struct.
+//
+// Returns:
+//   - A *SchemaError listing the missing, extra and, if checkOrder is true, out-of-order columns when the header
+//     row does not match, or an error if the sample is not a struct or there was a problem reading the header row,
+//     nil otherwise.
+func (gs *GoogleSheetsClient) ValidateSchema(sample interface{}, checkOrder bool) error {
+	expected, err := structHeaders(sample)
+	if err != nil {
+		return err
+	}
+
+	var schemaErr *SchemaError
+	err = gs.withHeaders(func(headers []string) error {
+		schemaErr = compareHeaders(expected, headers, checkOrder)
+		if schemaErr != nil {
+			return errStaleHeaders
+		}
+		return nil
+	})
+	if errors.Is(err, errStaleHeaders) {
+		return schemaErr
+	}
+	return err
+}
+
+// structHeaders returns the headers declared by the fields of a struct type, in the order of the fields. It is the
+// parser of the `sheet` struct tags shared by the methods that map structs to rows.
+//
+// Parameters:
+//   - sample: A struct, a pointer to a struct or a slice of them.
+//
+// Returns:
+//   - The headers of the fields, or an error if the sample is not a struct or two fields declare the same header.
+func structHeaders(sample interface{}) ([]string, error) {
+	t := reflect.TypeOf(sample)
+	for t != nil && (t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("invalid sample of type %T, use a struct, a pointer to a struct or a slice of them", sample)
+	}
+
+	var headers []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("sheet"), ",") // Leave room for options after the name
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if slices.Contains(headers, name) {
+			return nil, fmt.Errorf("%w: %q is declared by more than one field of %s", ErrDuplicateHeader, name, t)
+		}
+		headers = append(headers, name)
+	}
+	return headers, nil
+}
+
+// compareHeaders compares the headers declared by a struct with the header row of a sheet.
+//
+// Parameters:
+//   - expected: The headers declared by the struct, in the order of its fields.
+//   - actual: The header row of the sheet.
+//   - checkOrder: Whether the columns present in both must be in the same order.
+//
+// Returns:
+//   - A *SchemaError describing the differences, or nil if the headers match.
+func compareHeaders(expected, actual []string, checkOrder bool) *SchemaError {
+	schemaErr := &SchemaError{}
+	var common []string
+	for _, header := range expected {
+		if slices.Contains(actual, header) {
+			common = append(common, header)
+		} else {
+			schemaErr.Missing = append(schemaErr.Missing, header)
+		}
+	}
+
+	var sheetOrder []string
+	for _, header := range actual {
+		switch {
+		case header == "":
+		case slices.Contains(expected, header):
+			sheetOrder = append(sheetOrder, header)
+		default:
+			schemaErr.Extra = append(schemaErr.Extra, header)
+		}
+	}
+
+	if checkOrder {
+		// The columns that keep their relative order are the longest common subsequence of both orders; the
+		// others are the ones that moved.
+		inOrder := longestCommonSubsequence(common, sheetOrder)
+		for _, header := range common {
+			if !slices.Contains(inOrder, header) {
+				schemaErr.OutOfOrder = append(schemaErr.OutOfOrder, header)
+			}
+		}
+	}
+
+	if len(schemaErr.Missing) == 0 && len(schemaErr.Extra) == 0 && len(schemaErr.OutOfOrder) == 0 {
+		return nil
+	}
+	return schemaErr
+}
+
+// longestCommonSubsequence returns the longest sequence of items that appear in both a and b in the same order.
+func longestCommonSubsequence(a, b []string) []string {
+	lengths := make([][]int, len(a)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+
+	var result []string
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] == b[j]:
+			result = append(result, a[i])
+			i++
+			j++
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return result
+}
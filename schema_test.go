@@ -0,0 +1,135 @@
+package gosheets
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type schemaPerson struct {
+	Name     string `sheet:"Name"`
+	Email    string `sheet:"Email"`
+	Phone    string
+	Internal string `sheet:"-"`
+	secret   string
+}
+
+func TestValidateSchema(t *testing.T) {
+	fake.reset()
+	t.Cleanup(fake.reset)
+	fake.seedSheet("Exact", [][]interface{}{{"Name", "Email", "Phone"}})
+	fake.seedSheet("Reordered", [][]interface{}{{"Email", "Name", "Phone"}})
+	fake.seedSheet("Drifted", [][]interface{}{{"Name", "", "Address", "Phone"}})
+
+	// Test cases
+	tests := []struct {
+		name       string
+		sheetName  string
+		sample     interface{}
+		checkOrder bool
+		want       *SchemaError
+		wantErr    bool
+	}{
+		{
+			name:       "Matching header row",
+			sheetName:  "Exact",
+			sample:     schemaPerson{},
+			checkOrder: true,
+		},
+		{
+			name:      "Slice of pointers as sample",
+			sheetName: "Exact",
+			sample:    []*schemaPerson{},
+		},
+		{
+			name:       "Order not checked",
+			sheetName:  "Reordered",
+			sample:     &schemaPerson{},
+			checkOrder: false,
+		},
+		{
+			name:       "Order checked",
+			sheetName:  "Reordered",
+			sample:     schemaPerson{},
+			checkOrder: true,
+			want:       &SchemaError{OutOfOrder: []string{"Name"}},
+			wantErr:    true,
+		},
+		{
+			name:      "Missing and extra columns",
+			sheetName: "Drifted",
+			sample:    schemaPerson{},
+			want:      &SchemaError{Missing: []string{"Email"}, Extra: []string{"Address"}},
+			wantErr:   true,
+		},
+		{
+			name:      "Not a struct",
+			sheetName: "Exact",
+			sample:    "Name",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetClient()
+			client.SetSheetName(tt.sheetName)
+
+			err := client.ValidateSchema(tt.sample, tt.checkOrder)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateSchema() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var schemaErr *SchemaError
+			if errors.As(err, &schemaErr) != (tt.want != nil) {
+				t.Fatalf("ValidateSchema() error = %v, want %v", err, tt.want)
+			}
+			if tt.want != nil && !reflect.DeepEqual(schemaErr, tt.want) {
+				t.Errorf("ValidateSchema() error = %+v, want %+v", schemaErr, tt.want)
+			}
+		})
+	}
+}
+
+func TestStructHeaders(t *testing.T) {
+	type duplicated struct {
+		A string `sheet:"Name"`
+		B string `sheet:"Name"`
+	}
+
+	// Test cases
+	tests := []struct {
+		name    string
+		sample  interface{}
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:   "Tags, field names and skipped fields",
+			sample: schemaPerson{},
+			want:   []string{"Name", "Email", "Phone"},
+		},
+		{
+			name:    "Duplicate header",
+			sample:  duplicated{},
+			wantErr: true,
+		},
+		{
+			name:    "Nil sample",
+			sample:  nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := structHeaders(tt.sample)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("structHeaders() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("structHeaders() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,425 @@
+package gosheets
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// MoveSheet moves the current set sheet in the GoogleSheetsClient struct to a new position in the tab order of the spreadsheet.
+//
+// Parameters:
+//   - newIndex: The 0-based position the sheet must have after the move, 0 being the first tab.
+//
+// Returns:
+//   - An error if the index is out of range or there was a problem moving the sheet, nil otherwise.
+func (gs *GoogleSheetsClient) MoveSheet(newIndex int) error {
+	err := validateClientFields(gs)
+	if err != nil {
+		return err
+	}
+
+	err = gs.requireScope(writeScopes...)
+	if err != nil {
+		return err
+	}
+
+	sheetProperties, err := gs.listSheetProperties()
+	if err != nil {
+		return err
+	}
+
+	if newIndex < 0 || newIndex >= len(sheetProperties) {
+		return fmt.Errorf("index %d out of range, the spreadsheet has %d sheets", newIndex, len(sheetProperties))
+	}
+
+	var current *sheets.SheetProperties
+	for _, properties := range sheetProperties {
+		if properties.Title == gs.sheetName {
+			current = properties
+		}
+	}
+	if current == nil {
+		return fmt.Errorf("sheet with name %s not found", gs.sheetName)
+	}
+
+	// The API interprets the index relative to the tab order before the move, so moving a sheet to the
+	// right needs an index one past the final position.
+	index := int64(newIndex)
+	if index > current.Index {
+		index++
+	}
+
+	request := &sheets.Request{
+		UpdateSheetProperties: &sheets.UpdateSheetPropertiesRequest{
+			Properties: &sheets.SheetProperties{
+				SheetId:         current.SheetId,
+				Index:           index,
+				ForceSendFields: []string{"Index"}, // Index 0 would be omitted otherwise
+			},
+			Fields: "index",
+		},
+	}
+
+	batchUpdateRequest := &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{request},
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	_, err = gs.service.Spreadsheets.BatchUpdate(gs.spreadsheetID, batchUpdateRequest).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("unable to move sheet: %w", gs.apiError(err))
+	}
+
+	gs.audit("MoveSheet", gs.sheetName, "sheet", fmt.Sprintf("moved to index %d", newIndex))
+	return nil
+}
+
+// ConfigureView sets the frozen rows and columns and the gridline visibility of the current set sheet in the
+// GoogleSheetsClient struct in a single request, e.g., to set up a dashboard tab with a frozen header and no gridlines.
+//
+// Parameters:
+//   - frozenRows: The number of rows frozen at the top of the sheet, 0 to unfreeze them.
+//   - frozenCols: The number of columns frozen at the left of the sheet, 0 to unfreeze them.
+//   - showGridlines: Whether the gridlines of the sheet are shown.
+//
+// Returns:
+//   - An error if a count is negative or there was a problem updating the sheet, nil otherwise. Freezing more rows
+//     or columns than the sheet has makes the API reject the request.
+func (gs *GoogleSheetsClient) ConfigureView(frozenRows, frozenCols int, showGridlines bool) error {
+	if frozenRows < 0 || frozenCols < 0 {
+		return fmt.Errorf("invalid frozen rows %d and columns %d: they must not be negative", frozenRows, frozenCols)
+	}
+
+	err := gs.requireScope(writeScopes...)
+	if err != nil {
+		return err
+	}
+
+	sheetID, err := gs.getSheetID()
+	if err != nil {
+		return fmt.Errorf("unable to retrieve sheet ID: %w", err)
+	}
+
+	request := &sheets.Request{
+		UpdateSheetProperties: &sheets.UpdateSheetPropertiesRequest{
+			Properties: &sheets.SheetProperties{
+				SheetId: sheetID,
+				GridProperties: &sheets.GridProperties{
+					FrozenRowCount:    int64(frozenRows),
+					FrozenColumnCount: int64(frozenCols),
+					HideGridlines:     !showGridlines,
+					ForceSendFields:   []string{"FrozenRowCount", "FrozenColumnCount", "HideGridlines"}, // 0 and false would be omitted otherwise
+				},
+			},
+			Fields: "gridProperties.frozenRowCount,gridProperties.frozenColumnCount,gridProperties.hideGridlines",
+		},
+	}
+
+	batchUpdateRequest := &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{request},
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	_, err = gs.service.Spreadsheets.BatchUpdate(gs.spreadsheetID, batchUpdateRequest).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("unable to configure the view of the sheet: %w", gs.apiError(err))
+	}
+
+	gs.audit("ConfigureView", gs.sheetName, "sheet", fmt.Sprintf("%d frozen rows, %d frozen columns, gridlines shown: %t", frozenRows, frozenCols, showGridlines))
+	return nil
+}
+
+// DeleteSheets deletes several sheets of the spreadsheet set in the GoogleSheetsClient struct in a single request,
+// e.g., to clean up temporary tabs. The names that do not match any sheet are skipped, unless FailOnMissing is
+// passed. The spreadsheet must keep at least one sheet, so deleting all of them fails without deleting any.
+//
+// Parameters:
+//   - names: The names of the sheets to delete.
+//   - opts: Optional settings for the deletion (e.g., FailOnMissing).
+//
+// Returns:
+//   - An error if a name does not match any sheet and FailOnMissing was passed, the deletion would leave the
+//     spreadsheet without sheets, or there was a problem deleting the sheets, nil otherwise.
+func (gs *GoogleSheetsClient) DeleteSheets(names []string, opts ...WriteOption) error {
+	if gs.spreadsheetID == "" {
+		return fmt.Errorf("spreadsheet ID not set")
+	}
+
+	err := gs.requireScope(writeScopes...)
+	if err != nil {
+		return err
+	}
+
+	options := newWriteOptions(opts)
+
+	sheetProperties, err := gs.listSheetProperties()
+	if err != nil {
+		return err
+	}
+
+	sheetIDs := make(map[string]int64, len(sheetProperties))
+	for _, properties := range sheetProperties {
+		sheetIDs[properties.Title] = properties.SheetId
+	}
+
+	var requests []*sheets.Request
+	var deleted []string
+	for _, name := range names {
+		sheetID, ok := sheetIDs[name]
+		if !ok {
+			if options.failOnMissing {
+				return fmt.Errorf("sheet with name %s not found", name)
+			}
+			continue
+		}
+		delete(sheetIDs, name) // Repeated names are deleted once
+
+		requests = append(requests, &sheets.Request{
+			DeleteSheet: &sheets.DeleteSheetRequest{SheetId: sheetID},
+		})
+		deleted = append(deleted, name)
+	}
+
+	if len(requests) == 0 {
+		return nil
+	}
+	if len(sheetIDs) == 0 {
+		return fmt.Errorf("unable to delete all the sheets: a spreadsheet must have at least one sheet")
+	}
+
+	batchUpdateRequest := &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: requests,
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	_, err = gs.service.Spreadsheets.BatchUpdate(gs.spreadsheetID, batchUpdateRequest).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("unable to delete sheets: %w", gs.apiError(err))
+	}
+
+	// A sheet created later with a deleted name gets a new ID and an empty header row
+	gs.sheetIDCache.clear()
+	gs.headerCache.clear()
+
+	gs.audit("DeleteSheets", strings.Join(deleted, ", "), "sheets", fmt.Sprintf("%d sheets deleted", len(deleted)))
+	return nil
+}
+
+// datedSheetAttempts is the number of times AppendToDatedSheet tries to add a missing sheet, resolving it again
+// after each failure in case another caller added it first.
+const datedSheetAttempts = 3
+
+// AppendToDatedSheet appends data to the sheet of a date (e.g., one sheet per day named "2024-06-01"), adding the
+// sheet with the given header row, frozen, if the spreadsheet does not have it yet. The sheet is added together
+// with its header row in a single request, so no caller ever appends to a new sheet before its header is written.
+// Concurrent callers that find the same sheet missing do not fail: the ones that lose the race to add it resolve
+// it again and append to the sheet added by the winner. It does not change the current set sheet of the
+// GoogleSheetsClient struct.
+//
+// Parameters:
+//   - data: A 2D slice representing the data to be added. Each inner slice
+//     represents a row of data, with each element representing a cell value.
+//   - t: The date of the sheet.
+//   - layout: The time layout that formats the date as the name of the sheet (e.g., time.DateOnly).
+//   - headers: The header row of the sheet, written when the sheet is added. Empty to add the sheet without one.
+//
+// Returns:
+//   - An error if there was a problem adding the sheet or the data, nil otherwise. With empty or nil data the
+//     sheet is still added if it is missing.
+func (gs *GoogleSheetsClient) AppendToDatedSheet(data [][]interface{}, t time.Time, layout string, headers []string) error {
+	if gs.spreadsheetID == "" {
+		return fmt.Errorf("spreadsheet ID not set")
+	}
+
+	if layout == "" {
+		return fmt.Errorf("the time layout of the sheet name must not be empty")
+	}
+
+	err := gs.requireScope(writeScopes...)
+	if err != nil {
+		return err
+	}
+
+	name := t.Format(layout)
+	err = gs.ensureSheetWithHeaders(name, headers)
+	if err != nil {
+		return err
+	}
+
+	err = gs.appendToSheet(name, data, "A1")
+	if err != nil || len(data) == 0 {
+		return err
+	}
+
+	gs.audit("AppendToDatedSheet", name, "A1", fmt.Sprintf("%d rows", len(data)))
+	return nil
+}
+
+// ensureSheetWithHeaders adds a sheet with a given name and header row to the spreadsheet set in the
+// GoogleSheetsClient struct, unless the spreadsheet already has one. If adding the sheet fails because a sheet with
+// the same name exists (e.g., another caller added it in the meantime), the sheet is resolved again; any other
+// failure is returned at once.
+//
+// Parameters:
+//   - name: The name of the sheet.
+//   - headers: The header row of the sheet, frozen, or empty to add the sheet without one.
+//
+// Returns:
+//   - An error if there was a problem checking or adding the sheet, nil otherwise.
+func (gs *GoogleSheetsClient) ensureSheetWithHeaders(name string, headers []string) error {
+	var err error
+	for attempt := 0; attempt < datedSheetAttempts; attempt++ {
+		sheetProperties, listErr := gs.listSheetProperties()
+		if listErr != nil {
+			return listErr
+		}
+
+		for _, properties := range sheetProperties {
+			if properties.Title == name {
+				return nil
+			}
+		}
+
+		err = gs.addSheetWithHeaders(name, headers)
+		if !isSheetNameConflict(err) {
+			return err // Added, or a failure other than a conflict with an existing sheet
+		}
+	}
+	return err
+}
+
+// addSheetWithHeaders adds a sheet with a header row to the spreadsheet set in the GoogleSheetsClient struct in a
+// single request. The sheet ID is chosen by the client, so the header row can be written in the same request.
+//
+// Parameters:
+//   - name: The name of the sheet.
+//   - headers: The header row of the sheet, frozen, or empty to add the sheet without one.
+//
+// Returns:
+//   - An error if there was a problem adding the sheet, nil otherwise. A sheet with the same name makes the request
+//     fail with a 400 Bad Request status (see isSheetNameConflict).
+func (gs *GoogleSheetsClient) addSheetWithHeaders(name string, headers []string) error {
+	sheetID := rand.Int64N(1<<31-1) + 1 // Non-zero, since an ID of 0 is not sent
+	properties := &sheets.SheetProperties{SheetId: sheetID, Title: name}
+	requests := []*sheets.Request{{AddSheet: &sheets.AddSheetRequest{Properties: properties}}}
+
+	if len(headers) > 0 {
+		properties.GridProperties = &sheets.GridProperties{
+			RowCount:       1000,
+			ColumnCount:    max(26, int64(len(headers))),
+			FrozenRowCount: 1,
+		}
+
+		row := &sheets.RowData{}
+		for _, header := range headers {
+			row.Values = append(row.Values, &sheets.CellData{UserEnteredValue: interfaceToExtendedValue(header)})
+		}
+		requests = append(requests, &sheets.Request{
+			UpdateCells: &sheets.UpdateCellsRequest{
+				Start:  &sheets.GridCoordinate{SheetId: sheetID},
+				Rows:   []*sheets.RowData{row},
+				Fields: "userEnteredValue",
+			},
+		})
+	}
+
+	batchUpdateRequest := &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: requests,
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	_, err := gs.service.Spreadsheets.BatchUpdate(gs.spreadsheetID, batchUpdateRequest).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("unable to add sheet %s: %w", name, gs.apiError(err))
+	}
+	return nil
+}
+
+// ensureSheet adds a sheet with a given name to the spreadsheet set in the GoogleSheetsClient struct, unless the
+// spreadsheet already has one.
+//
+// Parameters:
+//   - name: The name of the sheet.
+//
+// Returns:
+//   - An error if there was a problem checking or adding the sheet, nil otherwise.
+func (gs *GoogleSheetsClient) ensureSheet(name string) error {
+	sheetProperties, err := gs.listSheetProperties()
+	if err != nil {
+		return err
+	}
+
+	for _, properties := range sheetProperties {
+		if properties.Title == name {
+			return nil
+		}
+	}
+
+	request := &sheets.Request{
+		AddSheet: &sheets.AddSheetRequest{
+			Properties: &sheets.SheetProperties{Title: name},
+		},
+	}
+
+	batchUpdateRequest := &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{request},
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	_, err = gs.service.Spreadsheets.BatchUpdate(gs.spreadsheetID, batchUpdateRequest).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("unable to add sheet %s: %w", name, gs.apiError(err))
+	}
+	return nil
+}
+
+// sheetIDCache caches the IDs of the sheets by spreadsheet ID and sheet name. The ID of a sheet never changes, so
+// the entries do not expire; they only go stale if the sheet is deleted and another one created with its name.
+type sheetIDCache struct {
+	mu  sync.Mutex
+	ids map[[2]string]int64
+}
+
+// get returns the cached ID of a sheet, and whether it was cached.
+func (c *sheetIDCache) get(key [2]string) (int64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	sheetID, ok := c.ids[key]
+	return sheetID, ok
+}
+
+// set caches the ID of a sheet.
+func (c *sheetIDCache) set(key [2]string, sheetID int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.ids == nil {
+		c.ids = map[[2]string]int64{}
+	}
+	c.ids[key] = sheetID
+}
+
+// clear discards every cached ID.
+func (c *sheetIDCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ids = nil
+}
@@ -0,0 +1,375 @@
+package gosheets
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMoveSheet(t *testing.T) {
+	fake.reset()
+	fake.seedSheet("Sheet2", nil)
+	fake.seedSheet("Summary", nil)
+	t.Cleanup(fake.reset)
+
+	// Test cases
+	tests := []struct {
+		name      string
+		sheetName string
+		newIndex  int
+		wantOrder []string
+		wantErr   bool
+	}{
+		{
+			name:      "Move to the first position",
+			sheetName: "Summary",
+			newIndex:  0,
+			wantOrder: []string{"Summary", "Sheet1", "Sheet2"},
+			wantErr:   false,
+		},
+		{
+			name:      "Move to the last position",
+			sheetName: "Summary",
+			newIndex:  2,
+			wantOrder: []string{"Sheet1", "Sheet2", "Summary"},
+			wantErr:   false,
+		},
+		{
+			name:      "Index out of range",
+			sheetName: "Summary",
+			newIndex:  3,
+			wantErr:   true,
+		},
+		{
+			name:      "Negative index",
+			sheetName: "Summary",
+			newIndex:  -1,
+			wantErr:   true,
+		},
+		{
+			name:      "Non-existent sheet",
+			sheetName: "Sheet99",
+			newIndex:  0,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetClient()
+			client.SetSheetName(tt.sheetName)
+
+			err := client.MoveSheet(tt.newIndex)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MoveSheet() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			for i, title := range tt.wantOrder {
+				if got := fake.sheet(title).properties.Index; got != int64(i) {
+					t.Errorf("MoveSheet() sheet %s at index %d, want %d", title, got, i)
+				}
+			}
+		})
+	}
+}
+
+func TestConfigureView(t *testing.T) {
+	t.Cleanup(fake.reset)
+
+	// Test cases
+	tests := []struct {
+		name          string
+		frozenRows    int
+		frozenCols    int
+		showGridlines bool
+		wantErr       bool
+	}{
+		{
+			name:       "Freeze the header and hide the gridlines",
+			frozenRows: 1,
+		},
+		{
+			name:          "Unfreeze and show the gridlines",
+			showGridlines: true,
+		},
+		{
+			name:       "Freeze rows and columns",
+			frozenRows: 2,
+			frozenCols: 1,
+		},
+		{
+			name:       "Negative count",
+			frozenRows: -1,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.reset()
+			resetClient()
+			grid := fake.sheet("Sheet1").properties.GridProperties
+			grid.FrozenRowCount, grid.FrozenColumnCount, grid.HideGridlines = 3, 3, tt.showGridlines // The opposite of the wanted view
+
+			err := client.ConfigureView(tt.frozenRows, tt.frozenCols, tt.showGridlines)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ConfigureView() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if grid.FrozenRowCount != int64(tt.frozenRows) || grid.FrozenColumnCount != int64(tt.frozenCols) {
+				t.Errorf("frozen rows and columns = %d, %d, want %d, %d", grid.FrozenRowCount, grid.FrozenColumnCount, tt.frozenRows, tt.frozenCols)
+			}
+			if grid.HideGridlines != !tt.showGridlines {
+				t.Errorf("HideGridlines = %t, want %t", grid.HideGridlines, !tt.showGridlines)
+			}
+			if got := len(fake.requests); got != 1 {
+				t.Errorf("requests = %d, want 1", got)
+			}
+		})
+	}
+}
+
+func TestDeleteSheets(t *testing.T) {
+	t.Cleanup(fake.reset)
+
+	// Test cases
+	tests := []struct {
+		name       string
+		names      []string
+		opts       []WriteOption
+		wantSheets []string
+		wantCalls  int
+		wantErr    bool
+	}{
+		{
+			name:       "Delete several sheets",
+			names:      []string{"Tmp1", "Tmp2"},
+			wantSheets: []string{"Sheet1", "Tmp3"},
+			wantCalls:  2,
+		},
+		{
+			name:       "Skip missing sheets",
+			names:      []string{"Tmp1", "Tmp9", "Tmp1"},
+			wantSheets: []string{"Sheet1", "Tmp2", "Tmp3"},
+			wantCalls:  2,
+		},
+		{
+			name:       "Only missing sheets",
+			names:      []string{"Tmp9"},
+			wantSheets: []string{"Sheet1", "Tmp1", "Tmp2", "Tmp3"},
+			wantCalls:  1,
+		},
+		{
+			name:       "Fail on missing sheets",
+			names:      []string{"Tmp1", "Tmp9"},
+			opts:       []WriteOption{FailOnMissing()},
+			wantSheets: []string{"Sheet1", "Tmp1", "Tmp2", "Tmp3"},
+			wantErr:    true,
+		},
+		{
+			name:       "Delete all the sheets",
+			names:      []string{"Sheet1", "Tmp1", "Tmp2", "Tmp3"},
+			wantSheets: []string{"Sheet1", "Tmp1", "Tmp2", "Tmp3"},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.reset()
+			fake.seedSheet("Tmp1", nil)
+			fake.seedSheet("Tmp2", nil)
+			fake.seedSheet("Tmp3", nil)
+			resetClient()
+
+			err := client.DeleteSheets(tt.names, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DeleteSheets() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && fake.callCount() != tt.wantCalls {
+				t.Errorf("DeleteSheets() made %d API calls, want %d", fake.callCount(), tt.wantCalls)
+			}
+
+			var got []string
+			for _, sheet := range fake.spreadsheets["SPREADSHEET_ID"].sheets {
+				got = append(got, sheet.properties.Title)
+			}
+			if !reflect.DeepEqual(got, tt.wantSheets) {
+				t.Errorf("DeleteSheets() left sheets %v, want %v", got, tt.wantSheets)
+			}
+		})
+	}
+}
+
+func TestUseFirstSheet(t *testing.T) {
+	fake.reset()
+	fake.seedSheet("Sheet2", [][]interface{}{{"Other"}})
+	t.Cleanup(fake.reset)
+	t.Cleanup(resetClient)
+
+	resetClient()
+	client.UseFirstSheet()
+
+	data, err := client.ReadData("A1:B2")
+	if err != nil {
+		t.Fatalf("ReadData() error = %v", err)
+	}
+	want := [][]interface{}{{"Header1", "Header2"}, {"Value1", "Value2"}}
+	if !reflect.DeepEqual(data, want) {
+		t.Errorf("ReadData() = %v, want %v", data, want)
+	}
+
+	err = client.AppendData([][]interface{}{{"Value3", "Value4"}}, "A1")
+	if err != nil {
+		t.Fatalf("AppendData() error = %v", err)
+	}
+	if got := len(fake.sheet("Sheet1").values); got != 3 {
+		t.Errorf("first sheet has %d rows after AppendData(), want 3", got)
+	}
+
+	// The call after the first sheet is renamed fails, the next one resolves the new title
+	fake.sheet("Sheet1").properties.Title = "Renamed"
+	_, err = client.ReadData("A1:B2")
+	if err == nil {
+		t.Fatalf("ReadData() after rename error = nil, want error")
+	}
+	data, err = client.ReadData("A1:A1")
+	if err != nil {
+		t.Fatalf("ReadData() after retry error = %v", err)
+	}
+	if want := [][]interface{}{{"Header1"}}; !reflect.DeepEqual(data, want) {
+		t.Errorf("ReadData() after retry = %v, want %v", data, want)
+	}
+
+	client.SetSheetName("Sheet2")
+	data, err = client.ReadData("A1")
+	if err != nil {
+		t.Fatalf("ReadData() after SetSheetName error = %v", err)
+	}
+	if want := [][]interface{}{{"Other"}}; !reflect.DeepEqual(data, want) {
+		t.Errorf("ReadData() after SetSheetName = %v, want %v", data, want)
+	}
+}
+
+func TestAppendToDatedSheet(t *testing.T) {
+	t.Cleanup(fake.reset)
+	day := time.Date(2024, 6, 1, 15, 4, 5, 0, time.UTC)
+
+	// Test cases
+	tests := []struct {
+		name       string
+		existing   [][]interface{}
+		layout     string
+		headers    []string
+		wantValues [][]interface{}
+		wantFrozen int64
+		wantErr    bool
+	}{
+		{
+			name:       "Missing sheet added with a frozen header row",
+			layout:     time.DateOnly,
+			headers:    []string{"Time", "Event"},
+			wantValues: [][]interface{}{{"Time", "Event"}, {"10:00", "login"}},
+			wantFrozen: 1,
+		},
+		{
+			name:       "Existing sheet keeps its rows",
+			existing:   [][]interface{}{{"Time", "Event"}, {"09:00", "start"}},
+			layout:     time.DateOnly,
+			headers:    []string{"Time", "Event"},
+			wantValues: [][]interface{}{{"Time", "Event"}, {"09:00", "start"}, {"10:00", "login"}},
+		},
+		{
+			name:       "Missing sheet added without a header row",
+			layout:     time.DateOnly,
+			wantValues: [][]interface{}{{"10:00", "login"}},
+		},
+		{
+			name:    "Empty layout",
+			layout:  "",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.reset()
+			if tt.existing != nil {
+				fake.seedSheet("2024-06-01", tt.existing)
+			}
+			resetClient()
+
+			err := client.AppendToDatedSheet([][]interface{}{{"10:00", "login"}}, day, tt.layout, tt.headers)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AppendToDatedSheet() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			sheet := fake.sheet("2024-06-01")
+			if sheet == nil {
+				t.Fatalf("sheet 2024-06-01 not found")
+			}
+			if !reflect.DeepEqual(sheet.values, tt.wantValues) {
+				t.Errorf("values = %v, want %v", sheet.values, tt.wantValues)
+			}
+			if got := sheet.properties.GridProperties.FrozenRowCount; got != tt.wantFrozen {
+				t.Errorf("frozen rows = %d, want %d", got, tt.wantFrozen)
+			}
+			if client.sheetName != "Sheet1" {
+				t.Errorf("current sheet = %q, want it unchanged", client.sheetName)
+			}
+		})
+	}
+}
+
+func TestAppendToDatedSheetAddFailure(t *testing.T) {
+	fake.reset()
+	t.Cleanup(fake.reset)
+	resetClient()
+
+	// A 400 other than a sheet name conflict is returned at once instead of resolving the sheet again
+	fake.batchUpdateErr = &fakeError{http.StatusBadRequest, "Invalid requests[1].updateCells: GridCoordinate.sheetId is invalid."}
+	calls := fake.callCount()
+	err := client.AppendToDatedSheet([][]interface{}{{"event"}}, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), time.DateOnly, []string{"Event"})
+	if StatusCode(err) != http.StatusBadRequest {
+		t.Fatalf("AppendToDatedSheet() error = %v, want a 400 error", err)
+	}
+	if got := fake.callCount() - calls; got != 2 {
+		t.Errorf("AppendToDatedSheet() made %d API calls, want 2 (list the sheets, add the sheet)", got)
+	}
+}
+
+func TestAppendToDatedSheetConcurrent(t *testing.T) {
+	fake.reset()
+	t.Cleanup(fake.reset)
+	resetClient()
+
+	// The callers that lose the race to add the sheet must append to the one added by the winner
+	const callers = 4
+	day := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	errs := make(chan error, callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			errs <- client.AppendToDatedSheet([][]interface{}{{"event"}}, day, time.DateOnly, []string{"Event"})
+		}()
+	}
+	for i := 0; i < callers; i++ {
+		if err := <-errs; err != nil {
+			t.Errorf("AppendToDatedSheet() error = %v", err)
+		}
+	}
+
+	sheet := fake.sheet("2024-06-01")
+	if sheet == nil {
+		t.Fatalf("sheet 2024-06-01 not found")
+	}
+	if len(sheet.values) != callers+1 || sheet.values[0][0] != "Event" {
+		t.Errorf("values = %v, want the header row and %d rows", sheet.values, callers)
+	}
+}
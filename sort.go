@@ -0,0 +1,108 @@
+package gosheets
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// SortSpec is a sort key of the rows of a sheet, for MaintainSortedUnique.
+//
+//   - The Column field is the column letter to sort by (e.g., "A").
+//   - The Descending field sorts the rows from the largest value to the smallest instead.
+type SortSpec struct {
+	Column     string
+	Descending bool
+}
+
+// toSortSpecs converts sort keys to the sort specs of a SortRangeRequest.
+//
+// Parameters:
+//   - specs: The sort keys, the first one taking precedence.
+//
+// Returns:
+//   - The sort specs, or an error if a column is not a column letter.
+func toSortSpecs(specs []SortSpec) ([]*sheets.SortSpec, error) {
+	converted := make([]*sheets.SortSpec, len(specs))
+	for i, spec := range specs {
+		column, row, err := parseA1Cell(spec.Column)
+		if err != nil || column == -1 || row != -1 {
+			return nil, fmt.Errorf("invalid sort column %q: use a column letter (e.g., \"A\")", spec.Column)
+		}
+
+		order := "ASCENDING"
+		if spec.Descending {
+			order = "DESCENDING"
+		}
+		converted[i] = &sheets.SortSpec{
+			DimensionIndex:  column,
+			SortOrder:       order,
+			ForceSendFields: []string{"DimensionIndex"}, // 0 is the valid index of column A
+		}
+	}
+	return converted, nil
+}
+
+// MaintainSortedUnique keeps a reference data sheet deduplicated and sorted: it appends to the current set sheet in
+// the GoogleSheetsClient struct the rows whose key is not in the sheet yet (see AppendDataIdempotent), then sorts
+// the data rows. It takes a read of the key column, an append when there are new rows and a sort. The header row is
+// assumed to be the first row of the sheet and is never sorted, and the rows are sorted whole, across every column.
+//
+// The sort runs after the append even when no row is new, so a call retried after a failed sort leaves the sheet
+// sorted.
+//
+// Parameters:
+//   - newRows: The rows to add, starting at column A. Their keys are compared exactly with the keys in the sheet,
+//     and a key repeated in newRows is only appended once.
+//   - keyColumn: The column letter of the keys (e.g., "A").
+//   - sortSpecs: The sort keys, the first one taking precedence, or nil to sort by the key column in ascending
+//     order.
+//
+// Returns:
+//   - An error if a column is not a column letter, a row has no key, or there was a problem appending or sorting the
+//     rows, nil otherwise.
+func (gs *GoogleSheetsClient) MaintainSortedUnique(newRows [][]interface{}, keyColumn string, sortSpecs []SortSpec) error {
+	column, row, err := parseA1Cell(keyColumn)
+	if err != nil || column == -1 || row != -1 {
+		return fmt.Errorf("invalid key column %q: use a column letter (e.g., \"A\")", keyColumn)
+	}
+
+	if len(sortSpecs) == 0 {
+		sortSpecs = []SortSpec{{Column: keyColumn}}
+	}
+	specs, err := toSortSpecs(sortSpecs)
+	if err != nil {
+		return err
+	}
+
+	appended, _, err := gs.AppendDataIdempotent(newRows, keyColumn, int(column))
+	if err != nil {
+		return err
+	}
+
+	sheetID, err := gs.getSheetID()
+	if err != nil {
+		return fmt.Errorf("unable to retrieve sheet ID: %w", err)
+	}
+
+	batchUpdateRequest := &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{{
+			SortRange: &sheets.SortRangeRequest{
+				Range:     a1Range{StartRow: 1, EndRow: -1, StartColumn: 0, EndColumn: -1}.gridRange(sheetID), // Below the header row
+				SortSpecs: specs,
+			},
+		}},
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	_, err = gs.service.Spreadsheets.BatchUpdate(gs.spreadsheetID, batchUpdateRequest).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("unable to sort the rows after appending %d rows: %w", appended, gs.apiError(err))
+	}
+
+	gs.audit("MaintainSortedUnique", gs.sheetName, "data rows", fmt.Sprintf("%d rows appended, sorted by %d columns", appended, len(specs)))
+	return nil
+}
@@ -0,0 +1,75 @@
+package gosheets
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMaintainSortedUnique(t *testing.T) {
+	t.Cleanup(fake.reset)
+
+	// Test cases
+	tests := []struct {
+		name      string
+		newRows   [][]interface{}
+		keyColumn string
+		sortSpecs []SortSpec
+		want      [][]interface{}
+		wantErr   bool
+	}{
+		{
+			name:      "New keys are appended and sorted below the header",
+			newRows:   [][]interface{}{{"FR", "France"}, {"BE", "Belgium"}, {"DE", "Germany again"}, {"BE", "Belgium again"}},
+			keyColumn: "A",
+			want: [][]interface{}{
+				{"Code", "Country"}, {"BE", "Belgium"}, {"DE", "Germany"}, {"ES", "Spain"}, {"FR", "France"}, {"IT", "Italy"},
+			},
+		},
+		{
+			name:      "Sort by another column, descending",
+			newRows:   [][]interface{}{{"AT", "Austria"}},
+			keyColumn: "A",
+			sortSpecs: []SortSpec{{Column: "B", Descending: true}},
+			want: [][]interface{}{
+				{"Code", "Country"}, {"ES", "Spain"}, {"IT", "Italy"}, {"DE", "Germany"}, {"AT", "Austria"},
+			},
+		},
+		{
+			name:      "No new rows still sorts",
+			newRows:   [][]interface{}{{"IT", "Italy"}},
+			keyColumn: "A",
+			want:      [][]interface{}{{"Code", "Country"}, {"DE", "Germany"}, {"ES", "Spain"}, {"IT", "Italy"}},
+		},
+		{
+			name:      "Invalid sort column",
+			newRows:   [][]interface{}{{"AT", "Austria"}},
+			keyColumn: "A",
+			sortSpecs: []SortSpec{{Column: "B2"}},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.reset()
+			fake.seedSheet("Countries", [][]interface{}{{"Code", "Country"}, {"IT", "Italy"}, {"DE", "Germany"}, {"ES", "Spain"}})
+			resetClient()
+			client.SetSheetName("Countries")
+
+			err := client.MaintainSortedUnique(tt.newRows, tt.keyColumn, tt.sortSpecs)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MaintainSortedUnique() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if len(fake.sheet("Countries").values) != 4 {
+					t.Errorf("MaintainSortedUnique() changed the sheet despite the error")
+				}
+				return
+			}
+
+			if got := fake.sheet("Countries").values; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("sheet values = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
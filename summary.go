@@ -0,0 +1,202 @@
+package gosheets
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// summaryRowKey is the key of the developer metadata that marks the summary row written by WriteSummaryRow. The
+// metadata is attached to the row, so it moves with it when rows are inserted or deleted above it.
+const summaryRowKey = "gosheets.summaryRow"
+
+// summaryAggregates are the aggregate functions accepted by WriteSummaryRow.
+var summaryAggregates = []string{"SUM", "AVERAGE", "COUNT", "MAX", "MIN"}
+
+// WriteSummaryRow writes a bold summary row below the data of the current set sheet in the GoogleSheetsClient
+// struct, with a formula per column aggregating the data rows (e.g., =SUM(C2:C120)), so the totals follow later
+// edits of the data. The header row is assumed to be the first row of the sheet.
+//
+// The summary row is marked with developer metadata. Running it again (e.g., after appending more rows, which land
+// below the previous summary row) deletes the previous summary row and writes a new one below the data, so summary
+// rows do not stack up.
+//
+// Parameters:
+//   - aggregates: The aggregate function of each column to summarize, by header (e.g., {"Amount": "SUM", "Price":
+//     "AVERAGE"}). The functions are SUM, AVERAGE, COUNT, MAX and MIN, in any case. The other cells of the row are
+//     left empty.
+//
+// Returns:
+//   - ErrHeaderNotFound if a header is not in the header row.
+//   - An error if an aggregate function is not supported, the sheet has no data rows or there was a problem writing
+//     the row, nil otherwise.
+func (gs *GoogleSheetsClient) WriteSummaryRow(aggregates map[string]string) error {
+	if len(aggregates) == 0 {
+		return fmt.Errorf("no aggregates to write")
+	}
+
+	functions := make(map[string]string, len(aggregates))
+	for header, aggregate := range aggregates {
+		function := strings.ToUpper(strings.TrimSpace(aggregate))
+		if !slices.Contains(summaryAggregates, function) {
+			return fmt.Errorf("unsupported aggregate %q for header %q: use one of %s", aggregate, header, strings.Join(summaryAggregates, ", "))
+		}
+		functions[header] = function
+	}
+
+	err := gs.requireScope(writeScopes...)
+	if err != nil {
+		return err
+	}
+
+	properties, err := gs.getSheetProperties()
+	if err != nil {
+		return fmt.Errorf("unable to retrieve sheet ID: %w", err)
+	}
+	sheetID := properties.SheetId
+
+	previous, err := gs.summaryRows(sheetID)
+	if err != nil {
+		return err
+	}
+
+	data, err := gs.ReadUsedRange()
+	if err != nil {
+		return err
+	}
+
+	// The last data row once the previous summary rows are deleted, as a 0-based index
+	lastRow := int64(-1)
+	for i := len(data) - 1; i >= 0; i-- {
+		if !slices.Contains(previous, int64(i)) && slices.ContainsFunc(data[i], func(cell interface{}) bool { return cell != nil && cell != "" }) {
+			lastRow = int64(i)
+			break
+		}
+	}
+	for _, row := range previous {
+		if row < lastRow {
+			lastRow--
+		}
+	}
+	if lastRow < 1 {
+		return fmt.Errorf("the sheet has no data rows to summarize")
+	}
+
+	var headers []string
+	if len(data) > 0 {
+		for _, cell := range data[0] {
+			headers = append(headers, fmt.Sprintf("%v", cell))
+		}
+	}
+
+	summaryRow := lastRow + 1
+	cells := make([]*sheets.CellData, len(headers))
+	for header, function := range functions {
+		column := slices.Index(headers, header)
+		if column == -1 {
+			return fmt.Errorf("%w: %q", ErrHeaderNotFound, header)
+		}
+
+		letter := columnLetter(column)
+		formula := fmt.Sprintf("=%s(%s2:%s%d)", function, letter, letter, lastRow+1)
+		cells[column] = &sheets.CellData{UserEnteredValue: &sheets.ExtendedValue{FormulaValue: &formula}}
+	}
+	for len(cells) > 0 && cells[len(cells)-1] == nil {
+		cells = cells[:len(cells)-1] // The range clears the cells after the last formula
+	}
+	for i := range cells {
+		if cells[i] == nil {
+			cells[i] = &sheets.CellData{}
+		}
+	}
+
+	// Delete the previous summary rows from the bottom up, so the indexes of the next ones do not change
+	var requests []*sheets.Request
+	slices.Sort(previous)
+	for _, row := range slices.Backward(previous) {
+		requests = append(requests, &sheets.Request{
+			DeleteDimension: &sheets.DeleteDimensionRequest{
+				Range: &sheets.DimensionRange{SheetId: sheetID, Dimension: "ROWS", StartIndex: row, EndIndex: row + 1},
+			},
+		})
+	}
+
+	if rowCount := properties.GridProperties.RowCount - int64(len(previous)); summaryRow >= rowCount {
+		requests = append(requests, &sheets.Request{
+			AppendDimension: &sheets.AppendDimensionRequest{SheetId: sheetID, Dimension: "ROWS", Length: summaryRow - rowCount + 1},
+		})
+	}
+
+	rowRange := a1Range{StartRow: summaryRow, EndRow: summaryRow + 1, EndColumn: -1}
+	requests = append(requests,
+		&sheets.Request{
+			UpdateCells: &sheets.UpdateCellsRequest{
+				Range:  rowRange.gridRange(sheetID),
+				Rows:   []*sheets.RowData{{Values: cells}},
+				Fields: "userEnteredValue",
+			},
+		},
+		&sheets.Request{
+			RepeatCell: &sheets.RepeatCellRequest{
+				Range:  rowRange.gridRange(sheetID),
+				Cell:   &sheets.CellData{UserEnteredFormat: &sheets.CellFormat{TextFormat: &sheets.TextFormat{Bold: true}}},
+				Fields: "userEnteredFormat.textFormat.bold",
+			},
+		},
+		&sheets.Request{
+			CreateDeveloperMetadata: &sheets.CreateDeveloperMetadataRequest{
+				DeveloperMetadata: &sheets.DeveloperMetadata{
+					MetadataKey: summaryRowKey,
+					Location: &sheets.DeveloperMetadataLocation{
+						DimensionRange: &sheets.DimensionRange{SheetId: sheetID, Dimension: "ROWS", StartIndex: summaryRow, EndIndex: summaryRow + 1},
+					},
+					Visibility: "DOCUMENT",
+				},
+			},
+		},
+	)
+
+	batchUpdateRequest := &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: requests,
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	_, err = gs.service.Spreadsheets.BatchUpdate(gs.spreadsheetID, batchUpdateRequest).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("unable to write summary row: %w", gs.apiError(err))
+	}
+
+	gs.audit("WriteSummaryRow", gs.sheetName, fmt.Sprintf("row %d", summaryRow+1), fmt.Sprintf("%d columns", len(functions)))
+	return nil
+}
+
+// summaryRows finds the summary rows written by WriteSummaryRow in a sheet of the spreadsheet set in the
+// GoogleSheetsClient struct.
+//
+// Parameters:
+//   - sheetID: The ID of the sheet.
+//
+// Returns:
+//   - The 0-based indexes of the summary rows, or an error if there was a problem searching them.
+func (gs *GoogleSheetsClient) summaryRows(sheetID int64) ([]int64, error) {
+	matches, err := gs.searchDeveloperMetadata(&sheets.DeveloperMetadataLookup{
+		MetadataKey:  summaryRowKey,
+		LocationType: "ROW",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to find the summary row: %w", err)
+	}
+
+	var rows []int64
+	for _, metadata := range matches {
+		if location := metadata.Location; location != nil && location.DimensionRange != nil && location.DimensionRange.SheetId == sheetID {
+			rows = append(rows, location.DimensionRange.StartIndex)
+		}
+	}
+	return rows, nil
+}
@@ -0,0 +1,95 @@
+package gosheets
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestWriteSummaryRow(t *testing.T) {
+	t.Cleanup(fake.reset)
+
+	data := [][]interface{}{{"Item", "Quantity", "Price"}, {"Pen", "1", "2"}, {"Ink", "3", "4"}}
+
+	// Test cases
+	tests := []struct {
+		name       string
+		aggregates map[string]string
+		appended   [][]interface{} // Appended after a first summary row, before writing it again
+		wantValues [][]interface{}
+		wantErr    bool
+		wantErrIs  error
+	}{
+		{
+			name:       "Write the summary row below the data",
+			aggregates: map[string]string{"Quantity": "SUM", "Price": "average"},
+			wantValues: append(data[:3:3], []interface{}{nil, "=SUM(B2:B3)", "=AVERAGE(C2:C3)"}),
+		},
+		{
+			name:       "Replace the summary row after an append",
+			aggregates: map[string]string{"Quantity": "MAX"},
+			appended:   [][]interface{}{{"Cap", "5", "6"}},
+			wantValues: append(data[:3:3], []interface{}{"Cap", "5", "6"}, []interface{}{nil, "=MAX(B2:B4)"}),
+		},
+		{
+			name:       "Unknown header",
+			aggregates: map[string]string{"Total": "SUM"},
+			wantErr:    true,
+			wantErrIs:  ErrHeaderNotFound,
+		},
+		{
+			name:       "Unsupported aggregate",
+			aggregates: map[string]string{"Quantity": "MEDIAN"},
+			wantErr:    true,
+		},
+		{
+			name:       "No aggregates",
+			aggregates: map[string]string{},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.reset()
+			fake.seedSheet("Sales", data[:3:3])
+			resetClient()
+			client.SetSheetName("Sales")
+
+			if tt.appended != nil {
+				if err := client.WriteSummaryRow(tt.aggregates); err != nil {
+					t.Fatalf("first WriteSummaryRow() error = %v", err)
+				}
+				if err := client.AppendData(tt.appended, "A1"); err != nil {
+					t.Fatalf("AppendData() error = %v", err)
+				}
+			}
+
+			err := client.WriteSummaryRow(tt.aggregates)
+			if (err != nil) != tt.wantErr || (tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs)) {
+				t.Fatalf("WriteSummaryRow() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			sheet := fake.sheet("Sales")
+			if !reflect.DeepEqual(sheet.values, tt.wantValues) {
+				t.Errorf("values = %v, want %v", sheet.values, tt.wantValues)
+			}
+
+			summaryRow := int64(len(tt.wantValues) - 1)
+			if cell := sheet.cells[[2]int64{summaryRow, 0}]; cell == nil || cell.UserEnteredFormat.TextFormat == nil || !cell.UserEnteredFormat.TextFormat.Bold {
+				t.Errorf("summary row %d is not bold", summaryRow+1)
+			}
+
+			rows, err := client.summaryRows(sheet.properties.SheetId)
+			if err != nil {
+				t.Fatalf("summaryRows() error = %v", err)
+			}
+			if want := []int64{summaryRow}; !reflect.DeepEqual(rows, want) {
+				t.Errorf("summary rows = %v, want %v", rows, want)
+			}
+		})
+	}
+}
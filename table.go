@@ -0,0 +1,66 @@
+package gosheets
+
+import "fmt"
+
+// SetTableRange sets the default range of the current set sheet in the GoogleSheetsClient struct: the region of
+// the table of the sheet, with the header in its first row. The methods that take a range use it when they are
+// given an empty range (e.g., ReadData(""), AppendData(data, "")), and ignore it when they are given an explicit one.
+// Each sheet of each spreadsheet has its own table range.
+//
+// Parameters:
+//   - rangeA1: The range of the table (e.g., "A1:H"), or an empty string to remove the table range of the sheet.
+//
+// Returns:
+//   - An error if the range is not valid A1 notation, nil otherwise.
+func (gs *GoogleSheetsClient) SetTableRange(rangeA1 string) error {
+	key := [2]string{gs.spreadsheetID, gs.sheetName}
+	if rangeA1 == "" {
+		delete(gs.tableRanges, key)
+		return nil
+	}
+
+	_, err := parseA1Range(rangeA1)
+	if err != nil {
+		return err
+	}
+
+	if gs.tableRanges == nil {
+		gs.tableRanges = map[[2]string]string{}
+	}
+	gs.tableRanges[key] = rangeA1
+	return nil
+}
+
+// TableRange returns the table range of the current set sheet in the GoogleSheetsClient struct (see SetTableRange),
+// or an empty string if it has none.
+func (gs *GoogleSheetsClient) TableRange() string {
+	return gs.tableRanges[[2]string{gs.spreadsheetID, gs.sheetName}]
+}
+
+// rangeOrTable returns a range given to a method, or the table range of a sheet if the range is empty.
+//
+// Parameters:
+//   - sheetName: The name of the sheet the range refers to.
+//   - rangeA1: The range given to the method.
+//
+// Returns:
+//   - The range to use, or an error if the range is empty and the sheet has no table range.
+func (gs *GoogleSheetsClient) rangeOrTable(sheetName, rangeA1 string) (string, error) {
+	if rangeA1 != "" {
+		return rangeA1, nil
+	}
+
+	if sheetName == "" && gs.firstSheet {
+		err := gs.resolveSheetName()
+		if err != nil {
+			return "", err
+		}
+		sheetName = gs.sheetName
+	}
+
+	tableRange, ok := gs.tableRanges[[2]string{gs.spreadsheetID, sheetName}]
+	if !ok {
+		return "", fmt.Errorf("no range given and no table range set for sheet %q, see SetTableRange", sheetName)
+	}
+	return tableRange, nil
+}
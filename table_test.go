@@ -0,0 +1,82 @@
+package gosheets
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTableRange(t *testing.T) {
+	fake.reset()
+	t.Cleanup(fake.reset)
+	fake.seedSheet("Inventory", [][]interface{}{
+		{"SKU", "Stock", "Notes"},
+		{"A-1", "4", "fragile"},
+	})
+
+	resetClient()
+	client.SetSheetName("Inventory")
+	t.Cleanup(func() {
+		client.SetSheetName("Inventory")
+		client.SetTableRange("")
+	})
+
+	if err := client.SetTableRange("B2:A1"); err == nil {
+		t.Fatalf("SetTableRange() with an invalid range error = nil, want an error")
+	}
+	if _, err := client.ReadData(""); err == nil {
+		t.Fatalf("ReadData() without a table range error = nil, want an error")
+	}
+	if err := client.SetTableRange("A1:B"); err != nil {
+		t.Fatalf("SetTableRange() error = %v", err)
+	}
+	if got := client.TableRange(); got != "A1:B" {
+		t.Fatalf("TableRange() = %q, want %q", got, "A1:B")
+	}
+
+	err := client.AppendData([][]interface{}{{"B-2", "7"}}, "")
+	if err != nil {
+		t.Fatalf("AppendData() error = %v", err)
+	}
+
+	// Test cases
+	tests := []struct {
+		name      string
+		sheetName string
+		readRange string
+		want      [][]interface{}
+		wantErr   bool
+	}{
+		{
+			name:      "Empty range reads the table range",
+			sheetName: "Inventory",
+			readRange: "",
+			want:      [][]interface{}{{"SKU", "Stock"}, {"A-1", "4"}, {"B-2", "7"}},
+		},
+		{
+			name:      "Explicit range ignores the table range",
+			sheetName: "Inventory",
+			readRange: "A1:C2",
+			want:      [][]interface{}{{"SKU", "Stock", "Notes"}, {"A-1", "4", "fragile"}},
+		},
+		{
+			name:      "Other sheet has no table range",
+			sheetName: "Sheet1",
+			readRange: "",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client.SetSheetName(tt.sheetName)
+
+			got, err := client.ReadData(tt.readRange)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadData() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadData() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
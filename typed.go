@@ -0,0 +1,206 @@
+package gosheets
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/sheets/v4"
+)
+
+// CellKind is the type of the value of a TypedCell.
+type CellKind int
+
+const (
+	// KindEmpty is the kind of the cells without a value.
+	KindEmpty CellKind = iota
+	// KindNumber is the kind of the cells with a number not formatted as a date or time.
+	KindNumber
+	// KindText is the kind of the cells with text.
+	KindText
+	// KindBool is the kind of the cells with a boolean (e.g., checkboxes).
+	KindBool
+	// KindDate is the kind of the cells with a number formatted as a date, a time or both.
+	KindDate
+	// KindError is the kind of the cells whose formula evaluates to an error (e.g., #DIV/0!).
+	KindError
+)
+
+// String returns the name of the kind (e.g., "Number").
+func (k CellKind) String() string {
+	switch k {
+	case KindEmpty:
+		return "Empty"
+	case KindNumber:
+		return "Number"
+	case KindText:
+		return "Text"
+	case KindBool:
+		return "Bool"
+	case KindDate:
+		return "Date"
+	case KindError:
+		return "Error"
+	}
+	return fmt.Sprintf("CellKind(%d)", int(k))
+}
+
+// TypedCell is the value of a cell with its type, as read by ReadTyped. The accessors return the value of the cell
+// and whether the cell is of the kind they read, so the callers do not need type assertions.
+type TypedCell struct {
+	kind      CellKind
+	value     interface{} // A float64, string, bool or time.Time, depending on the kind
+	formatted string
+}
+
+// Kind returns the type of the value of the cell.
+func (c TypedCell) Kind() CellKind {
+	return c.kind
+}
+
+// IsEmpty reports whether the cell has no value.
+func (c TypedCell) IsEmpty() bool {
+	return c.kind == KindEmpty
+}
+
+// Number returns the value of a number cell, and whether the cell is one. Date cells are not numbers: use Time.
+func (c TypedCell) Number() (float64, bool) {
+	number, ok := c.value.(float64)
+	return number, ok && c.kind == KindNumber
+}
+
+// Text returns the value of a text cell, and whether the cell is one.
+func (c TypedCell) Text() (string, bool) {
+	text, ok := c.value.(string)
+	return text, ok && c.kind == KindText
+}
+
+// Bool returns the value of a boolean cell, and whether the cell is one.
+func (c TypedCell) Bool() (bool, bool) {
+	b, ok := c.value.(bool)
+	return b, ok
+}
+
+// Time returns the value of a date cell in the time zone of the spreadsheet, and whether the cell is one. The cells
+// formatted as a time only are on 1899-12-30, the day 0 of spreadsheets.
+func (c TypedCell) Time() (time.Time, bool) {
+	t, ok := c.value.(time.Time)
+	return t, ok
+}
+
+// ErrorMessage returns the message of the error of a cell whose formula fails (e.g., "Function DIVIDE parameter 2
+// cannot be zero."), and whether the cell is one.
+func (c TypedCell) ErrorMessage() (string, bool) {
+	message, ok := c.value.(string)
+	return message, ok && c.kind == KindError
+}
+
+// Value returns the value of the cell as a float64, string, bool or time.Time depending on its kind, the error
+// message for error cells, or nil for empty cells.
+func (c TypedCell) Value() interface{} {
+	return c.value
+}
+
+// Formatted returns the value of the cell as shown in the sheet (e.g., "$1,200.00" or "1/31/2024"), empty for empty
+// cells.
+func (c TypedCell) Formatted() string {
+	return c.formatted
+}
+
+// typedCellFields is the field mask of the cell data read into a TypedCell.
+const typedCellFields = "effectiveValue,effectiveFormat.numberFormat.type,formattedValue"
+
+// ReadTyped reads a range of the current set sheet in the GoogleSheetsClient struct with the type of every cell, so
+// the callers get numbers, text, booleans, dates and formula errors apart without coercing the values themselves.
+// The dates are told apart from the numbers by the number format of the cells, read in the same request together
+// with the time zone of the spreadsheet.
+//
+// Parameters:
+//   - readRange: The range of cells to read (e.g., "A1:D100"), or an empty string to use the table range of the sheet
+//     (see SetTableRange), or the whole sheet without one.
+//
+// Returns:
+//   - A 2D slice of TypedCell values, indexed from the first cell of the range. Like ReadData, the trailing empty rows
+//     and the trailing empty cells of each row are omitted; the empty cells before a value are KindEmpty cells.
+//   - An error if there was a problem reading the range, nil otherwise.
+func (gs *GoogleSheetsClient) ReadTyped(readRange string) ([][]TypedCell, error) {
+	readRange, err := gs.rangeOrTable(gs.sheetName, readRange)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateClientFields(gs)
+	if err != nil {
+		return nil, err
+	}
+
+	rangeA1 := quoteSheetName(gs.sheetName)
+	if readRange != "" {
+		rangeA1 += "!" + readRange
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	spreadsheet, err := gs.service.Spreadsheets.Get(gs.spreadsheetID).
+		Ranges(rangeA1).
+		IncludeGridData(true).
+		Fields("properties.timeZone", googleapi.Field("sheets(data(rowData(values("+typedCellFields+"))))")).
+		Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve grid data from Google Sheets: %w", gs.apiError(err))
+	}
+
+	loc, err := time.LoadLocation(spreadsheet.Properties.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the time zone %q of the spreadsheet: %w", spreadsheet.Properties.TimeZone, err)
+	}
+
+	if len(spreadsheet.Sheets) == 0 || len(spreadsheet.Sheets[0].Data) == 0 {
+		return [][]TypedCell{}, nil
+	}
+
+	rowData := spreadsheet.Sheets[0].Data[0].RowData
+	cells := make([][]TypedCell, len(rowData))
+	for i, row := range rowData {
+		cells[i] = make([]TypedCell, len(row.Values))
+		for j, cellData := range row.Values {
+			cells[i][j] = typedCell(cellData, loc)
+		}
+	}
+	return cells, nil
+}
+
+// typedCell converts the cell data read by ReadTyped to a TypedCell.
+//
+// Parameters:
+//   - cellData: The cell data, with the fields of typedCellFields.
+//   - loc: The time zone of the spreadsheet, for the date cells.
+//
+// Returns:
+//   - The typed cell.
+func typedCell(cellData *sheets.CellData, loc *time.Location) TypedCell {
+	cell := TypedCell{formatted: cellData.FormattedValue}
+
+	value := cellData.EffectiveValue
+	switch {
+	case value == nil:
+		cell.kind = KindEmpty
+	case value.NumberValue != nil:
+		cell.kind, cell.value = KindNumber, *value.NumberValue
+		if format := cellData.EffectiveFormat; format != nil && format.NumberFormat != nil {
+			switch format.NumberFormat.Type {
+			case "DATE", "TIME", "DATE_TIME":
+				cell.kind, cell.value = KindDate, serialToTime(*value.NumberValue, loc)
+			}
+		}
+	case value.StringValue != nil:
+		cell.kind, cell.value = KindText, *value.StringValue
+	case value.BoolValue != nil:
+		cell.kind, cell.value = KindBool, *value.BoolValue
+	case value.ErrorValue != nil:
+		cell.kind, cell.value = KindError, value.ErrorValue.Message
+	}
+	return cell
+}
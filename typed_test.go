@@ -0,0 +1,81 @@
+package gosheets
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+func TestReadTyped(t *testing.T) {
+	t.Cleanup(fake.reset)
+
+	fake.reset()
+	fake.seedSheet("Typed", [][]interface{}{
+		{"Name", "Amount", "Paid", "Due", "Ratio"},
+		{"Pen", 2.5, true, 45292.5, "=1/0"},
+		{"Ink", nil, false, 45293.0},
+	})
+	fake.sheet("Typed").cells = map[[2]int64]*sheets.CellData{
+		{1, 3}: {UserEnteredFormat: &sheets.CellFormat{NumberFormat: &sheets.NumberFormat{Type: "DATE_TIME"}}},
+		{2, 3}: {UserEnteredFormat: &sheets.CellFormat{NumberFormat: &sheets.NumberFormat{Type: "NUMBER"}}},
+	}
+	resetClient()
+	client.SetSheetName("Typed")
+
+	got, err := client.ReadTyped("A1:E3")
+	if err != nil {
+		t.Fatalf("ReadTyped() error = %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("ReadTyped() returned %d rows, want 3", len(got))
+	}
+
+	madrid, err := time.LoadLocation("Europe/Madrid")
+	if err != nil {
+		t.Fatalf("LoadLocation() error = %v", err)
+	}
+
+	// Test cases
+	tests := []struct {
+		name      string
+		row, col  int
+		wantKind  CellKind
+		wantValue interface{}
+	}{
+		{name: "Text", row: 0, col: 0, wantKind: KindText, wantValue: "Name"},
+		{name: "Number", row: 1, col: 1, wantKind: KindNumber, wantValue: 2.5},
+		{name: "Bool", row: 1, col: 2, wantKind: KindBool, wantValue: true},
+		{name: "Date from the number format", row: 1, col: 3, wantKind: KindDate, wantValue: time.Date(2024, 1, 1, 12, 0, 0, 0, madrid)},
+		{name: "Formula error", row: 1, col: 4, wantKind: KindError, wantValue: "Function DIVIDE parameter 2 cannot be zero."},
+		{name: "Empty cell before a value", row: 2, col: 1, wantKind: KindEmpty, wantValue: nil},
+		{name: "Number with a number format", row: 2, col: 3, wantKind: KindNumber, wantValue: 45293.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cell := got[tt.row][tt.col]
+			if cell.Kind() != tt.wantKind {
+				t.Errorf("Kind() = %v, want %v", cell.Kind(), tt.wantKind)
+			}
+
+			value := cell.Value()
+			if want, ok := tt.wantValue.(time.Time); ok {
+				if gotTime, ok := cell.Time(); !ok || !gotTime.Equal(want) {
+					t.Errorf("Time() = %v, %t, want %v", gotTime, ok, want)
+				}
+				return
+			}
+			if value != tt.wantValue {
+				t.Errorf("Value() = %v, want %v", value, tt.wantValue)
+			}
+		})
+	}
+
+	if _, ok := got[1][3].Number(); ok {
+		t.Errorf("Number() of a date cell reported a number")
+	}
+	if text, ok := got[0][0].Text(); !ok || text != "Name" {
+		t.Errorf("Text() = %q, %t, want %q, true", text, ok, "Name")
+	}
+}
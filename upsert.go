@@ -0,0 +1,539 @@
+package gosheets
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// BulkUpsert writes rows to the current set sheet in the GoogleSheetsClient struct keyed on a column: the rows
+// whose key is already in the column overwrite the row holding it, and the other rows are appended. It reads the
+// key column once, writes all the updates in a single Values.BatchUpdate (grouping the rows that land on
+// consecutive rows of the sheet into one range) and appends all the new rows in a single Values.Append, so the
+// number of API calls does not depend on the number of rows.
+//
+// The first row of the sheet is the header row: the keys are searched below it, so it is never overwritten. When a
+// key appears more than once in the sheet, only its first occurrence is updated. The rows are written starting at
+// column A, so keyIndex must be the index of keyColumn in them (e.g., 2 for column "C"), and the cells of an updated
+// row after the end of the new row are left unchanged.
+//
+// Parameters:
+//   - keyColumn: The column letter of the keys in the sheet (e.g., "C").
+//   - rows: The rows to write. Each inner slice represents a row of data, starting at column A.
+//   - keyIndex: The 0-based index of the key in each row.
+//   - opts: Optional settings for the write of the rows (see AppendData), applied to the updated rows and to the
+//     appended ones alike. With WithSerialDates, the updated rows holding dates are written in a single batch
+//     update, and WithProtectionCheck checks the cells of the updated rows before writing anything.
+//
+// Returns:
+//   - The number of rows appended and the number of rows updated.
+//   - A *MultiError wrapping ErrDuplicateKey for every key that appears more than once in rows, in which case
+//     nothing is written, or an error if there was a problem writing the rows, nil otherwise. If the append fails
+//     after the updates were written, the number of updated rows is returned with the error.
+func (gs *GoogleSheetsClient) BulkUpsert(keyColumn string, rows [][]interface{}, keyIndex int, opts ...WriteOption) (int, int, error) {
+	gs = gs.startOperation() // The calls below share the retry budget of the method
+
+	if len(rows) == 0 {
+		return 0, 0, nil // Nothing to write, avoid spending an API call
+	}
+
+	err := validateClientFields(gs)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	err = gs.requireScope(writeScopes...)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	keys, err := rowKeys(rows, keyIndex)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	options := newWriteOptions(opts)
+
+	inputOption, err := gs.inputOption(options)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	column, err := gs.readColumn(keyColumn, 2) // Below the header row
+	if err != nil {
+		return 0, 0, fmt.Errorf("unable to read the key column: %w", err)
+	}
+
+	existing := make(map[string]int64)
+	for i, cell := range column {
+		if isEmptyCell(cell) {
+			continue
+		}
+		if key := fmt.Sprintf("%v", cell[0]); existing[key] == 0 {
+			existing[key] = int64(i) + 2 // Only the first occurrence of the key is updated
+		}
+	}
+
+	var updates []rowUpdate
+	var inserts [][]interface{}
+	for i, row := range rows {
+		if rowNumber, ok := existing[keys[i]]; ok {
+			updates = append(updates, rowUpdate{rowNumber: rowNumber, values: row})
+		} else {
+			inserts = append(inserts, row)
+		}
+	}
+
+	if len(updates) > 0 {
+		err = gs.updateRows(updates, options, inputOption)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
+	err = gs.appendToSheet(gs.sheetName, inserts, "A1", opts...)
+	if err != nil {
+		return 0, len(updates), fmt.Errorf("unable to append the new rows: %w", err)
+	}
+
+	gs.audit("BulkUpsert", gs.sheetName, "key column "+keyColumn, fmt.Sprintf("%d rows inserted, %d rows updated", len(inserts), len(updates)))
+	return len(inserts), len(updates), nil
+}
+
+// AppendDataIdempotent appends to the current set sheet in the GoogleSheetsClient struct only the rows whose key is
+// not in a column of the sheet yet, so retrying a job that already appended its rows does not append them twice.
+// Only the key column is read. Rows of data repeating the key of an earlier row of data are skipped too.
+//
+// It is best-effort: there is no locking, so two clients appending the same key at the same time can both append it.
+// That is enough to absorb retries of the same job, which do not run at the same time as the attempt they retry.
+//
+// Parameters:
+//   - data: The rows to append. Each inner slice represents a row of data, starting at column A.
+//   - keyColumn: The column letter of the keys in the sheet (e.g., "C").
+//   - keyIndex: The 0-based index of the key in each row.
+//   - opts: Optional settings for the write: WithMatch to change how the keys are compared with the cells (the same
+//     comparison as FindRowNumber, exact by default), and the settings of AppendData.
+//
+// Returns:
+//   - The number of rows appended and the number of rows skipped because their key already existed.
+//   - An error if a row has no key at keyIndex or there was a problem reading the keys or appending the rows,
+//     nil otherwise.
+func (gs *GoogleSheetsClient) AppendDataIdempotent(data [][]interface{}, keyColumn string, keyIndex int, opts ...WriteOption) (int, int, error) {
+	if len(data) == 0 {
+		return 0, 0, nil // Nothing to append, avoid spending an API call
+	}
+
+	options := newWriteOptions(opts)
+
+	err := validateClientFields(gs)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	err = gs.requireScope(writeScopes...)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for i, row := range data {
+		if keyIndex < 0 || keyIndex >= len(row) {
+			return 0, 0, fmt.Errorf("the row at index %d has no key at index %d", i, keyIndex)
+		}
+	}
+
+	column, err := gs.readColumn(keyColumn, 1)
+	if err != nil {
+		return 0, 0, fmt.Errorf("unable to read the key column: %w", err)
+	}
+
+	var seen []interface{}
+	exact := make(map[string]bool) // Used instead of scanning seen when the keys are compared exactly
+	for _, cell := range column {
+		if !isEmptyCell(cell) {
+			seen = append(seen, cell[0])
+			exact[fmt.Sprintf("%v", cell[0])] = true
+		}
+	}
+
+	var rows [][]interface{}
+	for _, row := range data {
+		key := fmt.Sprintf("%v", row[keyIndex])
+
+		exists := exact[key]
+		if !exists && len(options.match) > 0 {
+			matcher, err := gs.newMatcher(key, options.match)
+			if err != nil {
+				return 0, 0, err
+			}
+			exists = slices.ContainsFunc(seen, func(cell interface{}) bool { return matcher(cell) })
+		}
+		if exists {
+			continue
+		}
+
+		rows = append(rows, row)
+		seen = append(seen, row[keyIndex])
+		exact[key] = true
+	}
+
+	skipped := len(data) - len(rows)
+	if len(rows) == 0 {
+		return 0, skipped, nil
+	}
+
+	err = gs.appendToSheet(gs.sheetName, rows, "A1", opts...)
+	if err != nil {
+		return 0, 0, fmt.Errorf("unable to append the new rows: %w", err)
+	}
+
+	gs.audit("AppendDataIdempotent", gs.sheetName, "key column "+keyColumn, fmt.Sprintf("%d rows appended, %d rows skipped", len(rows), skipped))
+	return len(rows), skipped, nil
+}
+
+// appendReceiptPrefix is the prefix of the keys of the developer metadata that records the appends done by
+// AppendDataExactlyOnce, so they do not clash with the keys set with SetDeveloperMetadata.
+const appendReceiptPrefix = "gosheets.append."
+
+// AppendDataExactlyOnce appends data after the last row with data of the current set sheet in the GoogleSheetsClient
+// struct, at most once per idempotency key, so an append retried after an ambiguous failure (e.g., a timeout after
+// the request reached the API) does not duplicate the rows. The rows and a receipt with the key, stored as developer
+// metadata of the spreadsheet, are written in a single batch update: either both land or neither does, and a later
+// call with the same key finds the receipt and appends nothing.
+//
+// The receipt is checked before the append, so two clients appending with the same key at the same time can both
+// append; the key protects the retries of a single caller. Receipts are never deleted, and the developer metadata
+// of a spreadsheet is limited in size, so use one key per batch of rows rather than per row.
+//
+// Parameters:
+//   - data: A 2D slice representing the data to be added, starting at column A. time.Time values are written as
+//     dates, like with WithSerialDates.
+//   - idempotencyKey: The key that identifies the append (e.g., the ID of the job that produced the rows).
+//
+// Returns:
+//   - An error if the key is empty or there was a problem checking the receipt or appending the data, nil otherwise,
+//     including when the key was already used. Empty or nil data is a no-op that returns nil without calling the API.
+func (gs *GoogleSheetsClient) AppendDataExactlyOnce(data [][]interface{}, idempotencyKey string) error {
+	if idempotencyKey == "" {
+		return fmt.Errorf("the idempotency key must not be empty")
+	}
+
+	if len(data) == 0 {
+		return nil // Nothing to append, avoid spending an API call
+	}
+
+	err := validateClientFields(gs)
+	if err != nil {
+		return err
+	}
+
+	err = gs.requireScope(writeScopes...)
+	if err != nil {
+		return err
+	}
+
+	inputOption, err := gs.inputOption(newWriteOptions(nil))
+	if err != nil {
+		return err
+	}
+
+	receipts, err := gs.searchDeveloperMetadata(developerMetadataLookup(appendReceiptPrefix + idempotencyKey))
+	if err != nil {
+		return fmt.Errorf("unable to check the append receipt: %w", err)
+	}
+	if len(receipts) > 0 {
+		return nil // Already appended
+	}
+
+	appendRequest, err := gs.appendCellsRequest(gs.sheetName, data, "A1", inputOption)
+	if err != nil {
+		return err
+	}
+
+	receiptRequest := &sheets.Request{
+		CreateDeveloperMetadata: &sheets.CreateDeveloperMetadataRequest{
+			DeveloperMetadata: &sheets.DeveloperMetadata{
+				MetadataKey:   appendReceiptPrefix + idempotencyKey,
+				MetadataValue: time.Now().UTC().Format(time.RFC3339),
+				Location:      &sheets.DeveloperMetadataLocation{Spreadsheet: true},
+				Visibility:    "DOCUMENT",
+			},
+		},
+	}
+
+	batchUpdateRequest := &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{appendRequest, receiptRequest},
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	_, err = gs.service.Spreadsheets.BatchUpdate(gs.spreadsheetID, batchUpdateRequest).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("unable to add data to Google Sheets: %w", gs.apiError(err))
+	}
+
+	gs.audit("AppendDataExactlyOnce", gs.sheetName, "key "+idempotencyKey, fmt.Sprintf("%d rows", len(data)))
+	return nil
+}
+
+// FindOrAppend finds the row of a key in a column of the current set sheet in the GoogleSheetsClient struct, and
+// appends a new row if the key is not there yet, e.g., to get the row of a record while ingesting it only once.
+// Only the key column is read. Like AppendDataIdempotent it is best-effort: two clients appending the same key at
+// the same time can both append it.
+//
+// Parameters:
+//   - key: The key to search for.
+//   - keyColumn: The column letter of the keys in the sheet (e.g., "C").
+//   - rowIfNew: The row to append if the key is not found, which should hold the key in keyColumn.
+//   - range_: The cell used to search for existing data and find a "table" where the row is appended (e.g., "A1"),
+//     or an empty string to use the table range of the sheet (see SetTableRange).
+//   - opts: Optional settings: WithMatch to change how the key is compared with the cells (the same comparison as
+//     FindRowNumber, exact by default), and the settings of AppendData except WithSerialDates, for which the API
+//     does not report where the row was written.
+//
+// Returns:
+//   - The 1-based number of the row of the key, either the existing one or the appended one.
+//   - Whether the row was appended.
+//   - An error if rowIfNew is empty or there was a problem reading the keys or appending the row, nil otherwise.
+func (gs *GoogleSheetsClient) FindOrAppend(key string, keyColumn string, rowIfNew []interface{}, range_ string, opts ...WriteOption) (int, bool, error) {
+	options := newWriteOptions(opts)
+	if options.serialDates {
+		return 0, false, fmt.Errorf("WithSerialDates is not supported by FindOrAppend")
+	}
+
+	if len(rowIfNew) == 0 {
+		return 0, false, fmt.Errorf("the row to append for key %q is empty", key)
+	}
+
+	err := validateClientFields(gs)
+	if err != nil {
+		return 0, false, err
+	}
+
+	err = gs.requireScope(writeScopes...)
+	if err != nil {
+		return 0, false, err
+	}
+
+	matcher, err := gs.newMatcher(key, options.match)
+	if err != nil {
+		return 0, false, err
+	}
+
+	column, err := gs.readColumn(keyColumn, 1)
+	if err != nil {
+		return 0, false, fmt.Errorf("unable to read the key column: %w", err)
+	}
+
+	if row := findRow(column, "A", matcher); row != -1 { // The key column is the only one of column
+		return row, false, nil
+	}
+
+	updatedRange, err := gs.appendRows(gs.sheetName, [][]interface{}{rowIfNew}, range_, opts...)
+	if err != nil {
+		return 0, false, fmt.Errorf("unable to append the row of key %q: %w", key, err)
+	}
+	if updatedRange == "" {
+		return 0, false, fmt.Errorf("unable to locate the appended row of key %q", key) // Only empty cells in rowIfNew
+	}
+
+	row, err := firstRowOfRange(updatedRange)
+	if err != nil {
+		return 0, false, err
+	}
+
+	target, _ := gs.rangeOrTable(gs.sheetName, range_)
+	gs.audit("FindOrAppend", gs.sheetName, target, fmt.Sprintf("row %d appended for key %v", row, key))
+	return int(row), true, nil
+}
+
+// rowUpdate is a row to overwrite in the sheet.
+//
+//   - The rowNumber field is the 1-based number of the row in the sheet.
+//   - The values field is the new content of the row, starting at column A.
+type rowUpdate struct {
+	rowNumber int64
+	values    []interface{}
+}
+
+// rowKeys returns the keys of rows as strings, checking that every row has a key and that no key is repeated.
+//
+// Parameters:
+//   - rows: The rows to get the keys of.
+//   - keyIndex: The 0-based index of the key in each row.
+//
+// Returns:
+//   - The key of each row, or an error if a row has no key at keyIndex or a *MultiError wrapping ErrDuplicateKey
+//     for every repeated key.
+func rowKeys(rows [][]interface{}, keyIndex int) ([]string, error) {
+	keys := make([]string, len(rows))
+	firstIndex := make(map[string]int)
+	duplicates := &MultiError{}
+
+	for i, row := range rows {
+		if keyIndex < 0 || keyIndex >= len(row) {
+			return nil, fmt.Errorf("the row at index %d has no key at index %d", i, keyIndex)
+		}
+
+		keys[i] = fmt.Sprintf("%v", row[keyIndex])
+		if first, ok := firstIndex[keys[i]]; ok {
+			duplicates.Add(fmt.Sprintf("key %q", keys[i]), fmt.Errorf("%w: rows at indexes %d and %d", ErrDuplicateKey, first, i))
+			continue
+		}
+		firstIndex[keys[i]] = i
+	}
+
+	return keys, duplicates.ErrorOrNil()
+}
+
+// updateRows overwrites rows of the current set sheet in the GoogleSheetsClient struct with the conversions of the
+// write options applied, like the appended rows: the values are prepared with prepareValues, the rows holding dates
+// are written as dates with WithSerialDates, and WithProtectionCheck refuses to change protected cells.
+//
+// Parameters:
+//   - updates: The rows to overwrite, in any order.
+//   - options: The settings of the write.
+//   - inputOption: How the values are interpreted (InputRaw or InputUserEntered).
+//
+// Returns:
+//   - A *ProtectedRangeError if the rows overlap protected ranges and the protection is checked, an error if there
+//     was a problem writing the rows, nil otherwise.
+func (gs *GoogleSheetsClient) updateRows(updates []rowUpdate, options *writeOptions, inputOption string) error {
+	if options.protectionCheck {
+		rowNumbers := make([]int64, len(updates))
+		ranges := make([]a1Range, len(updates))
+		for i, update := range updates {
+			rowNumbers[i] = update.rowNumber
+			ranges[i] = a1Range{StartRow: update.rowNumber - 1, EndRow: update.rowNumber, StartColumn: 0, EndColumn: int64(len(update.values))}
+		}
+		err := gs.checkProtection(gs.sheetName, fmt.Sprintf("rows %v of %s", rowNumbers, quoteSheetName(gs.sheetName)), ranges...)
+		if err != nil {
+			return err
+		}
+	}
+
+	values := make([][]interface{}, len(updates))
+	for i, update := range updates {
+		values[i] = update.values
+	}
+
+	if options.serialDates {
+		dateRows, err := gs.serialDateRows(values, "A2", options)
+		if err != nil {
+			return err
+		}
+		if dateRows != nil {
+			return gs.updateDateRows(updates, dateRows)
+		}
+	}
+
+	values, err := gs.prepareValues(gs.sheetName, "A2", values, options, inputOption)
+	if err != nil {
+		return err
+	}
+
+	prepared := make([]rowUpdate, len(updates))
+	for i, update := range updates {
+		prepared[i] = rowUpdate{rowNumber: update.rowNumber, values: values[i]}
+	}
+	return gs.batchUpdateRows(prepared, inputOption)
+}
+
+// updateDateRows overwrites rows of the current set sheet in the GoogleSheetsClient struct with rows of cell data
+// holding dates (see WithSerialDates), in a single batch update with one UpdateCellsRequest per row.
+//
+// Parameters:
+//   - updates: The rows to overwrite, whose numbers are used.
+//   - rows: The cell data of each row of updates, starting at column A.
+//
+// Returns:
+//   - An error if there was a problem writing the rows, nil otherwise.
+func (gs *GoogleSheetsClient) updateDateRows(updates []rowUpdate, rows []*sheets.RowData) error {
+	sheetID, err := gs.getSheetID()
+	if err != nil {
+		return fmt.Errorf("unable to retrieve sheet ID: %w", err)
+	}
+
+	requests := make([]*sheets.Request, len(updates))
+	for i, update := range updates {
+		requests[i] = &sheets.Request{
+			UpdateCells: &sheets.UpdateCellsRequest{
+				Start:  &sheets.GridCoordinate{SheetId: sheetID, RowIndex: update.rowNumber - 1},
+				Rows:   []*sheets.RowData{rows[i]},
+				Fields: dateCellFields,
+			},
+		}
+	}
+
+	batchUpdateRequest := &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: requests,
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	_, err = gs.service.Spreadsheets.BatchUpdate(gs.spreadsheetID, batchUpdateRequest).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("unable to update rows in Google Sheets: %w", gs.apiError(err))
+	}
+	return nil
+}
+
+// batchUpdateRows overwrites rows of the current set sheet in the GoogleSheetsClient struct in a single
+// Values.BatchUpdate, with one range per group of consecutive rows.
+//
+// Parameters:
+//   - updates: The rows to overwrite, in any order.
+//   - inputOption: How the values are interpreted (InputRaw or InputUserEntered).
+//
+// Returns:
+//   - An error if there was a problem writing the rows, nil otherwise.
+func (gs *GoogleSheetsClient) batchUpdateRows(updates []rowUpdate, inputOption string) error {
+	data := groupRowUpdates(gs.sheetName, updates)
+
+	request := &sheets.BatchUpdateValuesRequest{
+		ValueInputOption: inputOption,
+		Data:             data,
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	_, err := gs.service.Spreadsheets.Values.BatchUpdate(gs.spreadsheetID, request).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("unable to update rows in Google Sheets: %w", gs.apiError(err))
+	}
+	return nil
+}
+
+// groupRowUpdates converts rows to overwrite to the ranges of a Values.BatchUpdate, with one range per group of
+// consecutive rows.
+//
+// Parameters:
+//   - sheetName: The name of the sheet of the rows.
+//   - updates: The rows to overwrite, in any order. The slice is sorted by row number.
+//
+// Returns:
+//   - The ranges to write, sorted by row number.
+func groupRowUpdates(sheetName string, updates []rowUpdate) []*sheets.ValueRange {
+	slices.SortFunc(updates, func(a, b rowUpdate) int {
+		return int(a.rowNumber - b.rowNumber)
+	})
+
+	var data []*sheets.ValueRange
+	for i, update := range updates {
+		if i > 0 && update.rowNumber == updates[i-1].rowNumber+1 {
+			last := data[len(data)-1]
+			last.Values = append(last.Values, update.values)
+			continue
+		}
+		data = append(data, &sheets.ValueRange{
+			Range:  fmt.Sprintf("%s!A%d", quoteSheetName(sheetName), update.rowNumber),
+			Values: [][]interface{}{update.values},
+		})
+	}
+	return data
+}
@@ -0,0 +1,347 @@
+package gosheets
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+func TestBulkUpsert(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name         string
+		rows         [][]interface{}
+		keyIndex     int
+		opts         []WriteOption
+		protected    string
+		wantInserted int
+		wantUpdated  int
+		wantValues   [][]interface{}
+		wantCalls    int
+		wantErr      error
+	}{
+		{
+			name:         "Updates and inserts",
+			rows:         [][]interface{}{{"b", 20.0}, {"d", 4.0}, {"a", 10.0}, {"c", 30.0}},
+			keyIndex:     0,
+			wantInserted: 1,
+			wantUpdated:  3,
+			wantValues:   [][]interface{}{{"SKU", "Qty"}, {"a", 10.0}, {"b", 20.0}, {"c", 30.0}, {"b", 9.0}, {"d", 4.0}},
+			wantCalls:    3, // Read the key column, update the rows, append the new rows
+		},
+		{
+			name:         "Only inserts",
+			rows:         [][]interface{}{{"e", 5.0}},
+			keyIndex:     0,
+			wantInserted: 1,
+			wantValues:   [][]interface{}{{"SKU", "Qty"}, {"a", 1.0}, {"b", 2.0}, {"c", 3.0}, {"b", 9.0}, {"e", 5.0}},
+			wantCalls:    2,
+		},
+		{
+			name:         "Key equal to the header",
+			rows:         [][]interface{}{{"SKU", 0.0}},
+			keyIndex:     0,
+			wantInserted: 1,
+			wantValues:   [][]interface{}{{"SKU", "Qty"}, {"a", 1.0}, {"b", 2.0}, {"c", 3.0}, {"b", 9.0}, {"SKU", 0.0}},
+			wantCalls:    2,
+		},
+		{
+			name:         "Updated rows converted like the appended ones",
+			rows:         [][]interface{}{{"a", "10"}, {"e", "5"}},
+			keyIndex:     0,
+			opts:         []WriteOption{InferTypes()},
+			wantInserted: 1,
+			wantUpdated:  1,
+			wantValues:   [][]interface{}{{"SKU", "Qty"}, {"a", 10.0}, {"b", 2.0}, {"c", 3.0}, {"b", 9.0}, {"e", 5.0}},
+			wantCalls:    3,
+		},
+		{
+			name:        "Updated rows with serial dates",
+			rows:        [][]interface{}{{"c", time.Date(2024, 5, 31, 22, 0, 0, 0, time.UTC)}}, // Midnight in Europe/Madrid
+			keyIndex:    0,
+			opts:        []WriteOption{WithSerialDates()},
+			wantUpdated: 1,
+			wantValues:  [][]interface{}{{"SKU", "Qty"}, {"a", 1.0}, {"b", 2.0}, {"c", 45444.0}, {"b", 9.0}},
+			wantCalls:   4, // Read the key column, the time zone and the sheet ID, update the rows
+		},
+		{
+			name:      "Updated row protected",
+			rows:      [][]interface{}{{"b", 20.0}, {"e", 5.0}},
+			keyIndex:  0,
+			opts:      []WriteOption{WithProtectionCheck()},
+			protected: "A3:B3",
+			wantCalls: 2, // Read the key column and the protected ranges
+			wantErr:   ErrProtected,
+		},
+		{
+			name:      "Duplicate keys in the input",
+			rows:      [][]interface{}{{"a", 10.0}, {"e", 5.0}, {"a", 11.0}},
+			keyIndex:  0,
+			wantCalls: 0,
+			wantErr:   ErrDuplicateKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.reset()
+			t.Cleanup(fake.reset)
+			// A sheet name that needs quotes in A1 notation
+			fake.seedSheet("Inventory 2024", [][]interface{}{{"SKU", "Qty"}, {"a", 1.0}, {"b", 2.0}, {"c", 3.0}, {"b", 9.0}})
+			if tt.protected != "" {
+				fake.seedProtectedRange("Inventory 2024", tt.protected, sheets.ProtectedRange{Description: "Audited"})
+			}
+			resetClient()
+			client.SetSheetName("Inventory 2024")
+
+			calls := fake.callCount()
+			inserted, updated, err := client.BulkUpsert("A", tt.rows, tt.keyIndex, tt.opts...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("BulkUpsert() error = %v, want %v", err, tt.wantErr)
+			}
+			if inserted != tt.wantInserted || updated != tt.wantUpdated {
+				t.Errorf("BulkUpsert() = %d, %d, want %d, %d", inserted, updated, tt.wantInserted, tt.wantUpdated)
+			}
+			if got := fake.callCount() - calls; got != tt.wantCalls {
+				t.Errorf("BulkUpsert() made %d API calls, want %d", got, tt.wantCalls)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got := fake.sheet("Inventory 2024").values; !reflect.DeepEqual(got, tt.wantValues) {
+				t.Errorf("BulkUpsert() left %v, want %v", got, tt.wantValues)
+			}
+		})
+	}
+}
+
+func TestAppendDataIdempotent(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name         string
+		data         [][]interface{}
+		keyIndex     int
+		opts         []WriteOption
+		wantAppended int
+		wantSkipped  int
+		wantValues   [][]interface{}
+		wantErr      bool
+	}{
+		{
+			name:         "Existing keys are skipped",
+			data:         [][]interface{}{{"a", 10.0}, {"d", 4.0}},
+			wantAppended: 1,
+			wantSkipped:  1,
+			wantValues:   [][]interface{}{{"SKU", "Qty"}, {"a", 1.0}, {"b", 2.0}, {"d", 4.0}},
+		},
+		{
+			name:         "Repeated keys in the data are appended once",
+			data:         [][]interface{}{{"d", 4.0}, {"d", 5.0}},
+			wantAppended: 1,
+			wantSkipped:  1,
+			wantValues:   [][]interface{}{{"SKU", "Qty"}, {"a", 1.0}, {"b", 2.0}, {"d", 4.0}},
+		},
+		{
+			name:        "Keys compared with match options",
+			data:        [][]interface{}{{" A ", 10.0}},
+			opts:        []WriteOption{WithMatch(IgnoreCase(), TrimSpace())},
+			wantSkipped: 1,
+			wantValues:  [][]interface{}{{"SKU", "Qty"}, {"a", 1.0}, {"b", 2.0}},
+		},
+		{
+			name:     "Row without key",
+			data:     [][]interface{}{{"d", 4.0}},
+			keyIndex: 2,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.reset()
+			t.Cleanup(fake.reset)
+			fake.seedSheet("Inventory 2024", [][]interface{}{{"SKU", "Qty"}, {"a", 1.0}, {"b", 2.0}})
+			resetClient()
+			client.SetSheetName("Inventory 2024")
+
+			appended, skipped, err := client.AppendDataIdempotent(tt.data, "A", tt.keyIndex, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AppendDataIdempotent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if appended != tt.wantAppended || skipped != tt.wantSkipped {
+				t.Errorf("AppendDataIdempotent() = %d, %d, want %d, %d", appended, skipped, tt.wantAppended, tt.wantSkipped)
+			}
+			if got := fake.sheet("Inventory 2024").values; !reflect.DeepEqual(got, tt.wantValues) {
+				t.Errorf("AppendDataIdempotent() left %v, want %v", got, tt.wantValues)
+			}
+		})
+	}
+}
+
+func TestAppendDataExactlyOnce(t *testing.T) {
+	t.Cleanup(fake.reset)
+
+	// Test cases
+	tests := []struct {
+		name       string
+		keys       []string
+		failFirst  bool
+		wantValues [][]interface{}
+		wantErr    bool
+	}{
+		{
+			name:       "First append writes the rows",
+			keys:       []string{"job-1"},
+			wantValues: [][]interface{}{{"Header1", "Header2"}, {"Value1", "Value2"}, {"New1", "New2"}},
+		},
+		{
+			name:       "Retry with the same key is a no-op",
+			keys:       []string{"job-1", "job-1"},
+			wantValues: [][]interface{}{{"Header1", "Header2"}, {"Value1", "Value2"}, {"New1", "New2"}},
+		},
+		{
+			name:       "Different keys append twice",
+			keys:       []string{"job-1", "job-2"},
+			wantValues: [][]interface{}{{"Header1", "Header2"}, {"Value1", "Value2"}, {"New1", "New2"}, {"New1", "New2"}},
+		},
+		{
+			name:       "Failed append records no receipt",
+			keys:       []string{"job-1", "job-1"},
+			failFirst:  true,
+			wantValues: [][]interface{}{{"Header1", "Header2"}, {"Value1", "Value2"}, {"New1", "New2"}},
+		},
+		{
+			name:    "Empty key",
+			keys:    []string{""},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.reset()
+			resetClient()
+
+			for i, key := range tt.keys {
+				fake.batchUpdateErr = nil
+				if tt.failFirst && i == 0 {
+					fake.batchUpdateErr = &fakeError{http.StatusInternalServerError, "Internal error encountered."}
+				}
+
+				err := client.AppendDataExactlyOnce([][]interface{}{{"New1", "New2"}}, key)
+				wantErr := tt.wantErr || (tt.failFirst && i == 0)
+				if (err != nil) != wantErr {
+					t.Fatalf("AppendDataExactlyOnce() call %d error = %v, wantErr %v", i, err, wantErr)
+				}
+			}
+			if tt.wantErr {
+				return
+			}
+
+			got := fake.sheet("Sheet1").values
+			if !reflect.DeepEqual(got, tt.wantValues) {
+				t.Errorf("values = %v, want %v", got, tt.wantValues)
+			}
+		})
+	}
+}
+
+func TestFindOrAppend(t *testing.T) {
+	t.Cleanup(fake.reset)
+
+	// Test cases
+	tests := []struct {
+		name        string
+		key         string
+		rowIfNew    []interface{}
+		opts        []WriteOption
+		wantRow     int
+		wantCreated bool
+		wantValues  [][]interface{}
+		wantErr     bool
+	}{
+		{
+			name:       "Existing key",
+			key:        "B-2",
+			rowIfNew:   []interface{}{"B-2", "new"},
+			wantRow:    3,
+			wantValues: [][]interface{}{{"ID", "Name"}, {"A-1", "first"}, {"B-2", "second"}},
+		},
+		{
+			name:        "New key appended",
+			key:         "C-3",
+			rowIfNew:    []interface{}{"C-3", "third"},
+			wantRow:     4,
+			wantCreated: true,
+			wantValues:  [][]interface{}{{"ID", "Name"}, {"A-1", "first"}, {"B-2", "second"}, {"C-3", "third"}},
+		},
+		{
+			name:       "Loose match",
+			key:        "b-2 ",
+			rowIfNew:   []interface{}{"b-2 ", "new"},
+			opts:       []WriteOption{WithMatch(IgnoreCase(), TrimSpace())},
+			wantRow:    3,
+			wantValues: [][]interface{}{{"ID", "Name"}, {"A-1", "first"}, {"B-2", "second"}},
+		},
+		{
+			name:     "Empty row",
+			key:      "C-3",
+			rowIfNew: nil,
+			wantErr:  true,
+		},
+		{
+			name:     "Serial dates",
+			key:      "C-3",
+			rowIfNew: []interface{}{"C-3", "third"},
+			opts:     []WriteOption{WithSerialDates()},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake.reset()
+			fake.seedSheet("Records", [][]interface{}{{"ID", "Name"}, {"A-1", "first"}, {"B-2", "second"}})
+			resetClient()
+			client.SetSheetName("Records")
+
+			row, created, err := client.FindOrAppend(tt.key, "A", tt.rowIfNew, "A1", tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FindOrAppend() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if row != tt.wantRow || created != tt.wantCreated {
+				t.Errorf("FindOrAppend() = %d, %v, want %d, %v", row, created, tt.wantRow, tt.wantCreated)
+			}
+			if got := fake.sheet("Records").values; !reflect.DeepEqual(got, tt.wantValues) {
+				t.Errorf("values = %v, want %v", got, tt.wantValues)
+			}
+		})
+	}
+}
+
+func TestGroupRowUpdates(t *testing.T) {
+	got := groupRowUpdates("Sheet1", []rowUpdate{
+		{rowNumber: 5, values: []interface{}{"e"}},
+		{rowNumber: 2, values: []interface{}{"b"}},
+		{rowNumber: 3, values: []interface{}{"c"}},
+	})
+
+	want := []*sheets.ValueRange{
+		{Range: "Sheet1!A2", Values: [][]interface{}{{"b"}, {"c"}}},
+		{Range: "Sheet1!A5", Values: [][]interface{}{{"e"}}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("groupRowUpdates() = %v, want %v", got, want)
+	}
+}
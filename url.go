@@ -0,0 +1,65 @@
+package gosheets
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// spreadsheetURLPrefix is the start of the URLs of the spreadsheets in the Google Sheets web application.
+const spreadsheetURLPrefix = "https://docs.google.com/spreadsheets/d/"
+
+// SpreadsheetURL returns the URL that opens the spreadsheet set in the GoogleSheetsClient struct in a browser.
+//
+// Returns:
+//   - The URL of the spreadsheet, or an empty string if the spreadsheet ID is not set.
+func (gs *GoogleSheetsClient) SpreadsheetURL() string {
+	if gs.spreadsheetID == "" {
+		return ""
+	}
+	return spreadsheetURLPrefix + url.PathEscape(gs.spreadsheetID) + "/edit"
+}
+
+// SheetURL returns the URL that opens the spreadsheet set in the GoogleSheetsClient struct on the current set sheet.
+// The URL identifies the sheet by its ID (the gid), which is cached after it is first read.
+//
+// Returns:
+//   - The URL of the sheet, or an error if the ID of the sheet could not be resolved.
+func (gs *GoogleSheetsClient) SheetURL() (string, error) {
+	sheetID, err := gs.getSheetID()
+	if err != nil {
+		return "", fmt.Errorf("unable to retrieve sheet ID: %w", err)
+	}
+	return sheetURL(gs.spreadsheetID, sheetID), nil
+}
+
+// RangeURL returns the URL that opens the spreadsheet set in the GoogleSheetsClient struct with a range of the
+// current set sheet selected, e.g., to link an alert to the row it is about.
+//
+// Parameters:
+//   - a1: The range to select, without the sheet name (e.g., "B7:D9", "B7" or "7:7" for a whole row).
+//
+// Returns:
+//   - The URL of the range, or an error if the range is not valid or the ID of the sheet could not be resolved.
+func (gs *GoogleSheetsClient) RangeURL(a1 string) (string, error) {
+	_, err := parseA1Range(a1)
+	if err != nil {
+		return "", err
+	}
+
+	sheetID, err := gs.getSheetID()
+	if err != nil {
+		return "", fmt.Errorf("unable to retrieve sheet ID: %w", err)
+	}
+	return rangeURL(gs.spreadsheetID, sheetID, a1), nil
+}
+
+// sheetURL builds the URL of a sheet of a spreadsheet.
+func sheetURL(spreadsheetID string, sheetID int64) string {
+	return fmt.Sprintf("%s%s/edit#gid=%d", spreadsheetURLPrefix, url.PathEscape(spreadsheetID), sheetID)
+}
+
+// rangeURL builds the URL of a range of a sheet of a spreadsheet. A valid A1 range only holds letters, digits, "$"
+// and ":", so it needs no escaping.
+func rangeURL(spreadsheetID string, sheetID int64, a1 string) string {
+	return sheetURL(spreadsheetID, sheetID) + "&range=" + a1
+}
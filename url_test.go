@@ -0,0 +1,88 @@
+package gosheets
+
+import (
+	"testing"
+)
+
+func TestURLs(t *testing.T) {
+	fake.reset()
+	t.Cleanup(fake.reset)
+	fake.seedSheet("Alerts", nil)
+
+	// Test cases
+	tests := []struct {
+		name      string
+		sheetName string
+		url       func() (string, error)
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:      "Spreadsheet",
+			sheetName: "Alerts",
+			url:       func() (string, error) { return client.SpreadsheetURL(), nil },
+			want:      "https://docs.google.com/spreadsheets/d/SPREADSHEET_ID/edit",
+		},
+		{
+			name:      "Sheet",
+			sheetName: "Alerts",
+			url:       client.SheetURL,
+			want:      "https://docs.google.com/spreadsheets/d/SPREADSHEET_ID/edit#gid=1",
+		},
+		{
+			name:      "Range",
+			sheetName: "Alerts",
+			url:       func() (string, error) { return client.RangeURL("B7:D9") },
+			want:      "https://docs.google.com/spreadsheets/d/SPREADSHEET_ID/edit#gid=1&range=B7:D9",
+		},
+		{
+			name:      "Row",
+			sheetName: "Sheet1",
+			url:       func() (string, error) { return client.RangeURL("7:7") },
+			want:      "https://docs.google.com/spreadsheets/d/SPREADSHEET_ID/edit#gid=0&range=7:7",
+		},
+		{
+			name:      "Invalid range",
+			sheetName: "Alerts",
+			url:       func() (string, error) { return client.RangeURL("D9:B7") },
+			wantErr:   true,
+		},
+		{
+			name:      "Non-existent sheet",
+			sheetName: "Missing",
+			url:       client.SheetURL,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetClient()
+			client.SetSheetName(tt.sheetName)
+
+			got, err := tt.url()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("url() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("url() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("Sheet ID is cached", func(t *testing.T) {
+		resetClient()
+		client.SetSheetName("Alerts")
+
+		if _, err := client.SheetURL(); err != nil {
+			t.Fatalf("SheetURL() error = %v", err)
+		}
+		calls := fake.callCount()
+		if _, err := client.RangeURL("A1"); err != nil {
+			t.Fatalf("RangeURL() error = %v", err)
+		}
+		if fake.callCount() != calls {
+			t.Errorf("RangeURL() made %d API calls, want 0", fake.callCount()-calls)
+		}
+	})
+}
@@ -0,0 +1,252 @@
+package gosheets
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// SetCheckboxes turns the cells of a range of the current set sheet in the GoogleSheetsClient struct into checkboxes,
+// by applying a BOOLEAN data validation to them. Checked boxes hold TRUE and unchecked boxes FALSE; the existing
+// values that are not booleans are kept but shown as invalid.
+//
+// Parameters:
+//   - rangeA1: The range of cells to turn into checkboxes (e.g., "C2:C100").
+//
+// Returns:
+//   - An error if the range is not valid or there was a problem applying the validation, nil otherwise.
+func (gs *GoogleSheetsClient) SetCheckboxes(rangeA1 string) error {
+	rule := &sheets.DataValidationRule{
+		Condition: &sheets.BooleanCondition{Type: "BOOLEAN"},
+		Strict:    true,
+	}
+
+	err := gs.setDataValidation(rangeA1, rule)
+	if err != nil {
+		return err
+	}
+
+	gs.audit("SetCheckboxes", gs.sheetName, rangeA1, "checkboxes set")
+	return nil
+}
+
+// SetCustomCheckboxes turns the cells of a range of the current set sheet in the GoogleSheetsClient struct into
+// checkboxes like SetCheckboxes, but the checked and unchecked boxes hold the given values instead of TRUE and FALSE.
+//
+// Parameters:
+//   - rangeA1: The range of cells to turn into checkboxes (e.g., "C2:C100").
+//   - checked: The value of the checked boxes (e.g., "yes").
+//   - unchecked: The value of the unchecked boxes (e.g., "no").
+//
+// Returns:
+//   - An error if the values are empty or equal, the range is not valid or there was a problem applying the
+//     validation, nil otherwise.
+func (gs *GoogleSheetsClient) SetCustomCheckboxes(rangeA1, checked, unchecked string) error {
+	if checked == "" || unchecked == "" {
+		return fmt.Errorf("the checked and unchecked values must not be empty")
+	}
+	if checked == unchecked {
+		return fmt.Errorf("the checked and unchecked values must be different, both are %q", checked)
+	}
+
+	rule := &sheets.DataValidationRule{
+		Condition: &sheets.BooleanCondition{
+			Type: "BOOLEAN",
+			Values: []*sheets.ConditionValue{
+				{UserEnteredValue: checked},
+				{UserEnteredValue: unchecked},
+			},
+		},
+		Strict: true,
+	}
+
+	err := gs.setDataValidation(rangeA1, rule)
+	if err != nil {
+		return err
+	}
+
+	gs.audit("SetCustomCheckboxes", gs.sheetName, rangeA1, "checkboxes set")
+	return nil
+}
+
+// SetNumberValidation restricts the cells of a range of the current set sheet in the GoogleSheetsClient struct to
+// numbers between a minimum and a maximum, both included. Values outside the bounds are rejected. Use math.Inf to
+// leave a side unbounded (e.g., min 0 and max math.Inf(1) for "number >= 0").
+//
+// Google Sheets never flags empty cells, so allowBlank cannot reject them: when it is false, the rule only shows a
+// help message telling users that the cells are required.
+//
+// Parameters:
+//   - rangeA1: The range of cells to validate (e.g., "D2:D100").
+//   - min: The smallest valid number, or math.Inf(-1) for no minimum.
+//   - max: The largest valid number, or math.Inf(1) for no maximum.
+//   - allowBlank: Whether the cells may be left empty.
+//
+// Returns:
+//   - An error if the bounds are not valid, the range is not valid or there was a problem applying the validation,
+//     nil otherwise.
+func (gs *GoogleSheetsClient) SetNumberValidation(rangeA1 string, min, max float64, allowBlank bool) error {
+	if math.IsNaN(min) || math.IsNaN(max) || min > max || (math.IsInf(min, 0) && math.IsInf(max, 0)) {
+		return fmt.Errorf("invalid bounds [%v, %v], set at least one finite bound and keep min <= max", min, max)
+	}
+
+	formatNumber := func(number float64) *sheets.ConditionValue {
+		return &sheets.ConditionValue{UserEnteredValue: strconv.FormatFloat(number, 'f', -1, 64)}
+	}
+
+	condition := &sheets.BooleanCondition{}
+	switch {
+	case math.IsInf(min, -1):
+		condition.Type = "NUMBER_LESS_THAN_EQ"
+		condition.Values = []*sheets.ConditionValue{formatNumber(max)}
+	case math.IsInf(max, 1):
+		condition.Type = "NUMBER_GREATER_THAN_EQ"
+		condition.Values = []*sheets.ConditionValue{formatNumber(min)}
+	default:
+		condition.Type = "NUMBER_BETWEEN"
+		condition.Values = []*sheets.ConditionValue{formatNumber(min), formatNumber(max)}
+	}
+
+	rule := &sheets.DataValidationRule{Condition: condition, Strict: true}
+	if !allowBlank {
+		rule.InputMessage = "Required"
+	}
+
+	err := gs.setDataValidation(rangeA1, rule)
+	if err != nil {
+		return err
+	}
+
+	gs.audit("SetNumberValidation", gs.sheetName, rangeA1, fmt.Sprintf("numbers between %v and %v", min, max))
+	return nil
+}
+
+// SetDateValidation restricts the cells of a range of the current set sheet in the GoogleSheetsClient struct to
+// dates between two dates, both included. Values that are not dates or are outside the bounds are rejected. Only
+// the date of the bounds is used, in their own time zone.
+//
+// Parameters:
+//   - rangeA1: The range of cells to validate (e.g., "E2:E100").
+//   - after: The earliest valid date, or the zero time.Time for no earliest date.
+//   - before: The latest valid date, or the zero time.Time for no latest date.
+//
+// Returns:
+//   - An error if both bounds are zero or after is later than before, the range is not valid or there was a
+//     problem applying the validation, nil otherwise.
+func (gs *GoogleSheetsClient) SetDateValidation(rangeA1 string, after, before time.Time) error {
+	if after.IsZero() && before.IsZero() {
+		return fmt.Errorf("no date bounds given, set after, before or both")
+	}
+	if !after.IsZero() && !before.IsZero() && after.After(before) {
+		return fmt.Errorf("invalid date bounds, %s is after %s", after.Format(time.DateOnly), before.Format(time.DateOnly))
+	}
+
+	formatDate := func(date time.Time) *sheets.ConditionValue {
+		return &sheets.ConditionValue{UserEnteredValue: date.Format(time.DateOnly)}
+	}
+
+	condition := &sheets.BooleanCondition{}
+	switch {
+	case after.IsZero():
+		condition.Type = "DATE_ON_OR_BEFORE"
+		condition.Values = []*sheets.ConditionValue{formatDate(before)}
+	case before.IsZero():
+		condition.Type = "DATE_ON_OR_AFTER"
+		condition.Values = []*sheets.ConditionValue{formatDate(after)}
+	default:
+		condition.Type = "DATE_BETWEEN"
+		condition.Values = []*sheets.ConditionValue{formatDate(after), formatDate(before)}
+	}
+
+	err := gs.setDataValidation(rangeA1, &sheets.DataValidationRule{Condition: condition, Strict: true})
+	if err != nil {
+		return err
+	}
+
+	gs.audit("SetDateValidation", gs.sheetName, rangeA1, fmt.Sprintf("dates of type %s", condition.Type))
+	return nil
+}
+
+// GetValidation reads the data validation rules of the cells of a range of the current set sheet in the
+// GoogleSheetsClient struct, to check the rules applied with the Set*Validation and Set*Checkboxes methods. A cell
+// has at most one rule, so cells of overlapping ranges show the rule applied last, and cells that were expected to
+// share a rule but hold different ones reveal a conflict.
+//
+// Parameters:
+//   - rangeA1: The range of cells to read (e.g., "D2:E100").
+//
+// Returns:
+//   - A 2D slice with the rule of each cell, indexed from the start of the range, nil for the cells without a rule.
+//     Like ReadData, trailing cells and rows without a rule may be omitted.
+//   - An error if the range is not valid or there was a problem reading the rules, nil otherwise.
+func (gs *GoogleSheetsClient) GetValidation(rangeA1 string) ([][]*sheets.DataValidationRule, error) {
+	_, err := parseA1Range(rangeA1)
+	if err != nil {
+		return nil, err
+	}
+
+	gridData, err := gs.getGridData(rangeA1, "rowData(values(dataValidation))")
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([][]*sheets.DataValidationRule, len(gridData.RowData))
+	for i, rowData := range gridData.RowData {
+		rules[i] = make([]*sheets.DataValidationRule, len(rowData.Values))
+		for j, cellData := range rowData.Values {
+			rules[i][j] = cellData.DataValidation
+		}
+	}
+	return rules, nil
+}
+
+// setDataValidation applies a data validation rule to a range of the current set sheet in the GoogleSheetsClient
+// struct, replacing the rules the cells had.
+//
+// Parameters:
+//   - rangeA1: The range of cells to validate (e.g., "A1:B2").
+//   - rule: The data validation rule, or nil to remove the rules of the cells.
+//
+// Returns:
+//   - An error if the range is not valid or there was a problem applying the rule, nil otherwise.
+func (gs *GoogleSheetsClient) setDataValidation(rangeA1 string, rule *sheets.DataValidationRule) error {
+	parsedRange, err := parseA1Range(rangeA1)
+	if err != nil {
+		return err
+	}
+
+	err = gs.requireScope(writeScopes...)
+	if err != nil {
+		return err
+	}
+
+	sheetID, err := gs.getSheetID()
+	if err != nil {
+		return fmt.Errorf("unable to retrieve sheet ID: %w", err)
+	}
+
+	request := &sheets.Request{
+		SetDataValidation: &sheets.SetDataValidationRequest{
+			Range: parsedRange.gridRange(sheetID),
+			Rule:  rule,
+		},
+	}
+
+	batchUpdateRequest := &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{request},
+	}
+
+	ctx, cancel := gs.withTimeout(context.Background())
+	defer cancel()
+
+	_, err = gs.service.Spreadsheets.BatchUpdate(gs.spreadsheetID, batchUpdateRequest).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("unable to set data validation: %w", gs.apiError(err))
+	}
+
+	return nil
+}
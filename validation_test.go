@@ -0,0 +1,190 @@
+package gosheets
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSetCheckboxes(t *testing.T) {
+	fake.reset()
+	t.Cleanup(fake.reset)
+
+	// Test cases
+	tests := []struct {
+		name          string
+		rangeA1       string
+		checked       string
+		unchecked     string
+		wantCondition string
+		wantValues    []string
+		wantErr       bool
+	}{
+		{
+			name:          "Default checkboxes",
+			rangeA1:       "A2:B3",
+			wantCondition: "BOOLEAN",
+		},
+		{
+			name:          "Custom checked and unchecked values",
+			rangeA1:       "C2:C3",
+			checked:       "yes",
+			unchecked:     "no",
+			wantCondition: "BOOLEAN",
+			wantValues:    []string{"yes", "no"},
+		},
+		{
+			name:      "Equal custom values",
+			rangeA1:   "C2:C3",
+			checked:   "yes",
+			unchecked: "yes",
+			wantErr:   true,
+		},
+		{
+			name:    "Invalid range",
+			rangeA1: "A0:B3",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetClient()
+
+			var err error
+			if tt.checked != "" {
+				err = client.SetCustomCheckboxes(tt.rangeA1, tt.checked, tt.unchecked)
+			} else {
+				err = client.SetCheckboxes(tt.rangeA1)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SetCheckboxes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			gridData, err := client.getGridData(tt.rangeA1, "rowData(values(dataValidation))")
+			if err != nil {
+				t.Fatalf("getGridData() error = %v", err)
+			}
+			for _, rowData := range gridData.RowData {
+				for _, cell := range rowData.Values {
+					rule := cell.DataValidation
+					if rule == nil || rule.Condition.Type != tt.wantCondition {
+						t.Fatalf("DataValidation = %+v, want a %s condition", rule, tt.wantCondition)
+					}
+					if len(rule.Condition.Values) != len(tt.wantValues) {
+						t.Fatalf("DataValidation values = %v, want %v", rule.Condition.Values, tt.wantValues)
+					}
+					for i, value := range rule.Condition.Values {
+						if value.UserEnteredValue != tt.wantValues[i] {
+							t.Errorf("DataValidation value %d = %v, want %v", i, value.UserEnteredValue, tt.wantValues[i])
+						}
+					}
+				}
+			}
+			if len(gridData.RowData) != 2 {
+				t.Errorf("getGridData() returned %d rows with validation, want 2", len(gridData.RowData))
+			}
+		})
+	}
+}
+
+func TestNumberAndDateValidation(t *testing.T) {
+	fake.reset()
+	t.Cleanup(fake.reset)
+
+	day := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	// Test cases
+	tests := []struct {
+		name       string
+		operation  func() error
+		rangeA1    string
+		wantType   string
+		wantValues []string
+		wantErr    bool
+	}{
+		{
+			name:       "Number between bounds",
+			operation:  func() error { return client.SetNumberValidation("D2:D3", 1, 9.5, true) },
+			rangeA1:    "D2:D3",
+			wantType:   "NUMBER_BETWEEN",
+			wantValues: []string{"1", "9.5"},
+		},
+		{
+			name:       "Number without maximum",
+			operation:  func() error { return client.SetNumberValidation("D2:D3", 0, math.Inf(1), false) },
+			rangeA1:    "D2:D3",
+			wantType:   "NUMBER_GREATER_THAN_EQ",
+			wantValues: []string{"0"},
+		},
+		{
+			name:       "Date between bounds",
+			operation:  func() error { return client.SetDateValidation("E2:E3", day(2024, 1, 1), day(2025, 12, 31)) },
+			rangeA1:    "E2:E3",
+			wantType:   "DATE_BETWEEN",
+			wantValues: []string{"2024-01-01", "2025-12-31"},
+		},
+		{
+			name:       "Date without earliest date",
+			operation:  func() error { return client.SetDateValidation("E2:E3", time.Time{}, day(2025, 12, 31)) },
+			rangeA1:    "E2:E3",
+			wantType:   "DATE_ON_OR_BEFORE",
+			wantValues: []string{"2025-12-31"},
+		},
+		{
+			name:      "Unbounded number",
+			operation: func() error { return client.SetNumberValidation("D2:D3", math.Inf(-1), math.Inf(1), true) },
+			wantErr:   true,
+		},
+		{
+			name:      "Minimum above maximum",
+			operation: func() error { return client.SetNumberValidation("D2:D3", 5, 1, true) },
+			wantErr:   true,
+		},
+		{
+			name:      "Dates in the wrong order",
+			operation: func() error { return client.SetDateValidation("E2:E3", day(2025, 1, 1), day(2024, 1, 1)) },
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetClient()
+
+			err := tt.operation()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("operation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			rules, err := client.GetValidation(tt.rangeA1)
+			if err != nil {
+				t.Fatalf("GetValidation() error = %v", err)
+			}
+			if len(rules) != 2 {
+				t.Fatalf("GetValidation() returned %d rows, want 2", len(rules))
+			}
+			for _, row := range rules {
+				if len(row) != 1 || row[0] == nil || row[0].Condition.Type != tt.wantType {
+					t.Fatalf("GetValidation() row = %+v, want a %s rule", row, tt.wantType)
+				}
+				var values []string
+				for _, value := range row[0].Condition.Values {
+					values = append(values, value.UserEnteredValue)
+				}
+				if !reflect.DeepEqual(values, tt.wantValues) {
+					t.Errorf("GetValidation() values = %v, want %v", values, tt.wantValues)
+				}
+			}
+		})
+	}
+}